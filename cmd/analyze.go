@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"strconv"
+	"os/signal"
 	"strings"
+	"sync/atomic"
 	"time"
 
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lakshaymaurya-felt/purewin/internal/analyze"
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -25,72 +29,185 @@ func init() {
 	analyzeCmd.Flags().Int("depth", 0, "Maximum directory depth to display")
 	analyzeCmd.Flags().String("min-size", "", "Minimum size to display (e.g., 100MB)")
 	analyzeCmd.Flags().StringSlice("exclude", nil, "Directories to exclude from scan")
+	analyzeCmd.Flags().String("export", "", "Scan without the TUI and write the tree to a JSON file")
+	analyzeCmd.Flags().String("import", "", "Load a previously exported JSON tree instead of scanning")
+	analyzeCmd.Flags().Bool("fresh", false, "Ignore any cached scan and rescan")
+	analyzeCmd.Flags().String("output", "", "Print results without the TUI: tree, plain, or json")
+	analyzeCmd.Flags().String("export-ncdu", "", "Scan without the TUI and write an ncdu-compatible JSON file")
+	analyzeCmd.Flags().String("old-after", "6mo", "Age after which entries are tagged as old (e.g. 90d, 6mo, 1y)")
+	analyzeCmd.Flags().Bool("show-links", false, "Show junctions and symlinks (with their targets) instead of hiding them")
+	analyzeCmd.Flags().Bool("follow-junctions", false, "Scan inside junctions and symlinks whose targets are not already part of the scan")
+	analyzeCmd.Flags().Bool("no-mouse", false, "Disable mouse support in the TUI")
+	analyzeCmd.Flags().Int64("max-entries", 0, "Stop scanning after this many entries and show what was found (0 = no limit)")
+	analyzeCmd.Flags().Duration("max-time", 0, "Stop scanning after this long and show what was found, e.g. 2m (0 = no limit)")
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) {
-	// Determine target path (default: user home).
-	target := ""
-	if len(args) > 0 {
-		target = args[0]
+	// Parse exclude list, falling back to settings.
+	settings := loadSettings()
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	if !cmd.Flags().Changed("exclude") {
+		exclude = settings.AnalyzeExclude
 	}
-	if target == "" {
-		home, err := os.UserHomeDir()
+
+	// Parse depth and min-size flags.
+	depth, _ := cmd.Flags().GetInt("depth")
+	minSizeStr, _ := cmd.Flags().GetString("min-size")
+	var minSize int64
+	if minSizeStr != "" {
+		size, err := core.ParseSize(minSizeStr)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: --min-size: %v\n", err)
 			os.Exit(1)
 		}
-		target = home
+		minSize = size
 	}
 
-	// Validate the path exists.
-	if _, err := os.Stat(target); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: cannot access %s: %v\n", target, err)
+	oldAfter, _ := cmd.Flags().GetString("old-after")
+	threshold, err := core.ParseAge(oldAfter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --old-after: %v\n", err)
 		os.Exit(1)
 	}
+	analyze.OldThreshold = threshold
 
-	// Parse exclude list.
-	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	// Cached scans never include links, so --show-links and
+	// --follow-junctions always rescan.
+	showLinks, _ := cmd.Flags().GetBool("show-links")
+	followLinks, _ := cmd.Flags().GetBool("follow-junctions")
+	withLinks := showLinks || followLinks
 
-	// Parse depth and min-size flags.
-	depth, _ := cmd.Flags().GetInt("depth")
-	minSizeStr, _ := cmd.Flags().GetString("min-size")
-	minSize := parseMinSize(minSizeStr)
+	// Limits guard against accidentally scanning a whole drive.
+	maxEntries, _ := cmd.Flags().GetInt64("max-entries")
+	maxTime, _ := cmd.Flags().GetDuration("max-time")
+	newScanner := func() *analyze.Scanner {
+		return analyze.NewScanner(8, exclude).
+			WithReparsePoints(showLinks).
+			WithFollowJunctions(followLinks).
+			WithLimits(maxEntries, maxTime)
+	}
 
-	// Try loading from cache first.
-	root, err := analyze.LoadCache(target)
-	if err != nil {
-		// No valid cache — run a fresh scan with a progress spinner.
-		scanner := analyze.NewScanner(8, exclude)
-
-		done := make(chan struct{})
-		go func() {
-			frame := 0
-			ticker := time.NewTicker(100 * time.Millisecond)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-done:
-					return
-				case <-ticker.C:
-					frame = (frame + 1) % len(ui.SpinnerFrames)
-					count := scanner.ScannedCount()
-					fmt.Fprintf(os.Stderr, "\r  %s Scanning %s … %d entries",
-						ui.SpinnerFrames[frame], target, count)
-				}
-			}
-		}()
+	exportPath, _ := cmd.Flags().GetString("export")
+	importPath, _ := cmd.Flags().GetString("import")
+	ncduPath, _ := cmd.Flags().GetString("export-ncdu")
+	output, _ := cmd.Flags().GetString("output")
+	output = strings.ToLower(strings.TrimSpace(output))
 
-		root, err = scanner.Scan(target)
-		close(done)
-		fmt.Fprint(os.Stderr, "\r\033[K") // clear spinner line
+	// Piped or redirected output can't host the TUI — default to a tree.
+	if output == "" && !ui.IsTerminal() {
+		output = "tree"
+	}
+	switch output {
+	case "", "tree", "plain", "json":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: invalid --output %q (use tree, plain, or json)\n", output)
+		os.Exit(1)
+	}
 
+	var root *analyze.DirEntry
+	var truncated string // why a limited scan stopped early
+	var deniedCount int64
+	var elevateArgs []string // set when an elevated rescan would see more
+	if importPath != "" {
+		// Analyze a tree captured elsewhere — no scan needed.
+		imported, err := analyze.ImportJSON(importPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		root = imported
+	} else {
+		target, err := resolveAnalyzeTarget(args)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error scanning: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		// Persist results for next time.
-		_ = analyze.SaveCache(root, target)
+		if exportPath != "" {
+			// Non-interactive: always scan fresh, write the tree, and exit.
+			root = scanWithSpinner(target, newScanner())
+			if err := analyze.ExportJSON(root, exportPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Exported %s (%s) to %s\n",
+				root.Path, ui.FormatSizePlain(root.Size), exportPath)
+			return
+		}
+
+		if ncduPath != "" {
+			// ncdu needs the skipped list so junctions show up as excluded.
+			scanner := newScanner()
+			root = scanWithSpinner(target, scanner)
+			if err := analyze.ExportNcdu(root, scanner.Skipped(), appVersion, ncduPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Exported %s (%s) to %s — open with: ncdu -f %s\n",
+				root.Path, ui.FormatSizePlain(root.Size), ncduPath, ncduPath)
+			return
+		}
+
+		// Cache location and lifetime come from config; fall back to
+		// defaults rather than refusing to analyze.
+		cacheDir, cacheTTL := "", config.DefaultAnalyzeCacheTTL
+		if cfg, cfgErr := config.Load(); cfgErr == nil {
+			cacheDir, cacheTTL = cfg.CacheDir, cfg.AnalyzeCacheDuration()
+		}
+
+		// Offer a recent cached scan unless --fresh was given.
+		if fresh, _ := cmd.Flags().GetBool("fresh"); !fresh && !withLinks {
+			cached, scannedAt, cacheErr := analyze.LoadCache(cacheDir, target, cacheTTL)
+			if cacheErr == nil {
+				root = cached
+				if ui.IsTerminal() {
+					use, _ := ui.Confirm(fmt.Sprintf("Use cached scan of %s from %s ago?",
+						target, formatCacheAge(time.Since(scannedAt))))
+					if !use {
+						root = nil
+					}
+				}
+			}
+		}
+
+		if root == nil {
+			// No cache (or declined) — run a fresh scan with a progress spinner.
+			scanner := newScanner()
+			root = scanWithSpinner(target, scanner)
+			if scanner.NeedsElevation() && !core.IsElevated() {
+				deniedCount = scanner.DeniedCount()
+				elevateArgs = analyzeElevatedArgs(cmd, target)
+			}
+			truncated = scanner.Truncated()
+
+			// Persist results for next time, keeping the cache link-free
+			// and complete: a scan cut short by a limit, or missing what
+			// only an elevated scan can read, is not saved.
+			if !withLinks && truncated == "" && elevateArgs == nil {
+				_ = analyze.SaveCache(cacheDir, root, target)
+			}
+		}
+	}
+
+	// Only the TUI can offer the rescan key; other outputs get a note.
+	if elevateArgs != nil && output != "" {
+		fmt.Fprintf(os.Stderr, "Note: %d entries could not be read — run pw --admin analyze for complete totals.\n",
+			deniedCount)
+	}
+
+	switch output {
+	case "tree":
+		analyze.PrintStaticTree(root, depth, minSize)
+		return
+	case "plain":
+		analyze.PrintPlainList(root, depth, minSize)
+		return
+	case "json":
+		if err := analyze.WriteJSON(os.Stdout, root); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
 	// Interactive TUI requires VT processing for ANSI cursor positioning.
@@ -104,47 +221,138 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 	}
 
 	// Launch the TUI.
-	model := analyze.NewAnalyzeModel(root, depth, minSize)
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	model := analyze.NewAnalyzeModel(root, depth, minSize).
+		WithExclude(exclude).
+		WithReparsePoints(showLinks).
+		WithFollowJunctions(followLinks).
+		WithElevatedRescan(deniedCount, elevateArgs).
+		WithLimits(maxEntries, maxTime).
+		WithTruncated(truncated).
+		WithPermanentDelete(settings.DeleteMode == config.DeleteModePermanent)
+	if _, err := ui.RunProgram(model, tuiOptions(cmd, settings)...); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-// parseMinSize parses a human-readable size string (e.g., "100MB", "1GB") into bytes.
-// Returns 0 if the string is empty or invalid.
-// parseMinSize parses a human-readable size string (e.g., "100MB", "1GB") into bytes.
-// Returns 0 if the string is empty or invalid. Supported suffixes: B, KB, MB, GB, TB.
-func parseMinSize(s string) int64 {
-	if s == "" {
-		return 0
-	}
-	s = strings.TrimSpace(strings.ToUpper(s))
-
-	var multiplier int64 = 1
-	if strings.HasSuffix(s, "TB") {
-		multiplier = 1024 * 1024 * 1024 * 1024
-		s = strings.TrimSuffix(s, "TB")
-	} else if strings.HasSuffix(s, "GB") {
-		multiplier = 1024 * 1024 * 1024
-		s = strings.TrimSuffix(s, "GB")
-	} else if strings.HasSuffix(s, "MB") {
-		multiplier = 1024 * 1024
-		s = strings.TrimSuffix(s, "MB")
-	} else if strings.HasSuffix(s, "KB") {
-		multiplier = 1024
-		s = strings.TrimSuffix(s, "KB")
-	} else if strings.HasSuffix(s, "B") {
-		s = strings.TrimSuffix(s, "B")
-	}
-
-	s = strings.TrimSpace(s)
-
-	// Use strconv.ParseFloat for strict parsing — rejects trailing chars like "100M".
-	value, err := strconv.ParseFloat(s, 64)
-	if err != nil || value < 0 {
-		return 0
-	}
-	return int64(value * float64(multiplier))
+// resolveAnalyzeTarget returns the path to scan (default: user home) after
+// verifying it exists.
+func resolveAnalyzeTarget(args []string) (string, error) {
+	target := ""
+	if len(args) > 0 {
+		target = args[0]
+	}
+	if target == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		target = home
+	}
+
+	// Validate the path exists.
+	if _, err := os.Stat(target); err != nil {
+		return "", fmt.Errorf("cannot access %s: %w", target, err)
+	}
+	return target, nil
+}
+
+// scanWithSpinner scans target with scanner while showing a live entry
+// count on stderr, and notes on stderr if a limit truncated the scan.
+// Exits the process on scan failure or when Ctrl+C stops the scan.
+func scanWithSpinner(target string, scanner *analyze.Scanner) *analyze.DirEntry {
+	// Ctrl+C stops the scan's goroutines instead of leaving them running.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// A quick sample of the top two levels turns the count into a rough
+	// progress bar once it is ready.
+	var estimate atomic.Int64
+	go func() {
+		estimate.Store(scanner.EstimateEntries(ctx, target))
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		frame := 0
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				frame = (frame + 1) % len(ui.SpinnerFrames)
+				count := scanner.ScannedCount()
+				if est := estimate.Load(); est > 0 {
+					pct := analyze.ScanProgress(count, est) * 100
+					fmt.Fprintf(os.Stderr, "\r  %s Scanning %s %s ~%2.0f%% · %d entries\033[K",
+						ui.SpinnerFrames[frame], target, ui.SolidBar(pct, 20, ui.ColorPrimary), pct, count)
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "\r  %s Scanning %s … %d entries",
+					ui.SpinnerFrames[frame], target, count)
+			}
+		}
+	}()
+
+	root, err := scanner.Scan(ctx, target)
+	close(done)
+	fmt.Fprint(os.Stderr, "\r\033[K") // clear spinner line
+
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "Scan canceled after %d entries.\n", scanner.ScannedCount())
+		os.Exit(130) // conventional exit status for Ctrl+C
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error scanning: %v\n", err)
+		os.Exit(1)
+	}
+	if note := scanner.Truncated(); note != "" {
+		fmt.Fprintf(os.Stderr, "Note: %s — totals cover only what was scanned.\n", note)
+	}
+	return root
+}
+
+// analyzeElevatedPassthroughFlags are the analyze flags forwarded to an
+// elevated rescan so it shows the same view.
+var analyzeElevatedPassthroughFlags = []string{
+	"depth", "min-size", "exclude", "old-after", "show-links", "follow-junctions", "no-mouse",
+	"max-entries", "max-time",
+}
+
+// analyzeElevatedArgs builds the arguments for re-running this analysis
+// elevated. The rescan is always fresh: the cached tree is the incomplete one.
+func analyzeElevatedArgs(cmd *cobra.Command, target string) []string {
+	if strings.ContainsAny(target, " \t") {
+		// A trailing backslash would escape the closing quote.
+		target = `"` + strings.TrimRight(target, `\`) + `"`
+	}
+	args := []string{"analyze", target, "--fresh"}
+	for _, name := range analyzeElevatedPassthroughFlags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			value := strings.Trim(f.Value.String(), "[]")
+			if strings.ContainsAny(value, " \t") {
+				value = `"` + value + `"`
+			}
+			args = append(args, fmt.Sprintf("--%s=%s", name, value))
+		}
+	}
+	return args
+}
+
+// formatCacheAge formats the age of a cached scan in human-readable form.
+func formatCacheAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "less than a minute"
+	case d < 2*time.Minute:
+		return "1 minute"
+	case d < time.Hour:
+		return fmt.Sprintf("%d minutes", int(d.Minutes()))
+	case d < 2*time.Hour:
+		return "1 hour"
+	default:
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	}
 }
@@ -1,15 +1,21 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
+	"github.com/lakshaymaurya-felt/purewin/internal/analyze"
 	"github.com/lakshaymaurya-felt/purewin/internal/clean"
 	"github.com/lakshaymaurya-felt/purewin/internal/config"
 	"github.com/lakshaymaurya-felt/purewin/internal/core"
@@ -20,29 +26,92 @@ import (
 var cleanCmd = &cobra.Command{
 	Use:   "clean",
 	Short: "Free up disk space",
-	Long:  "Deep cleanup of caches, logs, temp files, and browser leftovers to reclaim disk space.",
-	Run:   runClean,
+	Long: `Deep cleanup of caches, logs, temp files, and browser leftovers to reclaim disk space.
+
+Without category or target flags, pw clean scans everything and opens a
+checklist to pick what to clean (space toggles, c toggles a category).
+
+Protected paths are managed with --whitelist:
+  pw clean --whitelist                   list patterns
+  pw clean --whitelist add <pattern>     protect a path or glob
+  pw clean --whitelist remove <pattern>  stop protecting it
+  pw clean --whitelist defaults          merge built-in protections (SSH keys, credential stores, password managers)
+  pw clean --whitelist export <file|->   write patterns to a file or stdout
+  pw clean --whitelist import <file>     merge patterns (one per line, or JSON)`,
+	Run: runClean,
 }
 
 func init() {
 	cleanCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview the cleanup plan without deleting")
-	cleanCmd.Flags().Bool("whitelist", false, "Manage protected caches")
+	cleanCmd.Flags().Bool("whitelist", false, "Manage protected paths: list, add, remove, defaults, export, import")
 	cleanCmd.Flags().Bool("all", false, "Clean all categories")
 	cleanCmd.Flags().Bool("user", false, "Clean user caches only")
 	cleanCmd.Flags().Bool("system", false, "Clean system caches only (requires admin)")
 	cleanCmd.Flags().Bool("browser", false, "Clean browser caches only")
 	cleanCmd.Flags().Bool("dev", false, "Clean developer tool caches only")
+	cleanCmd.Flags().String("max-risk", "low", "Highest risk level to clean: low, medium, or high")
+	cleanCmd.Flags().Bool("include-high", false, "Also clean high-risk targets such as Windows.old")
+	cleanCmd.Flags().StringSlice("only", nil, "Clean only the named targets (e.g. ChromeCache,NpmCache)")
+	cleanCmd.Flags().StringSlice("skip", nil, "Skip the named targets")
+	cleanCmd.Flags().Duration("temp-min-age", clean.DefaultTempMinAge, "Leave temp files modified more recently than this (e.g. 10m, 2h; 0 cleans all)")
+	cleanCmd.Flags().Bool("check-locked", false, "Leave files held open by other programs out of the reclaimable total")
+	cleanCmd.Flags().Bool("on-reboot", false, "Schedule locked files for deletion at next reboot (requires admin)")
+	cleanCmd.Flags().String("report", "", "Append a JSON record of every deleted path to this file")
+	cleanCmd.Flags().String("drive", "", "Only empty the Recycle Bin on this drive (e.g. D:)")
+	cleanCmd.Flags().Bool("discover", false, "Also find app caches under AppData that the built-in list doesn't cover")
+	cleanCmd.Flags().Bool("quiet", false, "Run unattended: no prompts, high-risk targets skipped")
+	cleanCmd.Flags().String("schedule", "", "Register a scheduled user clean: hourly, daily, or weekly")
+	cleanCmd.Flags().Bool("unschedule", false, "Remove the scheduled clean")
+	cleanCmd.Flags().Bool("schedule-status", false, "Show whether a scheduled clean is registered")
+	cleanCmd.Flags().String("watch", "", "Repeat the clean at this interval (e.g. 30m) until Ctrl+C; implies --quiet")
+	cleanCmd.Flags().Bool("stale-report", false, "List large files in your profile untouched for a long time (read-only)")
+	cleanCmd.Flags().String("older-than", "6mo", "Age threshold for --stale-report (e.g. 90d, 12mo, 1y)")
+	cleanCmd.Flags().String("min-size", "100MB", "Size threshold for --stale-report (e.g. 500MB, 1GiB)")
 }
 
 // ─── Main Entry Point ────────────────────────────────────────────────────────
 
 func runClean(cmd *cobra.Command, args []string) {
+	// Scheduled-clean management runs instead of a clean.
+	if handleScheduleFlags(cmd) {
+		return
+	}
+
+	// Whitelist management runs instead of a clean.
+	if manage, _ := cmd.Flags().GetBool("whitelist"); manage {
+		runWhitelist(args)
+		return
+	}
+
+	// The stale-file report is read-only and replaces the clean entirely.
+	if staleReport, _ := cmd.Flags().GetBool("stale-report"); staleReport {
+		runStaleReport(cmd)
+		return
+	}
+
+	if watchStr, _ := cmd.Flags().GetString("watch"); watchStr != "" {
+		runCleanWatch(cmd, watchStr)
+		return
+	}
+
+	_, code, adminSkipped := cleanOnce(cmd)
+	setExitStatus(code)
+	if adminSkipped {
+		offerElevatedSystemClean(cmd)
+	}
+}
+
+// cleanOnce performs a single scan-and-clean pass under the command's flags
+// and returns the number of bytes freed (0 for dry runs or cancellations)
+// with the pass's exit code. adminSkipped reports a pass that cleaned but
+// left out system targets in scope for lack of elevation.
+func cleanOnce(cmd *cobra.Command) (freed int64, code int, adminSkipped bool) {
 	// Load configuration.
 	cfg, err := config.Load()
 	if err != nil {
 		fmt.Println(ui.ErrorStyle().Render(
 			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	// Override dry-run from config if flag not explicitly set.
@@ -52,6 +121,7 @@ func runClean(cmd *cobra.Command, args []string) {
 
 	// Debug mode.
 	debugMode := debug || cfg.DebugMode
+	quiet, _ := cmd.Flags().GetBool("quiet")
 
 	// Load whitelist.
 	wlPath := filepath.Join(cfg.ConfigDir, "whitelist.txt")
@@ -65,20 +135,107 @@ func runClean(cmd *cobra.Command, args []string) {
 		wl = nil
 	}
 
-	// Parse category flags.
-	allFlag, _ := cmd.Flags().GetBool("all")
-	userFlag, _ := cmd.Flags().GetBool("user")
-	systemFlag, _ := cmd.Flags().GetBool("system")
-	browserFlag, _ := cmd.Flags().GetBool("browser")
-	devFlag, _ := cmd.Flags().GetBool("dev")
+	// A bare `pw clean` in a terminal scans everything and lets the user
+	// pick targets instead of cleaning all of them. Decided before the
+	// settings fallback, which turns the default ["all"] into --all.
+	settings := loadSettings()
+	pick := !slices.ContainsFunc(cleanCategoryNames, cmd.Flags().Changed) &&
+		!settings.ChoosesCategories() &&
+		!cmd.Flags().Changed("only") && !cmd.Flags().Changed("skip") &&
+		!quiet && !assumeYes && ui.IsTerminal()
+
+	// Parse category flags, falling back to settings.
+	allFlag, userFlag, systemFlag, browserFlag, devFlag := cleanCategoryFlags(cmd, settings)
 
 	// Default to all if no category specified.
 	if !allFlag && !userFlag && !systemFlag && !browserFlag && !devFlag {
 		allFlag = true
 	}
 
+	// Parse risk filter.
+	maxRisk, _ := cmd.Flags().GetString("max-risk")
+	maxRisk = strings.ToLower(strings.TrimSpace(maxRisk))
+	if maxRisk != "low" && maxRisk != "medium" && maxRisk != "high" {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Invalid --max-risk %q (use low, medium, or high)", ui.IconError, maxRisk)))
+		os.Exit(exitUsage)
+	}
+	includeHigh, _ := cmd.Flags().GetBool("include-high")
+	var riskSkipped []string
+
+	// Recently written temp files may belong to an install in progress.
+	tempMinAge, _ := cmd.Flags().GetDuration("temp-min-age")
+	if tempMinAge < 0 {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Invalid --temp-min-age %s (must not be negative)", ui.IconError, tempMinAge)))
+		os.Exit(exitUsage)
+	}
+	probeLocked, _ := cmd.Flags().GetBool("check-locked")
+	scanOpts := clean.ScanOptions{TempMinAge: tempMinAge, ProbeLocked: probeLocked}
+
+	// Parse target name filters. When either is set, the normal plan is
+	// narrowed to the named targets after scanning.
+	onlyNames, _ := cmd.Flags().GetStringSlice("only")
+	skipNames, _ := cmd.Flags().GetStringSlice("skip")
+	if err := validateTargetNames(append(append([]string{}, onlyNames...), skipNames...)); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(exitUsage)
+	}
+	nameFilter := len(onlyNames) > 0 || len(skipNames) > 0
+
+	// Validate --drive up front so a typo doesn't silently skip the bin.
+	rbDrive, _ := cmd.Flags().GetString("drive")
+	if rbDrive != "" {
+		if _, err := clean.PlanRecycleBin(rbDrive); err != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s %v", ui.IconError, err)))
+			os.Exit(exitUsage)
+		}
+	}
+
+	// selected reports whether a specially-handled target (Recycle Bin,
+	// Go module cache, Windows.old) is in scope under the category flags
+	// or, in name-filter mode, the --only/--skip lists.
+	var namedTargets []config.CleanTarget
+	if nameFilter {
+		namedTargets = selectNamedTargets(allFlag, userFlag, systemFlag, browserFlag, devFlag, onlyNames, skipNames)
+	}
+	selected := func(name string, categoryOn bool) bool {
+		if !nameFilter {
+			return categoryOn
+		}
+		for _, t := range namedTargets {
+			if t.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
 	isAdmin := core.IsElevated()
 
+	// needsElevation is set when an admin-only target in scope will be
+	// skipped; elevation would not lift targets held back by risk.
+	needsElevation := false
+	if !isAdmin {
+		for _, t := range selectNamedTargets(allFlag, userFlag, systemFlag, browserFlag, devFlag, onlyNames, skipNames) {
+			if t.RequiresAdmin && config.RiskAllowed(t.RiskLevel, maxRisk, includeHigh) {
+				needsElevation = true
+				break
+			}
+		}
+	}
+
+	// Admin-only targets in scope are skipped without elevation, which a
+	// script should hear about unless something worse happened.
+	outcome := func(code int) int {
+		if needsElevation && (code == exitOK || code == exitNothingToDo) {
+			return exitNeedsAdmin
+		}
+		return code
+	}
+
 	// ── Header ───────────────────────────────────────────────────────────
 	fmt.Println()
 	fmt.Println(ui.SectionHeader("Deep Clean", 55))
@@ -99,14 +256,29 @@ func runClean(cmd *cobra.Command, args []string) {
 
 	var allResults []clean.ScanResult
 
+	// wanted reports whether a category is enabled and, under --only, has
+	// a named target in it, so scanners with nothing to keep don't run.
+	wanted := func(category string, categoryOn bool) bool {
+		if !categoryOn || !nameFilter || len(onlyNames) == 0 {
+			return categoryOn
+		}
+		for _, t := range namedTargets {
+			if t.Category == category {
+				return true
+			}
+		}
+		return false
+	}
+
 	// User caches: use config targets via ScanAll.
-	if allFlag || userFlag {
-		userTargets := config.GetTargetsByCategory("user")
-		userResults := clean.ScanAll(userTargets, wl, isAdmin)
+	if wanted("user", allFlag || userFlag) {
+		userTargets, held := config.FilterByRisk(config.GetTargetsByCategory("user"), maxRisk, includeHigh)
+		riskSkipped = append(riskSkipped, targetNames(held)...)
+		userResults := clean.ScanAll(userTargets, wl, isAdmin, scanOpts)
 		allResults = append(allResults, userResults...)
 
 		// Scan non-system drives (D:, E:, etc.) for temp/junk files.
-		driveItems := clean.ScanNonSystemDrives(wl)
+		driveItems := clean.ScanNonSystemDrives(wl, scanOpts)
 		if len(driveItems) > 0 {
 			driveGroups := groupItemsByDescription(driveItems)
 			for name, items := range driveGroups {
@@ -116,8 +288,8 @@ func runClean(cmd *cobra.Command, args []string) {
 	}
 
 	// Browser caches: use specialized multi-profile scanner.
-	if allFlag || browserFlag {
-		browserItems := clean.ScanBrowserCaches(wl)
+	if wanted("browser", allFlag || browserFlag) {
+		browserItems := clean.ScanBrowserCaches(wl, scanOpts)
 		if len(browserItems) > 0 {
 			browserGroups := groupItemsByDescription(browserItems)
 			for name, items := range browserGroups {
@@ -127,8 +299,8 @@ func runClean(cmd *cobra.Command, args []string) {
 	}
 
 	// Developer caches: use specialized scanner for safety.
-	if allFlag || devFlag {
-		devItems := clean.ScanDevCaches(wl)
+	if wanted("dev", allFlag || devFlag) {
+		devItems := clean.ScanDevCaches(wl, scanOpts)
 		if len(devItems) > 0 {
 			devGroups := groupItemsByDescription(devItems)
 			for name, items := range devGroups {
@@ -138,86 +310,210 @@ func runClean(cmd *cobra.Command, args []string) {
 	}
 
 	// System caches: use config targets via ScanAll (admin-gated).
-	if allFlag || systemFlag {
-		systemTargets := config.GetTargetsByCategory("system")
-		systemResults := clean.ScanAll(systemTargets, wl, isAdmin)
+	if wanted("system", allFlag || systemFlag) {
+		systemTargets, held := config.FilterByRisk(config.GetTargetsByCategory("system"), maxRisk, includeHigh)
+		riskSkipped = append(riskSkipped, targetNames(held)...)
+		systemResults := clean.ScanAll(systemTargets, wl, isAdmin, scanOpts)
 		allResults = append(allResults, systemResults...)
 
 		// Memory dumps (separate scan).
-		dumpItems := clean.ScanMemoryDumps()
+		dumpItems := clean.ScanMemoryDumps(scanOpts)
 		if len(dumpItems) > 0 {
 			allResults = append(allResults, clean.ItemsToResult("MemoryDumps", dumpItems))
 		}
 
 		// WER user-level reports (no admin needed).
-		werItems := clean.ScanWERUserReports(wl)
+		werItems := clean.ScanWERUserReports(wl, scanOpts)
 		if len(werItems) > 0 {
 			allResults = append(allResults, clean.ItemsToResult("WER User Reports", werItems))
 		}
 	}
 
-	// Recycle Bin (user category, via Shell API).
-	var recycleBinSize int64
-	if allFlag || userFlag {
-		recycleBinSize, _ = clean.ScanRecycleBin()
+	if nameFilter {
+		allResults = filterResultsByTarget(allResults, namedTargets, len(onlyNames) > 0)
+		named := targetNames(namedTargets)
+		heldNamed := riskSkipped[:0]
+		for _, name := range riskSkipped {
+			if slices.Contains(named, name) {
+				heldNamed = append(heldNamed, name)
+			}
+		}
+		riskSkipped = heldNamed
+	}
+
+	// Discovered app caches: added last so anything a known target already
+	// covers is dropped rather than counted twice.
+	if discover, _ := cmd.Flags().GetBool("discover"); discover && !nameFilter && (allFlag || userFlag) {
+		known := make(map[string]bool)
+		for _, r := range allResults {
+			for _, item := range r.Items {
+				known[strings.ToLower(item.Path)] = true
+			}
+		}
+		var fresh []clean.CleanItem
+		for _, item := range clean.DiscoverAppCaches(wl, scanOpts) {
+			if !known[strings.ToLower(item.Path)] {
+				fresh = append(fresh, item)
+			}
+		}
+		for name, items := range groupItemsByDescription(fresh) {
+			allResults = append(allResults, clean.ItemsToResult(name, items))
+		}
+	}
+
+	// Results from specialized scanners (e.g. Windows.old on other drives)
+	// carry their own risk level.
+	kept := allResults[:0]
+	for _, r := range allResults {
+		if config.RiskAllowed(r.RiskLevel, maxRisk, includeHigh) {
+			kept = append(kept, r)
+		} else {
+			riskSkipped = append(riskSkipped, r.Category)
+		}
+	}
+	allResults = kept
+
+	// Recycle Bin (user category, via Shell API), optionally one drive only.
+	var recycleBin clean.RecycleBinInfo
+	if selected("RecycleBin", allFlag || userFlag) && riskAllowedTarget("RecycleBin", maxRisk, includeHigh) {
+		recycleBin, _ = clean.PlanRecycleBin(rbDrive)
 	}
 
 	// Go module cache size.
 	var goModSize int64
-	if allFlag || devFlag {
+	if selected("GoModCache", allFlag || devFlag) && riskAllowedTarget("GoModCache", maxRisk, includeHigh) {
 		goModSize = clean.GoModCacheSize()
 	}
 
 	// Windows.old size.
 	var windowsOldSize int64
-	if (allFlag || systemFlag) && isAdmin {
+	if selected("WindowsOld", allFlag || systemFlag) && isAdmin && !quiet && riskAllowedTarget("WindowsOld", maxRisk, includeHigh) {
 		windowsOldSize = clean.WindowsOldSize()
 	}
 
 	spinner.Stop("Scan complete")
 
+	if len(riskSkipped) > 0 {
+		sort.Strings(riskSkipped)
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+			"  Held back above --max-risk %s: %s", maxRisk, strings.Join(riskSkipped, ", "))))
+		if maxRisk == "low" {
+			fmt.Println(ui.MutedStyle().Render(
+				"  Use --max-risk medium or --include-high to include them."))
+		}
+	}
+
 	// ── Calculate Totals ─────────────────────────────────────────────────
-	totalSize := clean.TotalSizeAll(allResults) + recycleBinSize + goModSize + windowsOldSize
-	totalItems := clean.TotalItemCount(allResults)
+	// Locked files stay listed but are not counted as reclaimable.
+	lockedSize, lockedCount := clean.LockedTotals(allResults)
+	totalSize := clean.TotalSizeAll(allResults) - lockedSize + recycleBin.Size + goModSize + windowsOldSize
+	totalItems := clean.TotalItemCount(allResults) - lockedCount
 
 	if totalSize == 0 {
 		fmt.Println()
 		fmt.Println(ui.SuccessStyle().Render(
 			fmt.Sprintf("  %s  System is clean! Nothing to remove.", ui.IconSuccess)))
+		printLockedNote(lockedSize, lockedCount)
 		fmt.Println()
-		return
+		return 0, outcome(exitNothingToDo), false
 	}
 
 	// ── Display Results ──────────────────────────────────────────────────
-	displayCleanResults(allResults, recycleBinSize, goModSize, windowsOldSize)
+	if pick {
+		picked, pickErr := pickCleanTargets(allResults, recycleBin, goModSize, windowsOldSize, isAdmin)
+		if pickErr != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s %v", ui.IconError, pickErr)))
+			os.Exit(exitError)
+		}
+		if len(picked) == 0 {
+			fmt.Println(ui.MutedStyle().Render("  Nothing selected. Cleanup cancelled."))
+			fmt.Println()
+			return 0, exitOK, false
+		}
 
-	fmt.Println(ui.Divider(55))
-	fmt.Printf("  %-35s %s  %s\n",
-		ui.BoldStyle().Render("Total"),
-		ui.FormatSize(totalSize),
-		ui.MutedStyle().Render(fmt.Sprintf("(%d items)", totalItems)),
-	)
-	fmt.Println()
+		kept := allResults[:0]
+		for _, r := range allResults {
+			if picked[r.Category] {
+				kept = append(kept, r)
+			}
+		}
+		allResults = kept
+		if !picked[pickRecycleBin] {
+			recycleBin = clean.RecycleBinInfo{}
+		}
+		if !picked[pickGoModCache] {
+			goModSize = 0
+		}
+		if !picked[pickWindowsOld] {
+			windowsOldSize = 0
+		}
+		lockedSize, lockedCount = clean.LockedTotals(allResults)
+		totalSize = clean.TotalSizeAll(allResults) - lockedSize + recycleBin.Size + goModSize + windowsOldSize
+
+		fmt.Println(ui.InfoStyle().Render(fmt.Sprintf(
+			"  %s  Cleaning %d selected targets (%s)", ui.IconArrow, len(picked), core.FormatSize(totalSize))))
+		fmt.Println()
+	} else {
+		displayCleanResults(allResults, recycleBin, goModSize, windowsOldSize)
+
+		fmt.Println(ui.Divider(55))
+		fmt.Printf("  %-35s %s  %s\n",
+			ui.BoldStyle().Render("Total"),
+			ui.FormatSize(totalSize),
+			ui.MutedStyle().Render(fmt.Sprintf("(%d items)", totalItems)),
+		)
+		printLockedNote(lockedSize, lockedCount)
+		fmt.Println()
+	}
+
+	// Warn about open browsers — their caches stay locked while running.
+	openBrowsers := runningBrowsers(allResults)
+	for _, b := range openBrowsers {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  %s is running — close it for a complete clean.", ui.IconWarning, b.name)))
+	}
+	if len(openBrowsers) > 0 {
+		fmt.Println()
+	}
+
+	// Optional JSON audit trail (--report).
+	reportPath, _ := cmd.Flags().GetString("report")
+	var report *core.CleanReport
+	if reportPath != "" {
+		report = core.NewCleanReport(dryRun)
+	}
 
 	// ── Dry Run: Export and Exit ─────────────────────────────────────────
 	if dryRun {
 		drc := core.NewDryRunContext()
 		for _, r := range allResults {
 			for _, item := range r.Items {
-				drc.Add(item.Path, item.Size, item.Category)
+				if !item.Locked {
+					drc.Add(item.Path, item.Size, item.Category)
+				}
 			}
 		}
-		if recycleBinSize > 0 {
-			drc.Add("Recycle Bin (Shell API)", recycleBinSize, "user")
+		if recycleBin.Items > 0 {
+			drc.Add(recycleBin.Label()+" (Shell API)", recycleBin.Size, "user")
 		}
 		if goModSize > 0 {
 			drc.Add("Go module cache", goModSize, "dev")
 		}
 		if windowsOldSize > 0 {
-			drc.Add(`C:\Windows.old`, windowsOldSize, "system")
+			drc.Add(clean.WindowsOldPath(), windowsOldSize, "system")
 		}
 
-		drc.PrintSummary()
+		printDryRunSummary(drc)
+		printLockedNote(lockedSize, lockedCount)
+		printProjectedFreeSpace(projectedGainByDrive(allResults, recycleBin, goModSize, windowsOldSize))
+
+		if report != nil {
+			for _, item := range drc.Items {
+				report.Add(item.Path, item.Category, item.Size, nil)
+			}
+			writeCleanReport(report, reportPath)
+		}
 
 		exportPath := filepath.Join(cfg.ConfigDir, "clean-list.txt")
 		if exportErr := drc.ExportToFile(exportPath); exportErr != nil {
@@ -228,16 +524,44 @@ func runClean(cmd *cobra.Command, args []string) {
 				fmt.Sprintf("  Report saved to %s", exportPath)))
 		}
 		fmt.Println()
-		return
+		return 0, outcome(exitOK), false
 	}
 
 	// ── Confirm ──────────────────────────────────────────────────────────
-	confirmed, confirmErr := ui.Confirm(
-		fmt.Sprintf("  Proceed to free %s?", core.FormatSize(totalSize)))
-	if confirmErr != nil || !confirmed {
-		fmt.Println(ui.MutedStyle().Render("  Cleanup cancelled."))
-		fmt.Println()
-		return
+	// Confirming the picker already approved the selection.
+	if !quiet && !assumeYes && !pick {
+		confirmed, confirmErr := ui.Confirm(
+			fmt.Sprintf("  Proceed to free %s?", core.FormatSize(totalSize)))
+		if confirmErr != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cleanup cancelled."))
+			fmt.Println()
+			return 0, exitOK, false
+		}
+	}
+
+	// ── Offer to Close Open Browsers ─────────────────────────────────────
+	for _, b := range openBrowsers {
+		if quiet || assumeYes {
+			break
+		}
+		closeIt, _ := ui.Confirm(fmt.Sprintf("  Close %s now?", b.name))
+		if !closeIt {
+			continue
+		}
+		if err := clean.CloseProcess(b.image); err != nil {
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("  %s  Could not close %s: %v", ui.IconWarning, b.name, err)))
+		}
+	}
+
+	// ── High-Risk Confirmation ───────────────────────────────────────────
+	// Unattended runs never delete high-risk targets; --yes only skips the
+	// typed confirmation when --include-high explicitly opted in.
+	switch {
+	case quiet || (assumeYes && !includeHigh):
+		allResults = dropHighRiskResults(allResults)
+	case !assumeYes:
+		allResults = confirmHighRiskResults(allResults)
 	}
 
 	// ── Initialize Logger ────────────────────────────────────────────────
@@ -253,6 +577,9 @@ func runClean(cmd *cobra.Command, args []string) {
 		logger.LogSession("clean")
 	}
 
+	// Capture free space on every affected drive for the before/after summary.
+	freeBefore := freeSpaceByDrive(projectedGainByDrive(allResults, recycleBin, goModSize, windowsOldSize))
+
 	// ── Execute Cleanup ──────────────────────────────────────────────────
 	cleanSpinner := ui.NewInlineSpinner()
 	cleanSpinner.Start("Cleaning...")
@@ -260,14 +587,60 @@ func runClean(cmd *cobra.Command, args []string) {
 	var totalFreed int64
 	var totalCleaned int
 	var errCount int
+	var rebootQueued int
+	var lockedLeft int
+
+	onReboot, _ := cmd.Flags().GetBool("on-reboot")
+	if onReboot && !isAdmin {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  --on-reboot requires admin — locked files will be skipped", ui.IconWarning)))
+		onReboot = false
+	}
 
-	// Delete all scanned items via SafeDelete.
+	// Delete all scanned items via SafeDelete. The whitelist is checked
+	// again per item so nothing protected slips through a scanner.
 	for _, r := range allResults {
 		for _, item := range r.Items {
+			if wl != nil && wl.IsWhitelisted(item.Path) {
+				continue
+			}
+
+			// Files locked at scan time would only fail after retries;
+			// queue them for reboot or leave them be.
+			if item.Locked {
+				if onReboot {
+					if schedErr := core.ScheduleDeleteOnReboot(item.Path); schedErr == nil {
+						rebootQueued++
+						report.Add(item.Path, item.Category, 0, fmt.Errorf("locked; scheduled for deletion on reboot"))
+						if logger != nil {
+							logger.Log("DELETE_ON_REBOOT", item.Path, item.Size, nil)
+						}
+						continue
+					}
+				}
+				lockedLeft++
+				report.Add(item.Path, item.Category, 0, fmt.Errorf("locked by another process; left in place"))
+				continue
+			}
+
 			cleanSpinner.UpdateMessage(
 				fmt.Sprintf("Cleaning %s...", filepath.Base(item.Path)))
 
 			freed, delErr := core.SafeDelete(item.Path, false)
+
+			// Still locked after SafeDelete's retries — queue for reboot.
+			if delErr != nil && onReboot && core.IsLockedError(delErr) {
+				if schedErr := core.ScheduleDeleteOnReboot(item.Path); schedErr == nil {
+					rebootQueued++
+					report.Add(item.Path, item.Category, 0, fmt.Errorf("locked; scheduled for deletion on reboot"))
+					if logger != nil {
+						logger.Log("DELETE_ON_REBOOT", item.Path, item.Size, nil)
+					}
+					continue
+				}
+			}
+
+			report.Add(item.Path, item.Category, freed, delErr)
 			if delErr != nil {
 				errCount++
 				if debugMode {
@@ -287,19 +660,21 @@ func runClean(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	// Empty Recycle Bin.
-	if recycleBinSize > 0 {
-		cleanSpinner.UpdateMessage("Emptying Recycle Bin...")
-		if rbErr := clean.EmptyRecycleBin(false); rbErr != nil {
+	// Empty Recycle Bin — skipped entirely when the bin has no items.
+	if recycleBin.Items > 0 {
+		cleanSpinner.UpdateMessage(fmt.Sprintf("Emptying %s...", recycleBin.Label()))
+		rbErr := recycleBin.Empty(false)
+		report.Add(recycleBin.Label(), "user", recycleBin.Size, rbErr)
+		if rbErr != nil {
 			errCount++
 			if logger != nil {
 				logger.Log("EMPTY_RECYCLE_BIN", "RecycleBin", 0, rbErr)
 			}
 		} else {
-			totalFreed += recycleBinSize
+			totalFreed += recycleBin.Size
 			totalCleaned++
 			if logger != nil {
-				logger.Log("EMPTY_RECYCLE_BIN", "RecycleBin", recycleBinSize, nil)
+				logger.Log("EMPTY_RECYCLE_BIN", "RecycleBin", recycleBin.Size, nil)
 			}
 		}
 	}
@@ -308,6 +683,7 @@ func runClean(cmd *cobra.Command, args []string) {
 	if goModSize > 0 {
 		cleanSpinner.UpdateMessage("Cleaning Go module cache...")
 		freed, goErr := clean.CleanGoModCache(false)
+		report.Add("Go module cache", "dev", freed, goErr)
 		if goErr != nil {
 			errCount++
 			if logger != nil {
@@ -322,21 +698,24 @@ func runClean(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	// Windows.old (requires DangerConfirm inside CleanWindowsOld).
+	// Windows.old (requires typed confirmation inside CleanWindowsOld).
 	if windowsOldSize > 0 {
 		cleanSpinner.Stop("Pausing for confirmation...")
 
-		freed, woErr := clean.CleanWindowsOld(false)
+		freed, woErr := clean.CleanWindowsOld(false, assumeYes && includeHigh)
+		if woErr != nil || freed > 0 {
+			report.Add(clean.WindowsOldPath(), "system", freed, woErr)
+		}
 		if woErr != nil {
 			errCount++
 			if logger != nil {
-				logger.Log("DELETE_WINDOWS_OLD", `C:\Windows.old`, 0, woErr)
+				logger.Log("DELETE_WINDOWS_OLD", clean.WindowsOldPath(), 0, woErr)
 			}
 		} else if freed > 0 {
 			totalFreed += freed
 			totalCleaned++
 			if logger != nil {
-				logger.Log("DELETE_WINDOWS_OLD", `C:\Windows.old`, freed, nil)
+				logger.Log("DELETE_WINDOWS_OLD", clean.WindowsOldPath(), freed, nil)
 			}
 		}
 
@@ -351,6 +730,9 @@ func runClean(cmd *cobra.Command, args []string) {
 	if logger != nil {
 		logger.LogSummary(totalFreed, totalCleaned, errCount)
 	}
+	if report != nil {
+		writeCleanReport(report, reportPath)
+	}
 
 	// ── Completion Banner ────────────────────────────────────────────────
 	fmt.Println()
@@ -365,45 +747,74 @@ func runClean(cmd *cobra.Command, args []string) {
 		fmt.Sprintf("  %s  Freed %s across %d items",
 			ui.IconSuccess, core.FormatSize(totalFreed), totalCleaned)))
 
+	printFreeSpaceDelta(freeBefore)
+
+	if lockedLeft > 0 {
+		fmt.Println(ui.MutedStyle().Render(
+			fmt.Sprintf("  %d files in use by other programs were left in place", lockedLeft)))
+	}
+	if rebootQueued > 0 {
+		fmt.Println(ui.InfoStyle().Render(
+			fmt.Sprintf("  %s  %d locked items queued for removal after reboot",
+				ui.IconPending, rebootQueued)))
+	}
 	if errCount > 0 {
 		fmt.Println(ui.WarningStyle().Render(
 			fmt.Sprintf("  %s  %d items skipped (locked or access denied)",
 				ui.IconWarning, errCount)))
 	}
 	fmt.Println()
+	if errCount > 0 {
+		return totalFreed, exitPartial, needsElevation
+	}
+	return totalFreed, outcome(exitOK), needsElevation
 }
 
 // ─── Display Helpers ─────────────────────────────────────────────────────────
 
+// cleanCategory pairs a scan category key with its display label.
+type cleanCategory struct {
+	key   string
+	label string
+}
+
+// cleanCategories lists the scan categories in display order.
+var cleanCategories = []cleanCategory{
+	{"user", "User Caches"},
+	{"browser", "Browser Caches"},
+	{"dev", "Developer Tools"},
+	{"system", "System"},
+}
+
+// categoryLabel returns the display label for a category key, falling back
+// to the key itself for categories without one.
+func categoryLabel(key string) string {
+	for _, cat := range cleanCategories {
+		if cat.key == key {
+			return cat.label
+		}
+	}
+	return key
+}
+
 // displayCleanResults prints scan results grouped by high-level category.
 func displayCleanResults(
 	results []clean.ScanResult,
-	recycleBinSize, goModSize, windowsOldSize int64,
+	recycleBin clean.RecycleBinInfo,
+	goModSize, windowsOldSize int64,
 ) {
 	groups := clean.GroupByCategory(results)
 
-	type categoryDef struct {
-		key   string
-		label string
-	}
-
-	categories := []categoryDef{
-		{"user", "User Caches"},
-		{"browser", "Browser Caches"},
-		{"dev", "Developer Tools"},
-		{"system", "System"},
-	}
-
 	fmt.Println()
 
-	for _, cat := range categories {
+	for _, cat := range cleanCategories {
 		groupResults, hasGroup := groups[cat.key]
 
 		// Check if this category has extra line items to show.
 		hasExtra := false
 		switch cat.key {
 		case "user":
-			hasExtra = recycleBinSize > 0
+			hasExtra = recycleBin.Items > 0
 		case "dev":
 			hasExtra = goModSize > 0 || clean.IsDockerAvailable()
 		case "system":
@@ -424,10 +835,18 @@ func displayCleanResults(
 			})
 
 			for _, r := range groupResults {
+				count := fmt.Sprintf("(%d items)", r.ItemCount)
+				if r.LockedCount > 0 {
+					count = fmt.Sprintf("(%d items, %d locked)", r.ItemCount, r.LockedCount)
+				}
+				note := ui.MutedStyle().Render(count)
+				if r.RiskLevel == "high" {
+					note += " " + ui.WarningStyle().Render("(requires confirmation)")
+				}
 				fmt.Printf("    %-31s  %10s  %s\n",
 					r.Category,
 					ui.FormatSize(r.TotalSize),
-					ui.MutedStyle().Render(fmt.Sprintf("(%d items)", r.ItemCount)),
+					note,
 				)
 			}
 		}
@@ -435,10 +854,11 @@ func displayCleanResults(
 		// Extra line items per category.
 		switch cat.key {
 		case "user":
-			if recycleBinSize > 0 {
-				fmt.Printf("    %-31s  %10s\n",
-					"Recycle Bin",
-					ui.FormatSize(recycleBinSize),
+			if recycleBin.Items > 0 {
+				fmt.Printf("    %-31s  %10s  %s\n",
+					recycleBin.Label(),
+					ui.FormatSize(recycleBin.Size),
+					ui.MutedStyle().Render(fmt.Sprintf("(across %d items)", recycleBin.Items)),
 				)
 			}
 		case "dev":
@@ -470,6 +890,874 @@ func displayCleanResults(
 	}
 }
 
+// printLockedNote reports the files left out of the reclaimable total
+// because another program held them open during the scan.
+func printLockedNote(size int64, count int) {
+	if count == 0 {
+		return
+	}
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+		"  %s in %d files is locked by running programs and will be left in place",
+		ui.FormatSize(size), count)))
+}
+
+// printDryRunSummary shows how much each category would reclaim, largest
+// first, with a bar for its share of the total.
+func printDryRunSummary(drc *core.DryRunContext) {
+	totals := drc.CategoryTotals()
+	if len(totals) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  Nothing to clean."))
+		fmt.Println()
+		return
+	}
+
+	grand := drc.TotalSize()
+	fmt.Println(ui.SectionHeader("Dry Run — No files deleted", 55))
+	for _, t := range totals {
+		pct := 0.0
+		if grand > 0 {
+			pct = float64(t.Size) / float64(grand) * 100
+		}
+		fmt.Printf("    %-16s  %s %3.0f%%  %10s  %s\n",
+			categoryLabel(t.Category),
+			ui.GradientBar(pct, 12),
+			pct,
+			ui.FormatSize(t.Size),
+			ui.MutedStyle().Render(fmt.Sprintf("(%d items)", t.Count)),
+		)
+	}
+	fmt.Println(ui.Divider(55))
+	fmt.Printf("    %s  %17s  %s  %s\n",
+		ui.BoldStyle().Render(fmt.Sprintf("%-16s", "Total")),
+		"",
+		ui.BoldStyle().Render(fmt.Sprintf("%10s", ui.FormatSize(grand))),
+		ui.MutedStyle().Render(fmt.Sprintf("(%d items)", drc.Count())),
+	)
+	fmt.Println()
+	fmt.Println(ui.MutedStyle().Render("  Run without --dry-run to execute cleanup."))
+	fmt.Println()
+}
+
+// ─── Interactive Picker ──────────────────────────────────────────────────────
+
+// Picker values for the targets cleaned outside the per-file scan.
+const (
+	pickRecycleBin = "RecycleBin"
+	pickGoModCache = "GoModCache"
+	pickWindowsOld = "WindowsOld"
+)
+
+// pickCleanTargets shows every scanned target in a checklist grouped by
+// category and returns the values of the ones the user kept: scan result
+// names plus the pick* constants. High-risk targets start unchecked. When
+// not elevated, admin-only system targets are listed but disabled. A nil
+// map means the user quit.
+func pickCleanTargets(
+	results []clean.ScanResult,
+	recycleBin clean.RecycleBinInfo,
+	goModSize, windowsOldSize int64,
+	isAdmin bool,
+) (map[string]bool, error) {
+	items := cleanPickerItems(results, recycleBin, goModSize, windowsOldSize, isAdmin)
+
+	chosen, err := ui.RunSelector(items, "Select what to clean:")
+	if err != nil || chosen == nil {
+		return nil, err
+	}
+
+	picked := make(map[string]bool, len(chosen))
+	for _, item := range chosen {
+		picked[item.Value] = true
+	}
+	return picked, nil
+}
+
+// cleanPickerItems builds the checklist for pickCleanTargets in display
+// category order, largest target first within each category.
+func cleanPickerItems(
+	results []clean.ScanResult,
+	recycleBin clean.RecycleBinInfo,
+	goModSize, windowsOldSize int64,
+	isAdmin bool,
+) []ui.SelectorItem {
+	groups := clean.GroupByCategory(results)
+
+	var items []ui.SelectorItem
+	for _, cat := range cleanCategories {
+		group := groups[cat.key]
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].TotalSize > group[j].TotalSize
+		})
+		for _, r := range group {
+			desc := fmt.Sprintf("%d items", r.ItemCount)
+			if r.LockedCount > 0 {
+				desc += fmt.Sprintf(" • %d locked, left in place", r.LockedCount)
+			}
+			if r.RiskLevel != "low" {
+				desc += fmt.Sprintf(" • %s risk", r.RiskLevel)
+			}
+			reclaimable := r.TotalSize - r.LockedSize
+			items = append(items, ui.SelectorItem{
+				Label:       r.Category,
+				Description: desc,
+				Value:       r.Category,
+				Size:        ui.FormatSize(reclaimable),
+				SizeBytes:   reclaimable,
+				Selected:    r.RiskLevel != "high",
+				Category:    cat.label,
+			})
+		}
+
+		switch cat.key {
+		case "user":
+			if recycleBin.Items > 0 {
+				items = append(items, ui.SelectorItem{
+					Label:       recycleBin.Label(),
+					Description: fmt.Sprintf("across %d items", recycleBin.Items),
+					Value:       pickRecycleBin,
+					Size:        ui.FormatSize(recycleBin.Size),
+					SizeBytes:   recycleBin.Size,
+					Selected:    true,
+					Category:    cat.label,
+				})
+			}
+		case "dev":
+			if goModSize > 0 {
+				items = append(items, ui.SelectorItem{
+					Label:       "Go module cache",
+					Description: "go clean -modcache",
+					Value:       pickGoModCache,
+					Size:        ui.FormatSize(goModSize),
+					SizeBytes:   goModSize,
+					Selected:    true,
+					Category:    cat.label,
+				})
+			}
+		case "system":
+			if windowsOldSize > 0 {
+				items = append(items, ui.SelectorItem{
+					Label:       "Windows.old",
+					Description: "high risk • requires typed confirmation",
+					Value:       pickWindowsOld,
+					Size:        ui.FormatSize(windowsOldSize),
+					SizeBytes:   windowsOldSize,
+					Category:    cat.label,
+				})
+			}
+			if !isAdmin {
+				for _, t := range config.GetTargetsByCategory("system") {
+					if !t.RequiresAdmin {
+						continue
+					}
+					items = append(items, ui.SelectorItem{
+						Label:       t.Name,
+						Description: "Requires admin — re-run with: pw --admin clean",
+						Size:        "needs admin",
+						Disabled:    true,
+						Category:    cat.label,
+					})
+				}
+			}
+		}
+	}
+	return items
+}
+
+// confirmHighRiskResults asks the user to type the name of every high-risk
+// result before it is deleted. Declined results are dropped.
+func confirmHighRiskResults(results []clean.ScanResult) []clean.ScanResult {
+	kept := results[:0]
+	for _, r := range results {
+		if r.RiskLevel != "high" {
+			kept = append(kept, r)
+			continue
+		}
+
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf(
+			"  %s %s is high-risk (%s, %d items) and cannot be undone.",
+			ui.IconWarning, r.Category, core.FormatSize(r.TotalSize), r.ItemCount)))
+		confirmed, err := ui.TypedConfirm(r.Category)
+		if err != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render(
+				fmt.Sprintf("  Skipping %s.", r.Category)))
+			fmt.Println()
+			continue
+		}
+		fmt.Println()
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// dropHighRiskResults removes high-risk results without prompting.
+func dropHighRiskResults(results []clean.ScanResult) []clean.ScanResult {
+	kept := results[:0]
+	for _, r := range results {
+		if r.RiskLevel == "high" {
+			fmt.Println(ui.MutedStyle().Render(
+				fmt.Sprintf("  Skipping high-risk %s (needs interactive confirmation or --yes --include-high).", r.Category)))
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// cleanCategoryNames are the category flags cleanCategoryFlags reads.
+var cleanCategoryNames = []string{"all", "user", "system", "browser", "dev"}
+
+// cleanCategoryFlags reads the category flags, falling back to the
+// settings file's clean_categories when none was passed.
+func cleanCategoryFlags(cmd *cobra.Command, settings *config.Settings) (all, user, system, browser, dev bool) {
+	all, _ = cmd.Flags().GetBool("all")
+	user, _ = cmd.Flags().GetBool("user")
+	system, _ = cmd.Flags().GetBool("system")
+	browser, _ = cmd.Flags().GetBool("browser")
+	dev, _ = cmd.Flags().GetBool("dev")
+	if all || user || system || browser || dev {
+		return
+	}
+	return settings.HasCategory("all"), settings.HasCategory("user"),
+		settings.HasCategory("system"), settings.HasCategory("browser"),
+		settings.HasCategory("dev")
+}
+
+// ─── Partial Elevation ───────────────────────────────────────────────────────
+
+// elevatedPassthroughFlags are the clean flags forwarded to the elevated
+// system-only run so it honours the same filters.
+var elevatedPassthroughFlags = []string{
+	"dry-run", "max-risk", "include-high", "only", "skip", "report", "on-reboot",
+	"temp-min-age", "check-locked",
+}
+
+// elevatedPathFlags are the passthrough flags holding a file path.
+var elevatedPathFlags = map[string]bool{"report": true}
+
+// offerElevatedSystemClean is called after a non-elevated clean that
+// skipped system targets. It offers to launch just "clean --system"
+// elevated, so user targets never run as admin. This process then exits
+// with its own status.
+func offerElevatedSystemClean(cmd *cobra.Command) {
+	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet || assumeYes {
+		return
+	}
+
+	ok, err := ui.Confirm("  Re-launch as administrator to clean the skipped system targets?")
+	if err != nil || !ok {
+		fmt.Println()
+		return
+	}
+
+	args := []string{"clean", "--system"}
+	for _, name := range elevatedPassthroughFlags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			value := strings.Trim(f.Value.String(), "[]")
+			// The elevated process may start in another directory.
+			if elevatedPathFlags[name] {
+				if abs, err := filepath.Abs(value); err == nil {
+					value = abs
+				}
+			}
+			if strings.ContainsAny(value, " \t") {
+				value = `"` + value + `"`
+			}
+			args = append(args, fmt.Sprintf("--%s=%s", name, value))
+		}
+	}
+	if err := core.LaunchElevated(args); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		fmt.Println()
+	}
+}
+
+// ─── Watch Mode ──────────────────────────────────────────────────────────────
+
+// minWatchInterval keeps --watch from rescanning the disk continuously.
+const minWatchInterval = time.Minute
+
+// runCleanWatch runs unattended cleans every interval until interrupted,
+// printing what each cycle freed and the running total.
+func runCleanWatch(cmd *cobra.Command, intervalStr string) {
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil || interval < minWatchInterval {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Invalid --watch %q (use a duration of at least 1m, e.g. 30m or 2h)", ui.IconError, intervalStr)))
+		os.Exit(exitUsage)
+	}
+	_ = cmd.Flags().Set("quiet", "true")
+
+	// Ctrl+C lets the current cycle finish, then stops the loop.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var total int64
+	for cycle := 1; ; cycle++ {
+		freed, _, _ := cleanOnce(cmd)
+		total += freed
+
+		fmt.Println(ui.InfoStyle().Render(fmt.Sprintf(
+			"  %s  Cycle %d freed %s — %s reclaimed in total. Next run at %s (Ctrl+C to stop).",
+			ui.IconPending, cycle, core.FormatSize(freed), core.FormatSize(total),
+			time.Now().Add(interval).Format("15:04"))))
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf(
+				"  %s  Watch stopped after %d cycle(s); freed %s in total.", ui.IconSuccess, cycle, core.FormatSize(total))))
+			fmt.Println()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ─── Stale File Report ───────────────────────────────────────────────────────
+
+// runStaleReport lists large, long-unmodified files under %USERPROFILE%
+// so the user can decide what to archive. Nothing is deleted.
+func runStaleReport(cmd *cobra.Command) {
+	olderThanStr, _ := cmd.Flags().GetString("older-than")
+	olderThan, err := core.ParseAge(olderThanStr)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s --older-than: %v", ui.IconError, err)))
+		os.Exit(exitUsage)
+	}
+	minSizeStr, _ := cmd.Flags().GetString("min-size")
+	minSize, err := core.ParseSize(minSizeStr)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s --min-size: %v", ui.IconError, err)))
+		os.Exit(exitUsage)
+	}
+
+	profile := os.Getenv("USERPROFILE")
+	if profile == "" {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s USERPROFILE is not set", ui.IconError)))
+		os.Exit(exitError)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Stale Files", 55))
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+		"  Files over %s not modified in %s under %s",
+		core.FormatSize(minSize), strings.TrimSpace(olderThanStr), profile)))
+	fmt.Println()
+
+	root := scanWithSpinner(profile, analyze.NewScanner(8, analyze.StaleExcludeDirs))
+	stale := analyze.FindStaleFiles(root, minSize, olderThan)
+
+	if len(stale) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s  No stale files found.", ui.IconSuccess)))
+		fmt.Println()
+		return
+	}
+
+	var total int64
+	for _, f := range stale {
+		total += f.Size
+		fmt.Printf("  %s  %s  %s\n",
+			core.FormatSizeOpts(f.Size, core.SizeFormat{Precision: 1, Pad: true}),
+			ui.MutedStyle().Render(fmt.Sprintf("%-10s", formatStaleAge(time.Since(f.ModTime)))),
+			f.Path)
+	}
+
+	fmt.Println(ui.Divider(55))
+	fmt.Printf("  %s  %s\n",
+		ui.BoldStyle().Render(core.FormatSize(total)),
+		ui.MutedStyle().Render(fmt.Sprintf("in %d stale files", len(stale))))
+	fmt.Println()
+}
+
+// formatStaleAge renders a file age in months, or years past two years.
+func formatStaleAge(d time.Duration) string {
+	months := int(d.Hours() / 24 / 30)
+	switch {
+	case months >= 24:
+		return fmt.Sprintf("%d years", months/12)
+	case months == 1:
+		return "1 month"
+	default:
+		return fmt.Sprintf("%d months", months)
+	}
+}
+
+// ─── Scheduled Clean ─────────────────────────────────────────────────────────
+
+// handleScheduleFlags processes --schedule, --unschedule, and
+// --schedule-status. It returns true if one of them was given.
+func handleScheduleFlags(cmd *cobra.Command) bool {
+	cadence, _ := cmd.Flags().GetString("schedule")
+	unschedule, _ := cmd.Flags().GetBool("unschedule")
+	status, _ := cmd.Flags().GetBool("schedule-status")
+	if cadence == "" && !unschedule && !status {
+		return false
+	}
+
+	fmt.Println()
+	switch {
+	case cadence != "":
+		if _, err := clean.ValidateCadence(cadence); err != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s %v", ui.IconError, err)))
+			os.Exit(exitUsage)
+		}
+		name, err := clean.CreateScheduledClean(cadence)
+		if err != nil {
+			exitScheduleError(err)
+		}
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Scheduled %s user clean as task %q", ui.IconSuccess,
+				strings.ToLower(strings.TrimSpace(cadence)), name)))
+
+	case unschedule:
+		if err := clean.DeleteScheduledClean(); err != nil {
+			exitScheduleError(err)
+		}
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Removed scheduled task %q", ui.IconSuccess, clean.ScheduledTaskName)))
+
+	case status:
+		exists, details, err := clean.ScheduledCleanStatus()
+		if err != nil {
+			exitScheduleError(err)
+		}
+		if !exists {
+			fmt.Println(ui.MutedStyle().Render(
+				"  No scheduled clean. Create one with: pw clean --schedule daily"))
+			break
+		}
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Scheduled clean is registered", ui.IconSuccess)))
+		for _, line := range strings.Split(details, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Println(ui.MutedStyle().Render("    " + line))
+			}
+		}
+	}
+	fmt.Println()
+	return true
+}
+
+// exitScheduleError reports a Task Scheduler failure. Access-denied errors
+// re-launch the command elevated, since some systems restrict task creation,
+// and exit with exitNeedsAdmin if that fails too.
+func exitScheduleError(err error) {
+	code := exitError
+	if errors.Is(err, clean.ErrScheduleAccessDenied) && !core.IsElevated() {
+		code = exitNeedsAdmin
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s Task Scheduler denied access — relaunching as administrator...", ui.IconWarning)))
+		var elevatedArgs []string
+		for _, a := range os.Args[1:] {
+			if a != "--admin" {
+				elevatedArgs = append(elevatedArgs, a)
+			}
+		}
+		if elevErr := core.RunElevated(elevatedArgs); elevErr != nil {
+			err = elevErr
+		}
+	}
+	fmt.Println(ui.ErrorStyle().Render(
+		fmt.Sprintf("  %s %v", ui.IconError, err)))
+	os.Exit(code)
+}
+
+// ─── Whitelist Management ────────────────────────────────────────────────────
+
+// runWhitelist handles `pw clean --whitelist [action] [arg]`, saving the
+// whitelist after any change.
+func runWhitelist(args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		exitWhitelistError(exitError, fmt.Errorf("failed to load config: %w", err))
+	}
+	wl, err := whitelist.Load(filepath.Join(cfg.ConfigDir, "whitelist.txt"))
+	if err != nil {
+		exitWhitelistError(exitError, err)
+	}
+
+	action, arg := "list", ""
+	if len(args) > 0 {
+		action = strings.ToLower(args[0])
+	}
+	if len(args) > 1 {
+		arg = strings.Join(args[1:], " ")
+	}
+	switch action {
+	case "add", "remove":
+		if arg == "" {
+			exitWhitelistError(exitUsage, fmt.Errorf("usage: pw clean --whitelist %s <pattern>", action))
+		}
+	case "export", "import":
+		if arg == "" {
+			exitWhitelistError(exitUsage, fmt.Errorf("usage: pw clean --whitelist %s <file>", action))
+		}
+	}
+
+	// Exporting to stdout must not be mixed with any decoration.
+	if action == "export" && arg == "-" {
+		if err := wl.Export(os.Stdout); err != nil {
+			exitWhitelistError(exitError, err)
+		}
+		return
+	}
+
+	fmt.Println()
+	switch action {
+	case "list":
+		patterns := wl.List()
+		if len(patterns) == 0 {
+			fmt.Println(ui.MutedStyle().Render("  Whitelist is empty."))
+		}
+		for _, p := range patterns {
+			fmt.Printf("  %s %s\n", ui.MutedStyle().Render(ui.IconBullet), p)
+		}
+
+	case "add":
+		if err := wl.Add(arg); err != nil {
+			exitWhitelistError(exitError, err)
+		}
+		saveWhitelist(wl, fmt.Sprintf("Protected %s", arg))
+
+	case "remove":
+		if err := wl.Remove(arg); err != nil {
+			exitWhitelistError(exitError, err)
+		}
+		saveWhitelist(wl, fmt.Sprintf("Removed %s", arg))
+
+	case "defaults":
+		added := wl.MergeDefaults()
+		saveWhitelist(wl, fmt.Sprintf("Added %d default protection(s)", added))
+
+	case "export":
+		f, err := os.Create(arg)
+		if err != nil {
+			exitWhitelistError(exitError, fmt.Errorf("cannot create %s: %w", arg, err))
+		}
+		err = wl.Export(f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			exitWhitelistError(exitError, err)
+		}
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Exported %d pattern(s) to %s", ui.IconSuccess, len(wl.List()), arg)))
+
+	case "import":
+		f, err := os.Open(arg)
+		if err != nil {
+			exitWhitelistError(exitError, fmt.Errorf("cannot open %s: %w", arg, err))
+		}
+		added, importErr := wl.Import(f)
+		f.Close()
+		if importErr != nil {
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("  %s Skipped invalid patterns:", ui.IconWarning)))
+			for _, line := range strings.Split(importErr.Error(), "\n") {
+				fmt.Println(ui.MutedStyle().Render("    " + line))
+			}
+		}
+		saveWhitelist(wl, fmt.Sprintf("Imported %d new pattern(s) from %s", added, arg))
+
+	default:
+		exitWhitelistError(exitUsage, fmt.Errorf("unknown whitelist action %q (use add, remove, defaults, export, or import)", action))
+	}
+	fmt.Println()
+}
+
+// saveWhitelist persists wl and prints msg on success.
+func saveWhitelist(wl *whitelist.Whitelist, msg string) {
+	if err := wl.Save(); err != nil {
+		exitWhitelistError(exitError, err)
+	}
+	fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s %s", ui.IconSuccess, msg)))
+}
+
+// exitWhitelistError reports a whitelist failure and exits with code.
+func exitWhitelistError(code int, err error) {
+	fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+	os.Exit(code)
+}
+
+// ─── Free Space Reporting ────────────────────────────────────────────────────
+
+// projectedGainByDrive sums the bytes queued for deletion per drive (e.g. "C:").
+// The all-drives Recycle Bin, Go module cache, and Windows.old are attributed
+// to the system drive.
+func projectedGainByDrive(
+	results []clean.ScanResult,
+	recycleBin clean.RecycleBinInfo,
+	goModSize, windowsOldSize int64,
+) map[string]int64 {
+	sysDrive := strings.ToUpper(os.Getenv("SystemDrive"))
+	if sysDrive == "" {
+		sysDrive = "C:"
+	}
+
+	gains := make(map[string]int64)
+	for _, r := range results {
+		for _, item := range r.Items {
+			if item.Locked {
+				continue
+			}
+			if vol := strings.ToUpper(filepath.VolumeName(item.Path)); vol != "" {
+				gains[vol] += item.Size
+			}
+		}
+	}
+	if recycleBin.Items > 0 {
+		drive := recycleBin.Drive
+		if drive == "" {
+			drive = sysDrive
+		}
+		gains[drive] += recycleBin.Size
+	}
+	gains[sysDrive] += goModSize + windowsOldSize
+	if gains[sysDrive] == 0 {
+		delete(gains, sysDrive)
+	}
+	return gains
+}
+
+// freeSpaceByDrive queries current free space for each drive in gains.
+// Drives that cannot be queried are omitted.
+func freeSpaceByDrive(gains map[string]int64) map[string]uint64 {
+	free := make(map[string]uint64, len(gains))
+	for drive := range gains {
+		if f, err := core.GetDiskFreeSpace(drive); err == nil {
+			free[drive] = f
+		}
+	}
+	return free
+}
+
+// sortedDrives returns the drives in m in drive-letter order.
+func sortedDrives(m map[string]int64) []string {
+	drives := make([]string, 0, len(m))
+	for d := range m {
+		drives = append(drives, d)
+	}
+	sort.Strings(drives)
+	return drives
+}
+
+// printProjectedFreeSpace shows the free space each drive would gain (dry run).
+func printProjectedFreeSpace(gains map[string]int64) {
+	if len(gains) == 0 {
+		return
+	}
+	free := freeSpaceByDrive(gains)
+	for _, drive := range sortedDrives(gains) {
+		line := fmt.Sprintf("  %s would gain %s", drive, core.FormatSize(gains[drive]))
+		if f, ok := free[drive]; ok {
+			line += fmt.Sprintf(" (%s free → %s)",
+				core.FormatSize(int64(f)), core.FormatSize(int64(f)+gains[drive]))
+		}
+		fmt.Println(ui.MutedStyle().Render(line))
+	}
+	fmt.Println()
+}
+
+// printFreeSpaceDelta re-queries free space and prints the change per drive.
+func printFreeSpaceDelta(before map[string]uint64) {
+	drives := make([]string, 0, len(before))
+	for d := range before {
+		drives = append(drives, d)
+	}
+	sort.Strings(drives)
+
+	for _, drive := range drives {
+		after, err := core.GetDiskFreeSpace(drive)
+		if err != nil {
+			continue
+		}
+		delta := int64(after) - int64(before[drive])
+		sign := "+"
+		if delta < 0 {
+			sign, delta = "-", -delta
+		}
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+			"  %s had %s free, now %s free (%s%s)",
+			drive, core.FormatSize(int64(before[drive])), core.FormatSize(int64(after)),
+			sign, core.FormatSize(delta))))
+	}
+}
+
+// openBrowser is a browser found running while its cache is queued for cleaning.
+type openBrowser struct {
+	name  string
+	image string
+}
+
+// runningBrowsers returns each running browser that owns a browser-category
+// result, once per browser.
+func runningBrowsers(results []clean.ScanResult) []openBrowser {
+	seen := make(map[string]bool)
+	var out []openBrowser
+	for _, r := range results {
+		if len(r.Items) == 0 || r.Items[0].Category != "browser" {
+			continue
+		}
+		name, image, running := clean.RunningBrowserFor(r.Category)
+		if !running || seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, openBrowser{name: name, image: image})
+	}
+	return out
+}
+
+// writeCleanReport appends report to path, warning (not failing) on error.
+func writeCleanReport(report *core.CleanReport, path string) {
+	if err := report.AppendToFile(path); err != nil {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  Could not write report: %v", ui.IconWarning, err)))
+		return
+	}
+	fmt.Println(ui.MutedStyle().Render(
+		fmt.Sprintf("  Report appended to %s", path)))
+}
+
+// targetNames returns the Name of each target.
+func targetNames(targets []config.CleanTarget) []string {
+	names := make([]string, 0, len(targets))
+	for _, t := range targets {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// validateTargetNames checks names against the known clean targets and
+// suggests the closest match for any typo.
+func validateTargetNames(names []string) error {
+	known := targetNames(config.GetCleanTargets())
+	for _, name := range names {
+		found := false
+		for _, k := range known {
+			if strings.EqualFold(name, k) {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		if guess := analyze.ClosestMatch(name, known); guess != "" {
+			return fmt.Errorf("unknown clean target %q (did you mean %q?)", name, guess)
+		}
+		return fmt.Errorf("unknown clean target %q (known: %s)", name, strings.Join(known, ", "))
+	}
+	return nil
+}
+
+// selectNamedTargets returns the config targets in the enabled categories,
+// narrowed to only (when non-empty) and minus skip. Names match
+// case-insensitively.
+func selectNamedTargets(all, user, system, browser, dev bool, only, skip []string) []config.CleanTarget {
+	contains := func(list []string, name string) bool {
+		for _, n := range list {
+			if strings.EqualFold(n, name) {
+				return true
+			}
+		}
+		return false
+	}
+	categoryOn := map[string]bool{
+		"user":    all || user,
+		"system":  all || system,
+		"browser": all || browser,
+		"dev":     all || dev,
+	}
+
+	var out []config.CleanTarget
+	for _, t := range config.GetCleanTargets() {
+		if !categoryOn[t.Category] {
+			continue
+		}
+		if len(only) > 0 && !contains(only, t.Name) {
+			continue
+		}
+		if contains(skip, t.Name) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// filterResultsByTarget keeps the items of results that lie under one of
+// the named targets' paths. Items no config target covers, such as junk
+// on other drives, are kept only when no --only list was given.
+func filterResultsByTarget(results []clean.ScanResult, named []config.CleanTarget, only bool) []clean.ScanResult {
+	all := config.GetCleanTargets()
+	var out []clean.ScanResult
+	for _, r := range results {
+		var items []clean.CleanItem
+		for _, item := range r.Items {
+			switch {
+			case slices.ContainsFunc(named, func(t config.CleanTarget) bool { return targetCovers(t, item.Path) }):
+				items = append(items, item)
+			case !only && !slices.ContainsFunc(all, func(t config.CleanTarget) bool { return targetCovers(t, item.Path) }):
+				items = append(items, item)
+			}
+		}
+		if len(items) > 0 {
+			out = append(out, clean.ItemsToResult(r.Category, items))
+		}
+	}
+	return out
+}
+
+// targetCovers reports whether path is at or under one of t's paths,
+// whose components may be globs. Matching ignores case.
+func targetCovers(t config.CleanTarget, path string) bool {
+	split := func(p string) []string {
+		return strings.FieldsFunc(strings.ToLower(filepath.Clean(p)), func(r rune) bool {
+			return r == '\\' || r == '/'
+		})
+	}
+	parts := split(path)
+	for _, p := range t.Paths {
+		pattern := split(p)
+		if len(pattern) == 0 || len(pattern) > len(parts) {
+			continue
+		}
+		matched := true
+		for i, elem := range pattern {
+			if ok, err := filepath.Match(elem, parts[i]); err != nil || !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// riskAllowedTarget reports whether the named clean target passes the risk
+// filter. Used for targets handled outside ScanAll (Recycle Bin, Windows.old).
+func riskAllowedTarget(name, maxRisk string, includeHigh bool) bool {
+	for _, t := range config.GetCleanTargets() {
+		if t.Name == name {
+			return config.RiskAllowed(t.RiskLevel, maxRisk, includeHigh)
+		}
+	}
+	return true
+}
+
 // groupItemsByDescription groups CleanItems by their Description field.
 func groupItemsByDescription(items []clean.CleanItem) map[string][]clean.CleanItem {
 	groups := make(map[string][]clean.CleanItem)
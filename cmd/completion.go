@@ -15,14 +15,32 @@ const (
 	completionMarkerEnd   = "# END PureWin completion"
 )
 
+// completionShells lists the shells accepted by "pw completion <shell>".
+var completionShells = []string{"powershell", "bash", "zsh", "fish"}
+
 var completionCmd = &cobra.Command{
-	Use:   "completion",
-	Short: "Generate PowerShell tab completion",
-	Long:  "Generate or install PowerShell tab completion for PureWin (pw).",
+	Use:   "completion [powershell|bash|zsh|fish]",
+	Short: "Generate shell tab completion",
+	Long: `Generate tab completion for PureWin (pw). PowerShell is the default;
+bash, zsh, and fish are supported for WSL and Git Bash users.
+
+The script is printed to stdout so it can be redirected to a file.
+Use --install to add PowerShell completion to your profile.`,
+	ValidArgs: completionShells,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		install, _ := cmd.Flags().GetBool("install")
 		uninstall, _ := cmd.Flags().GetBool("uninstall")
 
+		shell := "powershell"
+		if len(args) == 1 {
+			shell = args[0]
+		}
+
+		if (install || uninstall) && shell != "powershell" {
+			return fmt.Errorf("--install and --uninstall only support PowerShell")
+		}
+
 		if uninstall {
 			return uninstallCompletion()
 		}
@@ -32,7 +50,7 @@ var completionCmd = &cobra.Command{
 		}
 
 		// Default: print to stdout
-		return printCompletion()
+		return printCompletion(shell)
 	},
 }
 
@@ -41,16 +59,43 @@ func init() {
 	completionCmd.Flags().Bool("uninstall", false, "Remove completion from PowerShell profile")
 }
 
-// printCompletion outputs the completion script to stdout
-func printCompletion() error {
-	return rootCmd.GenPowerShellCompletion(os.Stdout)
+// printCompletion outputs the completion script for shell to stdout, with
+// a note on where to source it on stderr so redirection stays clean.
+func printCompletion(shell string) error {
+	var (
+		err  error
+		hint string
+	)
+	switch shell {
+	case "bash":
+		err = rootCmd.GenBashCompletionV2(os.Stdout, true)
+		hint = "pw completion bash > ~/.pw-completion.bash, then add 'source ~/.pw-completion.bash' to ~/.bashrc"
+	case "zsh":
+		err = rootCmd.GenZshCompletion(os.Stdout)
+		hint = "pw completion zsh > \"${fpath[1]}/_pw\", then restart zsh"
+	case "fish":
+		err = rootCmd.GenFishCompletion(os.Stdout, true)
+		hint = "pw completion fish > ~/.config/fish/completions/pw.fish"
+	default:
+		err = rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		hint = "pw completion --install, or add 'pw completion | Out-String | Invoke-Expression' to $PROFILE"
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate %s completion script: %w", shell, err)
+	}
+
+	if fi, statErr := os.Stdout.Stat(); statErr == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, ui.MutedStyle().Render("# To enable: "+hint))
+	}
+	return nil
 }
 
 // installCompletion generates and installs the completion script to the PowerShell profile
 func installCompletion() error {
 	// Generate completion script to a string
 	var buf strings.Builder
-	if err := rootCmd.GenPowerShellCompletion(&buf); err != nil {
+	if err := rootCmd.GenPowerShellCompletionWithDesc(&buf); err != nil {
 		return fmt.Errorf("failed to generate completion script: %w", err)
 	}
 
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage default settings",
+	Long: `Manage the settings file that supplies defaults for clean, analyze,
+purge, and status when the corresponding flags are not passed.`,
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented default settings file",
+	Args:  cobra.NoArgs,
+	Run:   runConfigInit,
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the settings file location",
+	Args:  cobra.NoArgs,
+	Run:   runConfigPath,
+}
+
+func init() {
+	configInitCmd.Flags().Bool("force", false, "Overwrite an existing settings file")
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configPathCmd)
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) {
+	force, _ := cmd.Flags().GetBool("force")
+
+	path, err := config.WriteDefaultSettings(force)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		if path != "" {
+			fmt.Println(ui.MutedStyle().Render("  Use --force to overwrite it."))
+		}
+		os.Exit(1)
+	}
+
+	fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s Wrote default settings to %s", ui.IconSuccess, path)))
+}
+
+func runConfigPath(cmd *cobra.Command, args []string) {
+	path, err := config.SettingsPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(path)
+}
+
+// loadSettings returns the user's settings, warning and falling back to
+// built-in defaults when the file cannot be read.
+func loadSettings() *config.Settings {
+	s, err := config.LoadSettings()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.WarningStyle().Render(
+			fmt.Sprintf("  %s Ignoring settings: %v", ui.IconWarning, err)))
+		return &config.Settings{}
+	}
+	return s
+}
+
+// tuiOptions returns the bubbletea options for the full-screen TUIs. Mouse
+// capture is on unless --no-mouse, or the no_mouse setting when the flag
+// is not passed, turns it off.
+func tuiOptions(cmd *cobra.Command, s *config.Settings) []tea.ProgramOption {
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	noMouse := s.NoMouse
+	if cmd.Flags().Changed("no-mouse") {
+		noMouse, _ = cmd.Flags().GetBool("no-mouse")
+	}
+	if !noMouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	return opts
+}
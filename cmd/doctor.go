@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check whether PureWin can do its job here",
+	Long: `Probe the environment and report readiness: elevation, terminal
+support, Windows version, required environment variables, free space per
+drive, settings, and where PureWin is running from.`,
+	Args: cobra.NoArgs,
+	Run:  runDoctor,
+}
+
+// doctorEnvVars are the variables PureWin derives its cleanup paths from.
+var doctorEnvVars = []string{
+	"USERPROFILE", "LOCALAPPDATA", "APPDATA", "TEMP",
+	"WINDIR", "SYSTEMDRIVE", "PROGRAMDATA",
+}
+
+// doctorLowSpace is the free-space level below which a drive is flagged.
+const doctorLowSpace = 5 * 1024 * 1024 * 1024
+
+func runDoctor(cmd *cobra.Command, args []string) {
+	warnings := 0
+	check := func(ok bool, label, detail string) {
+		icon := ui.SuccessStyle().Render(ui.IconSuccess)
+		if !ok {
+			icon = ui.WarningStyle().Render(ui.IconWarning)
+			warnings++
+		}
+		fmt.Printf("  %s %-22s %s\n", icon, label, ui.MutedStyle().Render(detail))
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Doctor", 50))
+	fmt.Println()
+
+	// ── System ──
+	check(core.IsWindows10OrAbove(), "Windows version", core.WindowsVersionString())
+	if core.IsElevated() {
+		check(true, "Administrator", "elevated")
+	} else {
+		check(false, "Administrator", "not elevated — system targets need: pw --admin <command>")
+	}
+	if ui.IsVTEnabled() {
+		check(true, "Terminal", "ANSI/VT processing enabled")
+	} else {
+		check(false, "Terminal", "no VT support — live views fall back to plain output")
+	}
+
+	// ── Environment ──
+	fmt.Println()
+	for _, name := range doctorEnvVars {
+		if v := os.Getenv(name); v != "" {
+			check(true, "%"+name+"%", v)
+		} else {
+			check(false, "%"+name+"%", "not set — related paths are derived or skipped")
+		}
+	}
+
+	// ── Drives ──
+	fmt.Println()
+	drives, err := core.MountedDrives()
+	if err != nil {
+		check(false, "Drives", err.Error())
+	}
+	for _, d := range drives {
+		free, err := core.GetDiskFreeSpace(d)
+		if err != nil {
+			// Empty card readers and optical drives report "not ready".
+			fmt.Printf("  %s %-22s %s\n", ui.MutedStyle().Render(ui.IconCircle), d,
+				ui.MutedStyle().Render("not ready"))
+			continue
+		}
+		check(free >= doctorLowSpace, d, core.FormatSize(int64(free))+" free")
+	}
+
+	// ── PureWin ──
+	fmt.Println()
+	if path, err := config.SettingsPath(); err != nil {
+		check(false, "Settings", err.Error())
+	} else if _, err := config.LoadSettings(); err != nil {
+		check(false, "Settings", err.Error())
+	} else if _, statErr := os.Stat(path); statErr != nil {
+		check(true, "Settings", "defaults (run pw config init to customize)")
+	} else {
+		check(true, "Settings", path)
+	}
+
+	if exe, err := os.Executable(); err != nil {
+		check(false, "Executable", err.Error())
+	} else {
+		exe, _ = filepath.EvalSymlinks(exe)
+		if config.IsProtectedPath(exe) {
+			check(false, "Executable", exe+" (protected location — pw update needs admin)")
+		} else {
+			check(true, "Executable", exe)
+		}
+	}
+
+	fmt.Println()
+	if warnings == 0 {
+		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s Ready", ui.IconSuccess)))
+	} else {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf("  %s %d warning(s)", ui.IconWarning, warnings)))
+	}
+	fmt.Println()
+}
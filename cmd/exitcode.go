@@ -0,0 +1,57 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// Exit codes for scripting. clean, uninstall, purge, and installer use the
+// full set; the README documents it, so the numbers must not change.
+const (
+	exitOK             = 0 // finished, or the user cancelled at a prompt
+	exitError          = 1 // unexpected failure: config, scan, or I/O
+	exitUsage          = 2 // invalid arguments or flags
+	exitNothingToDo    = 3 // nothing found to clean, purge, or uninstall
+	exitPartial        = 4 // some items could not be deleted or uninstalled
+	exitNeedsAdmin     = 5 // requested work needs an elevated prompt
+	exitRebootRequired = 6 // succeeded, but a restart finishes the job
+)
+
+// exitStatus is the code Execute returns once the command has run. Commands
+// that fail outright still exit immediately with one of the codes above.
+var exitStatus = exitOK
+
+// setExitStatus records the code the finished command should exit with.
+func setExitStatus(code int) {
+	exitStatus = code
+}
+
+// runError marks an error returned by a command's RunE, so Execute can tell
+// it from the flag and argument errors cobra reports before running it.
+type runError struct{ err error }
+
+func (e runError) Error() string { return e.err.Error() }
+func (e runError) Unwrap() error { return e.err }
+
+// markRunErrors wraps the RunE of c and every subcommand so the errors they
+// return are marked as runError.
+func markRunErrors(c *cobra.Command) {
+	if run := c.RunE; run != nil {
+		c.RunE = func(cmd *cobra.Command, args []string) error {
+			if err := run(cmd, args); err != nil {
+				return runError{err}
+			}
+			return nil
+		}
+	}
+	for _, sub := range c.Commands() {
+		markRunErrors(sub)
+	}
+}
+
+// deleteErrorExitCode is the exit code for a batch delete that reported
+// errors, given how many items it did delete: a partial failure, or a
+// plain failure when nothing was removed.
+func deleteErrorExitCode(deleted int) int {
+	if deleted == 0 {
+		return exitError
+	}
+	return exitPartial
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"os"
 	"sort"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/lakshaymaurya-felt/purewin/internal/core"
@@ -34,11 +32,11 @@ func runInstaller(cmd *cobra.Command, args []string) {
 
 	var minSize int64
 	if minSizeStr != "" {
-		size, err := parseSize(minSizeStr)
+		size, err := core.ParseSize(minSizeStr)
 		if err != nil {
 			fmt.Printf("%s Invalid size format: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
 			fmt.Println(ui.MutedStyle().Render("  Examples: 10MB, 1GB, 500KB"))
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		minSize = size
 	}
@@ -55,7 +53,7 @@ func runInstaller(cmd *cobra.Command, args []string) {
 	files, err := installer.ScanInstallers(minAge, minSize)
 	if err != nil {
 		spinner.StopWithError(fmt.Sprintf("Scan failed: %v", err))
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	spinner.Stop(fmt.Sprintf("Found %d installer files", len(files)))
@@ -64,6 +62,7 @@ func runInstaller(cmd *cobra.Command, args []string) {
 		fmt.Println()
 		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s No installer files found!", ui.IconCheck)))
 		fmt.Println()
+		setExitStatus(exitNothingToDo)
 		return
 	}
 
@@ -74,7 +73,7 @@ func runInstaller(cmd *cobra.Command, args []string) {
 	selected, err := ui.RunSelector(items, "Select installer files to delete:")
 	if err != nil {
 		fmt.Printf("%s Selector error: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	if selected == nil || len(selected) == 0 {
@@ -104,11 +103,11 @@ func runInstaller(cmd *cobra.Command, args []string) {
 	fmt.Println()
 
 	// Confirm
-	if !dryRun {
+	if !dryRun && !assumeYes {
 		confirmed, err := ui.Confirm("Proceed with deletion?")
 		if err != nil {
 			fmt.Printf("%s Error: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
-			os.Exit(1)
+			os.Exit(exitError)
 		}
 		if !confirmed {
 			fmt.Println()
@@ -131,6 +130,7 @@ func runInstaller(cmd *cobra.Command, args []string) {
 		fmt.Println()
 		if cleanErr != nil {
 			fmt.Printf("%s Completed with errors: %v\n", ui.WarningStyle().Render(ui.IconWarning), cleanErr)
+			setExitStatus(deleteErrorExitCode(count))
 		} else {
 			fmt.Printf("%s Success!\n", ui.SuccessStyle().Render(ui.IconSuccess))
 		}
@@ -209,47 +209,3 @@ func formatInstallerAge(d time.Duration) string {
 	}
 	return fmt.Sprintf("%d months", months)
 }
-
-// parseSize parses a size string like "10MB", "1.5GB" to bytes.
-func parseSize(s string) (int64, error) {
-	s = strings.TrimSpace(strings.ToUpper(s))
-
-	// Extract number and unit
-	var numStr string
-	var unit string
-	for i, r := range s {
-		if r >= '0' && r <= '9' || r == '.' {
-			numStr += string(r)
-		} else {
-			unit = s[i:]
-			break
-		}
-	}
-
-	if numStr == "" {
-		return 0, fmt.Errorf("no number found in size string")
-	}
-
-	num, err := strconv.ParseFloat(numStr, 64)
-	if err != nil {
-		return 0, fmt.Errorf("invalid number: %w", err)
-	}
-
-	multiplier := int64(1)
-	switch unit {
-	case "B", "":
-		multiplier = 1
-	case "KB", "K":
-		multiplier = 1024
-	case "MB", "M":
-		multiplier = 1024 * 1024
-	case "GB", "G":
-		multiplier = 1024 * 1024 * 1024
-	case "TB", "T":
-		multiplier = 1024 * 1024 * 1024 * 1024
-	default:
-		return 0, fmt.Errorf("unknown unit: %s", unit)
-	}
-
-	return int64(num * float64(multiplier)), nil
-}
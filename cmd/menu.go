@@ -185,9 +185,7 @@ func (m mainMenuModel) View() string {
 // the selected command name. Returns "" if the user quit without selecting.
 func runMainMenu() (string, error) {
 	m := newMainMenuModel()
-	p := tea.NewProgram(m, tea.WithAltScreen())
-
-	final, err := p.Run()
+	final, err := ui.RunProgram(m, tea.WithAltScreen())
 	if err != nil {
 		return "", fmt.Errorf("menu error: %w", err)
 	}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -23,6 +24,7 @@ func init() {
 	optimizeCmd.Flags().Bool("whitelist", false, "Manage protected optimization rules")
 	optimizeCmd.Flags().Bool("services", false, "Restart system services only")
 	optimizeCmd.Flags().Bool("maintenance", false, "Run maintenance tasks only")
+	optimizeCmd.Flags().Bool("deep", false, "Also offer slow maintenance: DISM cleanup, SFC check, search index rebuild")
 	optimizeCmd.Flags().Bool("startup", false, "Manage startup programs only")
 }
 
@@ -37,6 +39,7 @@ func runOptimize(cmd *cobra.Command, args []string) {
 	servicesOnly, _ := cmd.Flags().GetBool("services")
 	maintenanceOnly, _ := cmd.Flags().GetBool("maintenance")
 	startupOnly, _ := cmd.Flags().GetBool("startup")
+	deep, _ := cmd.Flags().GetBool("deep")
 
 	// If --startup, show startup items and return.
 	if startupOnly {
@@ -44,8 +47,11 @@ func runOptimize(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// Fail fast: service and maintenance tasks require admin.
-	if !core.IsElevated() && !dryRun {
+	runAll := !servicesOnly && !maintenanceOnly
+
+	// Fail fast: service tasks require admin. Maintenance tasks check
+	// elevation individually so --maintenance can run the rest.
+	if (servicesOnly || runAll) && !core.IsElevated() && !dryRun {
 		fmt.Println()
 		fmt.Println(ui.ErrorStyle().Render(
 			fmt.Sprintf("  %s  Optimization tasks require administrator privileges.", ui.IconError)))
@@ -60,9 +66,15 @@ func runOptimize(cmd *cobra.Command, args []string) {
 	fmt.Println()
 	fmt.Println(ui.SectionHeader("System Optimization", 50))
 	fmt.Println()
+	edition := ""
+	if core.IsWindowsServer() {
+		edition = " · Server"
+	}
+	fmt.Printf("  %s %s\n", ui.MutedStyle().Render("Detected:"),
+		ui.InfoStyle().Render(core.WindowsVersionString()+edition))
+	fmt.Println()
 
 	var results []optimizeResult
-	runAll := !servicesOnly && !maintenanceOnly
 
 	// ── Services ──
 	if servicesOnly || runAll {
@@ -71,7 +83,7 @@ func runOptimize(cmd *cobra.Command, args []string) {
 
 	// ── Maintenance ──
 	if maintenanceOnly || runAll {
-		results = append(results, runMaintenanceOptimizations()...)
+		results = append(results, runMaintenanceOptimizations(deep)...)
 	}
 
 	// ── Summary ──
@@ -85,14 +97,13 @@ func runServiceOptimizations() []optimizeResult {
 
 	var results []optimizeResult
 
-	// DNS flush.
-	results = append(results, runOptimizeTask("Flush DNS cache", func() error {
-		return optimize.FlushDNS()
-	}))
-
 	// Restart managed services.
 	for _, svc := range optimize.GetManagedServices() {
 		svc := svc // capture for closure
+		if reason := svc.Requires.Unmet(); reason != "" {
+			printUnsupported("Restart "+svc.DisplayName, reason)
+			continue
+		}
 		results = append(results, runOptimizeTask(
 			fmt.Sprintf("Restart %s", svc.DisplayName),
 			func() error {
@@ -105,37 +116,61 @@ func runServiceOptimizations() []optimizeResult {
 	return results
 }
 
-// runMaintenanceOptimizations executes maintenance tasks.
-func runMaintenanceOptimizations() []optimizeResult {
+// runMaintenanceOptimizations offers each refresh-type maintenance task
+// for confirmation and runs the accepted ones. Deep tasks are only listed
+// unless deep is set.
+func runMaintenanceOptimizations(deep bool) []optimizeResult {
 	fmt.Println(ui.SectionHeader("Maintenance", 50))
 	fmt.Println()
 
 	var results []optimizeResult
+	elevated := core.IsElevated()
+
+	for _, task := range optimize.GetMaintenanceTasks() {
+		fmt.Printf("  %s %s\n", ui.BoldStyle().Render(task.Name),
+			ui.MutedStyle().Render("— "+task.Description))
 
-	results = append(results, runOptimizeTask("DISM component cleanup", func() error {
-		return optimize.RunDISMCleanup()
-	}))
+		if task.Deep && !deep {
+			fmt.Println(ui.MutedStyle().Render(
+				fmt.Sprintf("  %s Not run: opt in with --deep", ui.IconCircle)))
+			continue
+		}
 
-	results = append(results, runOptimizeTask("System file integrity check", func() error {
-		return optimize.RunSFCCheck()
-	}))
+		if reason := task.Requires.Unmet(); reason != "" {
+			printUnsupported(task.Name, reason)
+			continue
+		}
 
-	results = append(results, runOptimizeTask("Rebuild icon cache", func() error {
-		return optimize.RebuildIconCache()
-	}))
+		if task.NeedsAdmin && !elevated && !dryRun {
+			err := fmt.Errorf("requires administrator privileges")
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("  %s Skipped: %s (re-run with pw --admin)", ui.IconWarning, err)))
+			results = append(results, optimizeResult{Name: task.Name, Success: false, Error: err})
+			continue
+		}
 
-	results = append(results, runOptimizeTask("Rebuild search index", func() error {
-		return optimize.RebuildSearchIndex()
-	}))
+		if !dryRun {
+			ok, err := ui.Confirm(fmt.Sprintf("  Run %s?", strings.ToLower(task.Name)))
+			if err != nil || !ok {
+				fmt.Println(ui.MutedStyle().Render("  Skipped."))
+				continue
+			}
+		}
 
-	results = append(results, runOptimizeTask("Clear event logs", func() error {
-		return optimize.ClearEventLogs()
-	}))
+		results = append(results, runOptimizeTask(task.Name, task.Run))
+	}
 
 	fmt.Println()
 	return results
 }
 
+// printUnsupported reports an action skipped because this Windows version
+// does not support it. Such skips are not counted as failures.
+func printUnsupported(name, reason string) {
+	fmt.Println(ui.MutedStyle().Render(
+		fmt.Sprintf("  %s Skipped %s: %s", ui.IconCircle, name, reason)))
+}
+
 // runOptimizeTask runs a single optimization task with spinner feedback.
 func runOptimizeTask(name string, fn func() error) optimizeResult {
 	if dryRun {
@@ -34,7 +34,7 @@ func runPurge(cmd *cobra.Command, args []string) {
 	cfg, err := config.Load()
 	if err != nil {
 		fmt.Printf("%s Failed to load config: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	// Check --paths flag
@@ -44,6 +44,18 @@ func runPurge(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Parse size filter
+	var minSize int64
+	if minSizeStr, _ := cmd.Flags().GetString("min-size"); minSizeStr != "" {
+		size, err := core.ParseSize(minSizeStr)
+		if err != nil {
+			fmt.Printf("%s Invalid size format: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
+			fmt.Println(ui.MutedStyle().Render("  Examples: 50MB, 1GiB, 500KB"))
+			os.Exit(exitUsage)
+		}
+		minSize = size
+	}
+
 	// Start scanning
 	fmt.Println()
 	fmt.Println(ui.SectionHeader("Project Purge", 50))
@@ -58,14 +70,32 @@ func runPurge(cmd *cobra.Command, args []string) {
 		spinner.StopWithError("No scan paths configured")
 		fmt.Println()
 		fmt.Println(ui.MutedStyle().Render("  Run 'pw purge --paths' to configure scan directories."))
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+
+	// Restrict artifact types from settings
+	settings := loadSettings()
+	if err := purge.SetArtifactTypes(settings.PurgeArtifacts); err != nil {
+		spinner.StopWithError(fmt.Sprintf("Invalid purge_artifacts setting: %v", err))
+		os.Exit(exitError)
 	}
 
 	// Scan for artifacts
 	artifacts, err := purge.ScanProjects(scanPaths)
 	if err != nil {
 		spinner.StopWithError(fmt.Sprintf("Scan failed: %v", err))
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+
+	// Drop artifacts below --min-size
+	if minSize > 0 {
+		filtered := artifacts[:0]
+		for _, a := range artifacts {
+			if a.Size >= minSize {
+				filtered = append(filtered, a)
+			}
+		}
+		artifacts = filtered
 	}
 
 	spinner.Stop(fmt.Sprintf("Found %d artifacts", len(artifacts)))
@@ -74,6 +104,7 @@ func runPurge(cmd *cobra.Command, args []string) {
 		fmt.Println()
 		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s No project artifacts found!", ui.IconCheck)))
 		fmt.Println()
+		setExitStatus(exitNothingToDo)
 		return
 	}
 
@@ -84,7 +115,7 @@ func runPurge(cmd *cobra.Command, args []string) {
 	selected, err := ui.RunSelector(items, "Select artifacts to delete:")
 	if err != nil {
 		fmt.Printf("%s Selector error: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	if selected == nil || len(selected) == 0 {
@@ -118,11 +149,11 @@ func runPurge(cmd *cobra.Command, args []string) {
 	fmt.Println()
 
 	// Confirm
-	if !dryRun {
+	if !dryRun && !assumeYes {
 		confirmed, err := ui.Confirm("Proceed with deletion?")
 		if err != nil {
 			fmt.Printf("%s Error: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
-			os.Exit(1)
+			os.Exit(exitError)
 		}
 		if !confirmed {
 			fmt.Println()
@@ -134,7 +165,8 @@ func runPurge(cmd *cobra.Command, args []string) {
 
 	// Delete
 	fmt.Println()
-	freed, count, purgeErr := purge.PurgeArtifacts(selectedArtifacts, dryRun)
+	toRecycleBin := settings.DeleteMode == config.DeleteModeRecycle
+	freed, count, purgeErr := purge.PurgeArtifacts(selectedArtifacts, dryRun, toRecycleBin)
 
 	if dryRun {
 		fmt.Println()
@@ -145,6 +177,7 @@ func runPurge(cmd *cobra.Command, args []string) {
 		fmt.Println()
 		if purgeErr != nil {
 			fmt.Printf("%s Completed with errors: %v\n", ui.WarningStyle().Render(ui.IconWarning), purgeErr)
+			setExitStatus(deleteErrorExitCode(count))
 		} else {
 			fmt.Printf("%s Success!\n", ui.SuccessStyle().Render(ui.IconSuccess))
 		}
@@ -174,7 +207,7 @@ func managePurgePaths(cfg *config.Config) {
 		defaults := purge.GetDefaultScanPaths()
 		if err := purge.SaveCustomScanPaths(cfg.ConfigDir, defaults); err != nil {
 			fmt.Printf("%s Failed to create purge_paths: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
-			os.Exit(1)
+			os.Exit(exitError)
 		}
 	}
 
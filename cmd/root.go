@@ -2,16 +2,18 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
 	"github.com/lakshaymaurya-felt/purewin/internal/core"
 	"github.com/lakshaymaurya-felt/purewin/internal/shell"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/internal/update"
 )
 
 var (
@@ -21,6 +23,20 @@ var (
 	runAdmin bool
 	noColor  bool
 
+	// assumeYes skips confirmation prompts in clean, uninstall, purge, and
+	// installer. High-risk deletions additionally need --include-high.
+	assumeYes bool
+
+	// noUpdateCheck suppresses the background new-version notice.
+	noUpdateCheck bool
+
+	// themeName selects the color theme; empty falls back to settings.
+	themeName string
+
+	// logFile, when set, receives warnings and errors, plus every step
+	// with --debug.
+	logFile string
+
 	// Version info populated from main
 	appVersion = "dev"
 	appCommit  = "none"
@@ -43,8 +59,10 @@ All-in-one toolkit for system cleanup, app uninstallation,
 disk analysis, system optimization, and live monitoring.`,
 }
 
-// Execute runs the root command.
-func Execute() error {
+// Execute runs the root command and returns the process exit code. Flag
+// and argument errors reported by cobra exit with exitUsage; an error
+// from a command's own RunE exits with exitError.
+func Execute() int {
 	// Enable Windows Virtual Terminal Processing globally so ANSI escape
 	// codes render as colours in cmd.exe / PowerShell for ALL code paths
 	// (inline spinners, confirm dialogs, styled fmt.Print output, etc.).
@@ -61,7 +79,14 @@ func Execute() error {
 		os.Setenv("NO_COLOR", "1")
 	}
 
-	return rootCmd.Execute()
+	markRunErrors(rootCmd)
+	if err := rootCmd.Execute(); err != nil {
+		if errors.As(err, new(runError)) {
+			return exitError
+		}
+		return exitUsage
+	}
+	return exitStatus
 }
 
 func init() {
@@ -72,8 +97,15 @@ func init() {
 	}
 
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Show detailed operation logs")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Append warnings and errors to this file (every step with --debug)")
 	rootCmd.PersistentFlags().BoolVar(&runAdmin, "admin", false, "Re-launch PureWin with administrator privileges (UAC)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	rootCmd.PersistentFlags().BoolVar(&noUpdateCheck, "no-update-check", false, "Don't check for new PureWin versions")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", "", "Color theme: "+strings.Join(ui.ThemeNames(), ", "))
+	_ = rootCmd.RegisterFlagCompletionFunc("theme", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return ui.ThemeNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Skip confirmation prompts (high-risk targets also need --include-high)")
 
 	// PersistentPreRun: if --admin is set, re-launch elevated and exit.
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
@@ -81,6 +113,12 @@ func init() {
 		if noColor {
 			os.Setenv("NO_COLOR", "1")
 		}
+		applyTheme()
+		openLogFile()
+
+		// Remove the binary left behind by a previous self-update.
+		update.CleanupOldBinary()
+		startUpdateCheck(cmd)
 
 		if !runAdmin {
 			return
@@ -98,10 +136,15 @@ func init() {
 		}
 		if err := core.RunElevated(elevatedArgs); err != nil {
 			fmt.Fprintf(os.Stderr, "%s %v\n", ui.IconError, err)
-			os.Exit(1)
+			os.Exit(exitNeedsAdmin)
 		}
 	}
 
+	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		printUpdateNotice()
+		core.CloseDebugLog()
+	}
+
 	// Register all subcommands
 	rootCmd.AddCommand(cleanCmd)
 	rootCmd.AddCommand(uninstallCmd)
@@ -111,11 +154,48 @@ func init() {
 	rootCmd.AddCommand(purgeCmd)
 	rootCmd.AddCommand(installerCmd)
 	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(doctorCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(removeCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
+// applyTheme activates --theme, or the settings theme when the flag is not
+// given. An unknown name is reported and the default theme kept.
+func applyTheme() {
+	name := themeName
+	if name == "" {
+		if s, err := config.LoadSettings(); err == nil {
+			name = s.Theme
+		}
+	}
+	if name == "" {
+		return
+	}
+	if err := ui.ApplyTheme(name); err != nil {
+		fmt.Fprintln(os.Stderr, ui.WarningStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconWarning, err)))
+	}
+}
+
+// openLogFile starts the --log-file log, or with a bare --debug logs every
+// step to stderr. A log that cannot be opened is reported and the command
+// runs without it.
+func openLogFile() {
+	if logFile == "" {
+		if debug {
+			core.LogToStderr()
+		}
+		return
+	}
+	header := fmt.Sprintf("pw %s (%s)", strings.Join(os.Args[1:], " "), appVersion)
+	if err := core.OpenDebugLog(logFile, header, debug); err != nil {
+		fmt.Fprintln(os.Stderr, ui.WarningStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconWarning, err)))
+	}
+}
+
 // runInteractiveShell launches the persistent interactive shell with
 // slash-command autocomplete. The shell runs in a loop: each iteration
 // runs a bubbletea program; when the user invokes a command, the shell
@@ -134,11 +214,10 @@ func runInteractiveShell() {
 	m.AppendOutput("")
 
 	for {
-		p := tea.NewProgram(m)
-		finalModel, err := p.Run()
+		finalModel, err := ui.RunProgram(m)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%s Shell error: %v\n", ui.IconError, err)
-			os.Exit(1)
+			os.Exit(exitError)
 		}
 
 		result, ok := finalModel.(shell.ShellModel)
@@ -164,9 +243,11 @@ func runInteractiveShell() {
 
 			result.AppendOutput("")
 
-			// Clear the exec signal and relaunch shell.
+			// Clear the exec signal and relaunch shell. A command's exit
+			// status does not become the shell's.
 			result.ExecCmd = ""
 			result.ExecArgs = nil
+			setExitStatus(exitOK)
 		}
 
 		// Preserve state for next iteration.
@@ -232,6 +313,7 @@ func runSimpleShell() {
 				fmt.Printf("  Error: %s\n", err)
 				fmt.Println("  Type /help for available commands.")
 			}
+			setExitStatus(exitOK)
 			fmt.Println()
 		}
 	}
@@ -1,12 +1,12 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
-	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lakshaymaurya-felt/purewin/internal/jsonutil"
 	"github.com/lakshaymaurya-felt/purewin/internal/status"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
 	"github.com/spf13/cobra"
@@ -22,21 +22,82 @@ var statusCmd = &cobra.Command{
 func init() {
 	statusCmd.Flags().Int("refresh", 1, "Refresh interval in seconds")
 	statusCmd.Flags().Bool("json", false, "Output metrics as JSON")
+	statusCmd.Flags().String("log", "", "Append one CSV row of metrics per refresh to this file")
+	statusCmd.Flags().Bool("oneline", false, "Print a single-line summary (repeats when --refresh is set)")
+	statusCmd.Flags().Float64("alert-cpu", status.DefaultThresholds.CPU, "CPU percent shown as an alert (0 disables)")
+	statusCmd.Flags().Float64("alert-mem", status.DefaultThresholds.Memory, "Memory percent shown as an alert (0 disables)")
+	statusCmd.Flags().Float64("alert-disk", status.DefaultThresholds.Disk, "Disk used percent shown as an alert (0 disables)")
+	statusCmd.Flags().Float64("alert-temp", status.DefaultThresholds.Temp, "Temperature in °C shown as an alert (0 disables)")
+	statusCmd.Flags().Bool("alert-bell", false, "Ring the terminal bell when a metric crosses its alert threshold")
+	statusCmd.Flags().Bool("once", false, "Print one styled frame of the dashboard and exit")
+	statusCmd.Flags().String("tab", "overview", "Tab to show: overview, cpu, memory, disk, network, processes")
+	statusCmd.Flags().Bool("no-mouse", false, "Disable mouse support in the dashboard")
+	statusCmd.Flags().Int("procs", 0, fmt.Sprintf("Number of busiest processes to collect (default %d for JSON, %d for the dashboard)",
+		status.DefaultTopProcs, status.DashboardTopProcs))
+	_ = statusCmd.RegisterFlagCompletionFunc("tab", completeStatusTabs)
+}
+
+// completeStatusTabs offers the dashboard tab names for --tab.
+func completeStatusTabs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := strings.ToLower(toComplete)
+	var names []string
+	for _, name := range status.TabNames {
+		if name = strings.ToLower(name); strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
 func runStatus(cmd *cobra.Command, args []string) {
 	jsonMode, _ := cmd.Flags().GetBool("json")
+	settings := loadSettings()
 	refreshSecs, _ := cmd.Flags().GetInt("refresh")
+	if !cmd.Flags().Changed("refresh") && settings.StatusRefreshSeconds > 0 {
+		refreshSecs = settings.StatusRefreshSeconds
+	}
+	logPath, _ := cmd.Flags().GetString("log")
+	oneLine, _ := cmd.Flags().GetBool("oneline")
+	procs, _ := cmd.Flags().GetInt("procs")
+	jsonProcs, dashboardProcs := status.DefaultTopProcs, status.DashboardTopProcs
+	if procs > 0 {
+		jsonProcs, dashboardProcs = procs, procs
+	}
+	// Validate --tab up front so a typo fails in every output mode.
+	tabName, _ := cmd.Flags().GetString("tab")
+	tab, err := status.ParseTab(tabName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if oneLine {
+		runStatusOneLine(refreshSecs, cmd.Flags().Changed("refresh"))
+		return
+	}
 
 	if jsonMode {
 		// Single-shot: collect once, print JSON, exit.
-		metrics, err := status.CollectMetrics(nil, 0)
+		metrics, err := status.CollectMetrics(nil, 0, jsonProcs)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		data, _ := json.MarshalIndent(metrics, "", "  ")
-		fmt.Println(string(data))
+		if err := jsonutil.Write(os.Stdout, metrics); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if once, _ := cmd.Flags().GetBool("once"); once {
+		frame, err := status.NewStatusModel(0).WithTab(tab).
+			WithCollector(status.SystemCollector{TopProcs: dashboardProcs}).Snapshot()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(frame)
 		return
 	}
 
@@ -46,21 +107,72 @@ func runStatus(cmd *cobra.Command, args []string) {
 		fmt.Fprintln(os.Stderr, "Note: Live dashboard requires a modern terminal with ANSI support.")
 		fmt.Fprintln(os.Stderr, "Falling back to single-shot JSON output.")
 		fmt.Fprintln(os.Stderr, "")
-		metrics, err := status.CollectMetrics(nil, 0)
+		metrics, err := status.CollectMetrics(nil, 0, jsonProcs)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		data, _ := json.MarshalIndent(metrics, "", "  ")
-		fmt.Println(string(data))
+		if err := jsonutil.Write(os.Stdout, metrics); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		return
 	}
 
+	var thresholds status.Thresholds
+	thresholds.CPU, _ = cmd.Flags().GetFloat64("alert-cpu")
+	thresholds.Memory, _ = cmd.Flags().GetFloat64("alert-mem")
+	thresholds.Disk, _ = cmd.Flags().GetFloat64("alert-disk")
+	thresholds.Temp, _ = cmd.Flags().GetFloat64("alert-temp")
+	alertBell, _ := cmd.Flags().GetBool("alert-bell")
+
+	interval := time.Duration(refreshSecs) * time.Second
+	model := status.NewStatusModel(interval).WithTab(tab).WithAlerts(thresholds, alertBell).
+		WithCollector(status.SystemCollector{TopProcs: dashboardProcs})
+	if logPath != "" {
+		metricsLog, err := status.NewMetricsLogger(logPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer metricsLog.Close()
+		model = model.WithMetricsLog(metricsLog)
+	}
+	if _, err := ui.RunProgram(model, tuiOptions(cmd, settings)...); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runStatusOneLine prints a compact single-line summary without bubbletea.
+// A baseline sample is taken first so network speeds are meaningful even in
+// single-shot mode. With repeat set, a new line is printed every interval.
+func runStatusOneLine(refreshSecs int, repeat bool) {
 	interval := time.Duration(refreshSecs) * time.Second
-	model := status.NewStatusModel(interval)
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	plain := noColor || os.Getenv("NO_COLOR") != ""
+
+	prev, err := status.CollectMetrics(nil, 0, status.DefaultTopProcs)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+
+	for {
+		time.Sleep(interval)
+
+		metrics, err := status.CollectMetrics(&prev.Network, time.Since(prev.CollectedAt), status.DefaultTopProcs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(status.FormatOneLine(metrics, plain))
+
+		if !repeat {
+			return
+		}
+		prev = metrics
+	}
 }
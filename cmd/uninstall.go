@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -13,10 +14,12 @@ import (
 )
 
 var uninstallCmd = &cobra.Command{
-	Use:   "uninstall",
-	Short: "Remove apps completely",
-	Long:  "Thoroughly remove applications along with their registry entries, data, and hidden remnants.",
-	Run:   runUninstall,
+	Use:               "uninstall [app name]",
+	Short:             "Remove apps completely",
+	Long:              "Thoroughly remove applications along with their registry entries, data, and hidden remnants.",
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: completeAppNames,
+	Run:               runUninstall,
 }
 
 func init() {
@@ -24,9 +27,43 @@ func init() {
 	uninstallCmd.Flags().Bool("quiet", false, "Prefer silent uninstall commands")
 	uninstallCmd.Flags().Bool("show-all", false, "Show system components too")
 	uninstallCmd.Flags().String("search", "", "Search for apps by name")
+	uninstallCmd.Flags().Bool("edge-status", false, "Report which Edge uninstall prerequisites are in place, then exit")
+	_ = uninstallCmd.RegisterFlagCompletionFunc("search", completeAppNames)
+}
+
+// maxAppSuggestions caps how many app names a single completion returns.
+const maxAppSuggestions = 50
+
+// completeAppNames offers installed application names for the positional
+// argument and for --search. The registry is read afresh for every
+// request, so an app removed a moment ago is never offered.
+func completeAppNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	apps, err := uninstall.GetInstalledApps(false)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	prefix := strings.ToLower(toComplete)
+	var names []string
+	for _, app := range apps {
+		if strings.HasPrefix(strings.ToLower(app.Name), prefix) {
+			names = append(names, app.Name)
+			if len(names) == maxAppSuggestions {
+				break
+			}
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
 func runUninstall(cmd *cobra.Command, args []string) {
+	if edgeStatus, _ := cmd.Flags().GetBool("edge-status"); edgeStatus {
+		fmt.Println()
+		fmt.Println(ui.HeaderStyle().Render("  Edge uninstall prerequisites"))
+		uninstall.PrintEdgePrep(uninstall.EdgeStatus())
+		return
+	}
+
 	// Check if running as administrator and warn if not.
 	if !core.IsElevated() {
 		fmt.Println(ui.WarningStyle().Render(
@@ -39,6 +76,10 @@ func runUninstall(cmd *cobra.Command, args []string) {
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	showAll, _ := cmd.Flags().GetBool("show-all")
 	search, _ := cmd.Flags().GetString("search")
+	if search == "" && len(args) > 0 {
+		// "pw uninstall Foo Bar" is shorthand for --search "Foo Bar".
+		search = strings.Join(args, " ")
+	}
 
 	// Scan installed apps from the registry.
 	fmt.Println()
@@ -48,7 +89,7 @@ func runUninstall(cmd *cobra.Command, args []string) {
 	apps, err := uninstall.GetInstalledApps(showAll)
 	if err != nil {
 		spin.StopWithError(fmt.Sprintf("Failed to read registry: %s", err))
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 	spin.Stop(fmt.Sprintf("Found %d installed applications", len(apps)))
 
@@ -58,6 +99,7 @@ func runUninstall(cmd *cobra.Command, args []string) {
 		if len(apps) == 0 {
 			fmt.Println(ui.WarningStyle().Render(
 				fmt.Sprintf("  No applications matching %q found.", search)))
+			setExitStatus(exitNothingToDo)
 			return
 		}
 		fmt.Println(ui.InfoStyle().Render(
@@ -71,12 +113,30 @@ func runUninstall(cmd *cobra.Command, args []string) {
 	}
 
 	// Batch uninstall flow with selector.
-	if err := uninstall.RunBatchUninstall(apps, dryRun); err != nil {
+	result, err := uninstall.RunBatchUninstall(apps, dryRun, assumeYes)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "\n%s %s\n",
 			ui.ErrorStyle().Render(ui.IconError),
 			ui.ErrorStyle().Render(err.Error()))
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+	setExitStatus(batchUninstallExitCode(len(apps), result))
+}
+
+// batchUninstallExitCode maps a batch outcome to an exit code. Failures
+// outrank a pending restart; an empty app list means nothing to do.
+func batchUninstallExitCode(apps int, result uninstall.BatchResult) int {
+	switch {
+	case apps == 0:
+		return exitNothingToDo
+	case result.Failed > 0 && result.Uninstalled > 0:
+		return exitPartial
+	case result.Failed > 0:
+		return exitError
+	case result.RebootRequired > 0:
+		return exitRebootRequired
 	}
+	return exitOK
 }
 
 // filterAppsByName returns apps whose Name contains the search term
@@ -99,18 +159,30 @@ func runSingleUninstall(app uninstall.InstalledApp, dryRun bool, quiet bool) {
 		return
 	}
 
-	confirmed, err := ui.Confirm(fmt.Sprintf("Uninstall %s?", app.Name))
-	if err != nil || !confirmed {
-		fmt.Println(ui.MutedStyle().Render("  Cancelled."))
-		return
+	if !assumeYes {
+		confirmed, err := ui.Confirm(fmt.Sprintf("Uninstall %s?", app.Name))
+		if err != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+			return
+		}
 	}
 
 	spin := ui.NewInlineSpinner()
 	spin.Start(fmt.Sprintf("Uninstalling %s...", app.Name))
 
-	if uninstErr := uninstall.UninstallApp(app, quiet); uninstErr != nil {
+	uninstErr := uninstall.UninstallApp(app, quiet)
+	if errors.Is(uninstErr, uninstall.ErrRebootRequired) {
+		spin.Stop(fmt.Sprintf("Uninstalled %s — restart Windows to finish", app.Name))
+		setExitStatus(exitRebootRequired)
+		return
+	}
+	if uninstErr != nil {
 		spin.StopWithError(fmt.Sprintf("Failed: %s", uninstErr))
-		os.Exit(1)
+		var prepErr *uninstall.EdgePrepError
+		if errors.As(uninstErr, &prepErr) {
+			uninstall.PrintEdgePrep(prepErr.Prep)
+		}
+		os.Exit(exitError)
 	}
 	spin.Stop(fmt.Sprintf("Uninstalled %s", app.Name))
 }
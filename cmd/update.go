@@ -20,10 +20,56 @@ var updateCmd = &cobra.Command{
 
 func init() {
 	updateCmd.Flags().Bool("force", false, "Force reinstall latest version")
+	updateCmd.Flags().Bool("skip-verify", false, "Install even if the release has no checksum file")
+	updateCmd.Flags().Bool("disable-check", false, "Stop showing new-version notices")
+	updateCmd.Flags().Bool("enable-check", false, "Resume showing new-version notices")
+}
+
+// updateNoticeCacheDir is set once the background version check has been
+// started for this invocation; the post-run hook reads the cached result.
+var updateNoticeCacheDir string
+
+// startUpdateCheck kicks off the once-a-day background version check unless
+// it is disabled for this run or persistently.
+func startUpdateCheck(cmd *cobra.Command) {
+	if noUpdateCheck || appVersion == "dev" || !ui.IsTerminal() {
+		return
+	}
+	switch cmd.Name() {
+	case "update", "completion", "version",
+		cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil || cfg.DisableUpdateCheck {
+		return
+	}
+	updateNoticeCacheDir = cfg.CacheDir
+	update.CheckForUpdateBackground(appVersion, cfg.CacheDir)
+}
+
+// printUpdateNotice prints a one-line hint when a cached check found a newer
+// release. It only reads the cache, so it never delays the command.
+func printUpdateNotice() {
+	if updateNoticeCacheDir == "" {
+		return
+	}
+	latest := update.AvailableUpdate(appVersion, updateNoticeCacheDir)
+	updateNoticeCacheDir = ""
+	if latest == "" {
+		return
+	}
+	fmt.Println()
+	fmt.Println(ui.MutedStyle().Render(
+		fmt.Sprintf("  A new version v%s is available — run pw update", latest)))
 }
 
 func runUpdate(cmd *cobra.Command, args []string) {
 	force, _ := cmd.Flags().GetBool("force")
+	skipVerify, _ := cmd.Flags().GetBool("skip-verify")
+	disableCheck, _ := cmd.Flags().GetBool("disable-check")
+	enableCheck, _ := cmd.Flags().GetBool("enable-check")
 
 	// Load config
 	cfg, err := config.Load()
@@ -32,6 +78,19 @@ func runUpdate(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if disableCheck || enableCheck {
+		if err := cfg.SetUpdateCheck(enableCheck); err != nil {
+			fmt.Printf("%s Failed to save config: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
+			os.Exit(1)
+		}
+		state := "disabled"
+		if enableCheck {
+			state = "enabled"
+		}
+		fmt.Printf("  %s New-version notices %s\n", ui.SuccessStyle().Render(ui.IconSuccess), state)
+		return
+	}
+
 	fmt.Println()
 	fmt.Println(ui.SectionHeader("Update", 50))
 	fmt.Println()
@@ -44,11 +103,12 @@ func runUpdate(cmd *cobra.Command, args []string) {
 	spinner := ui.NewInlineSpinner()
 	spinner.Start("Checking for updates...")
 
-	latestVersion, downloadURL, err := update.CheckForUpdate(appVersion)
+	latest, err := update.CheckLatest()
 	if err != nil {
 		spinner.StopWithError(fmt.Sprintf("Update check failed: %v", err))
 		os.Exit(1)
 	}
+	latestVersion := latest.Version
 
 	spinner.Stop("Update check complete")
 
@@ -70,6 +130,15 @@ func runUpdate(cmd *cobra.Command, args []string) {
 	}
 	fmt.Println()
 
+	// Refuse an unverifiable download unless explicitly allowed.
+	if latest.ChecksumURL == "" && !skipVerify {
+		fmt.Printf("  %s Release has no checksum file; refusing to install unverified\n",
+			ui.ErrorStyle().Render(ui.IconError))
+		fmt.Println(ui.MutedStyle().Render("  Re-run with --skip-verify to install anyway."))
+		fmt.Println()
+		os.Exit(1)
+	}
+
 	// Confirm update
 	confirmed, err := ui.Confirm("Download and install update?")
 	if err != nil {
@@ -88,7 +157,7 @@ func runUpdate(cmd *cobra.Command, args []string) {
 	spinner = ui.NewInlineSpinner()
 	spinner.Start("Downloading update...")
 
-	tempPath, err := update.DownloadUpdate(downloadURL)
+	tempPath, err := update.DownloadUpdate(latest.DownloadURL)
 	if err != nil {
 		spinner.StopWithError(fmt.Sprintf("Download failed: %v", err))
 		os.Exit(1)
@@ -96,6 +165,21 @@ func runUpdate(cmd *cobra.Command, args []string) {
 
 	spinner.Stop("Download complete")
 
+	// Verify checksum
+	if latest.ChecksumURL == "" {
+		fmt.Printf("  %s Release has no checksum file; skipping verification (--skip-verify)\n",
+			ui.WarningStyle().Render(ui.IconWarning))
+	} else {
+		spinner = ui.NewInlineSpinner()
+		spinner.Start("Verifying checksum...")
+		if err := update.VerifyChecksum(tempPath, latest.ChecksumURL, latest.AssetName); err != nil {
+			spinner.StopWithError(fmt.Sprintf("Verification failed: %v", err))
+			_ = os.Remove(tempPath)
+			os.Exit(1)
+		}
+		spinner.Stop("Checksum verified")
+	}
+
 	// Apply update
 	spinner = ui.NewInlineSpinner()
 	spinner.Start("Installing update...")
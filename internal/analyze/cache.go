@@ -8,10 +8,7 @@ import (
 	"time"
 )
 
-const (
-	cacheFileName = "analyze_cache.json"
-	cacheTTL      = 5 * time.Minute
-)
+const cacheFileName = "analyze_cache.json"
 
 // cacheEntry wraps a scan result with metadata for validation.
 type cacheEntry struct {
@@ -21,8 +18,9 @@ type cacheEntry struct {
 	RootMtime time.Time `json:"root_mtime"`
 }
 
-// cacheDir returns the %APPDATA%\purewin directory, creating it if needed.
-func cacheDir() (string, error) {
+// defaultCacheDir returns the %APPDATA%\purewin directory, creating it if needed.
+// Used when the caller does not supply a cache directory.
+func defaultCacheDir() (string, error) {
 	appData := os.Getenv("APPDATA")
 	if appData == "" {
 		home, err := os.UserHomeDir()
@@ -35,10 +33,15 @@ func cacheDir() (string, error) {
 	return dir, os.MkdirAll(dir, 0o755)
 }
 
-// cachePath generates a cache file path keyed by the scan root.
-func cachePath(rootPath string) string {
-	dir, err := cacheDir()
-	if err != nil {
+// cachePath generates a cache file path under dir keyed by the scan root.
+func cachePath(dir, rootPath string) string {
+	if dir == "" {
+		d, err := defaultCacheDir()
+		if err != nil {
+			return ""
+		}
+		dir = d
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
 		return ""
 	}
 	// Sanitize path into a safe filename component.
@@ -49,10 +52,10 @@ func cachePath(rootPath string) string {
 	return filepath.Join(dir, safe+"_"+cacheFileName)
 }
 
-// SaveCache persists scan results to disk. Non-sensitive: only paths, sizes,
+// SaveCache persists scan results under dir. Non-sensitive: only paths, sizes,
 // and timestamps are stored.
-func SaveCache(root *DirEntry, rootPath string) error {
-	path := cachePath(rootPath)
+func SaveCache(dir string, root *DirEntry, rootPath string) error {
+	path := cachePath(dir, rootPath)
 	if path == "" {
 		return nil
 	}
@@ -78,32 +81,33 @@ func SaveCache(root *DirEntry, rootPath string) error {
 	return os.WriteFile(path, data, 0o644)
 }
 
-// LoadCache loads cached scan results if they exist and haven't expired.
+// LoadCache loads cached scan results from dir if they exist and are younger
+// than ttl. It also returns when the cached scan was taken.
 // Returns os.ErrNotExist if no valid cache is found.
-func LoadCache(rootPath string) (*DirEntry, error) {
-	path := cachePath(rootPath)
+func LoadCache(dir, rootPath string, ttl time.Duration) (*DirEntry, time.Time, error) {
+	path := cachePath(dir, rootPath)
 	if path == "" {
-		return nil, os.ErrNotExist
+		return nil, time.Time{}, os.ErrNotExist
 	}
 
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return nil, time.Time{}, err
 	}
 
 	var entry cacheEntry
 	if err := json.Unmarshal(data, &entry); err != nil {
-		return nil, err
+		return nil, time.Time{}, err
 	}
 
-	// Validate: root path must match.
-	if entry.RootPath != rootPath {
-		return nil, os.ErrNotExist
+	// Validate: root path must match and the tree must be present.
+	if !strings.EqualFold(entry.RootPath, rootPath) || entry.Root == nil {
+		return nil, time.Time{}, os.ErrNotExist
 	}
 
 	// Validate: cache must not be expired.
-	if time.Since(entry.Timestamp) > cacheTTL {
-		return nil, os.ErrNotExist
+	if time.Since(entry.Timestamp) > ttl {
+		return nil, time.Time{}, os.ErrNotExist
 	}
 
 	// Validate: root directory mtime must not have changed.
@@ -111,13 +115,13 @@ func LoadCache(rootPath string) (*DirEntry, error) {
 	// Deep tree modifications within the TTL window won't invalidate.
 	info, err := os.Stat(rootPath)
 	if err != nil || !info.ModTime().Equal(entry.RootMtime) {
-		return nil, os.ErrNotExist
+		return nil, time.Time{}, os.ErrNotExist
 	}
 
 	// Rebuild parent pointers (not serialized to avoid circular refs).
 	rebuildParents(entry.Root, nil)
 
-	return entry.Root, nil
+	return entry.Root, entry.Timestamp, nil
 }
 
 // rebuildParents restores Parent pointers after deserialization.
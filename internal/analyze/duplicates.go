@@ -0,0 +1,306 @@
+package analyze
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+)
+
+// ─── Duplicate Files ─────────────────────────────────────────────────────────
+
+// minDuplicateSize skips small files: they free little space and hashing
+// thousands of them dominates the run.
+const minDuplicateSize = 1 << 20
+
+// hashChunk is how much of a file is hashed between cancellation checks.
+const hashChunk = 1 << 20
+
+// DuplicateSet is a group of files with identical content.
+type DuplicateSet struct {
+	Size  int64       // size of one copy
+	Files []*DirEntry // oldest first
+	Keep  int         // index into Files of the copy to keep; 0 = oldest
+}
+
+// Reclaimable is the space freed by deleting every copy but one.
+func (d DuplicateSet) Reclaimable() int64 {
+	return d.Size * int64(len(d.Files)-1)
+}
+
+// Extras returns the copies other than the one being kept.
+func (d DuplicateSet) Extras() []*DirEntry {
+	extras := make([]*DirEntry, 0, len(d.Files)-1)
+	for i, f := range d.Files {
+		if i != d.Keep {
+			extras = append(extras, f)
+		}
+	}
+	return extras
+}
+
+// DuplicateProgress reports how far hashing has got. Only files that share
+// their size with another file are hashed.
+type DuplicateProgress struct {
+	FilesDone  int64
+	FilesTotal int64
+	BytesDone  int64
+	BytesTotal int64
+}
+
+// duplicateWorkers is how many files are hashed at once.
+const duplicateWorkers = 4
+
+// FindDuplicates finds files under root with identical content. Sets are
+// returned largest reclaimable space first. Links, files under
+// minDuplicateSize, and protected system entries (unless withSystem is
+// set) are skipped, as are files that cannot be read. progress, if
+// non-nil, is called after each file from the hashing goroutines, so calls
+// run concurrently and may arrive out of order: it must be safe for
+// concurrent use. When ctx is canceled, hashing stops and ctx.Err() is
+// returned.
+func FindDuplicates(ctx context.Context, root *DirEntry, withSystem bool,
+	progress func(DuplicateProgress)) ([]DuplicateSet, error) {
+	return hashDuplicates(ctx, duplicateCandidates(root, withSystem), progress)
+}
+
+// duplicateCandidates returns the files under root whose size is shared by
+// at least one other file. It only reads the scan tree, so the TUI calls it
+// on the Update goroutine before hashing starts in the background.
+func duplicateCandidates(root *DirEntry, withSystem bool) []*DirEntry {
+	bySize := make(map[int64][]*DirEntry)
+	var walk func(e *DirEntry)
+	walk = func(e *DirEntry) {
+		if e.IsReparse || (e.IsSystem && !withSystem) {
+			return
+		}
+		if e.IsDir {
+			for _, child := range e.Children {
+				walk(child)
+			}
+			return
+		}
+		if e.Size >= minDuplicateSize {
+			bySize[e.Size] = append(bySize[e.Size], e)
+		}
+	}
+	if root != nil {
+		walk(root)
+	}
+
+	var candidates []*DirEntry
+	for _, files := range bySize {
+		if len(files) >= 2 {
+			candidates = append(candidates, files...)
+		}
+	}
+	return candidates
+}
+
+// fileID identifies a file on disk. Every hard link to a file shares the
+// same volume serial number and file index.
+type fileID struct {
+	volume uint32
+	index  uint64
+}
+
+// fileIdentity returns the fileID of the file at path. It is a variable so
+// tests can stand in hard links without creating them.
+var fileIdentity = func(path string) (fileID, error) {
+	p, err := windows.UTF16PtrFromString(core.LongPath(path))
+	if err != nil {
+		return fileID{}, err
+	}
+	h, err := windows.CreateFile(p, 0,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, windows.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return fileID{}, err
+	}
+	defer windows.CloseHandle(h)
+
+	var info windows.ByHandleFileInformation
+	if err := windows.GetFileInformationByHandle(h, &info); err != nil {
+		return fileID{}, err
+	}
+	return fileID{
+		volume: info.VolumeSerialNumber,
+		index:  uint64(info.FileIndexHigh)<<32 | uint64(info.FileIndexLow),
+	}, nil
+}
+
+// distinctFiles keeps one path per file among candidates, so hard links to
+// the same data are neither hashed twice nor reported as duplicates of each
+// other, then drops files left without another file of the same size.
+// Files whose identity cannot be read are kept and left to hashing.
+func distinctFiles(ctx context.Context, candidates []*DirEntry) []*DirEntry {
+	sorted := append([]*DirEntry(nil), candidates...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+
+	seen := make(map[fileID]bool)
+	bySize := make(map[int64]int)
+	var files []*DirEntry
+	for _, f := range sorted {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if id, err := fileIdentity(f.Path); err == nil {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+		}
+		files = append(files, f)
+		bySize[f.Size]++
+	}
+
+	out := files[:0]
+	for _, f := range files {
+		if bySize[f.Size] >= 2 {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// hashDuplicates hashes candidates with a pool of duplicateWorkers and
+// groups those with equal size and SHA-256 into sets. Hard links are
+// counted once.
+func hashDuplicates(ctx context.Context, candidates []*DirEntry,
+	progress func(DuplicateProgress)) ([]DuplicateSet, error) {
+	candidates = distinctFiles(ctx, candidates)
+	total := DuplicateProgress{FilesTotal: int64(len(candidates))}
+	for _, f := range candidates {
+		total.BytesTotal += f.Size
+	}
+
+	type hashKey struct {
+		size int64
+		sum  [sha256.Size]byte
+	}
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		done   = total
+		byHash = make(map[hashKey][]*DirEntry)
+		work   = make(chan *DirEntry)
+	)
+	for i := 0; i < duplicateWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range work {
+				sum, err := hashFile(ctx, f.Path)
+
+				mu.Lock()
+				if err == nil {
+					key := hashKey{size: f.Size, sum: sum}
+					byHash[key] = append(byHash[key], f)
+				}
+				done.FilesDone++
+				done.BytesDone += f.Size
+				snapshot := done
+				mu.Unlock()
+
+				// Outside the lock so a slow callback doesn't stall the
+				// other workers.
+				if progress != nil {
+					progress(snapshot)
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, f := range candidates {
+		select {
+		case work <- f:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var sets []DuplicateSet
+	for key, files := range byHash {
+		if len(files) < 2 {
+			continue
+		}
+		sort.Slice(files, func(i, j int) bool {
+			if !files[i].ModTime.Equal(files[j].ModTime) {
+				return files[i].ModTime.Before(files[j].ModTime)
+			}
+			return files[i].Path < files[j].Path
+		})
+		sets = append(sets, DuplicateSet{Size: key.size, Files: files})
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].Reclaimable() != sets[j].Reclaimable() {
+			return sets[i].Reclaimable() > sets[j].Reclaimable()
+		}
+		return sets[i].Files[0].Path < sets[j].Files[0].Path
+	})
+	return sets, nil
+}
+
+// hashFile returns the SHA-256 of the file at path, checking ctx between
+// chunks so a cancel does not wait for a large file to finish.
+func hashFile(ctx context.Context, path string) ([sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+
+	f, err := os.Open(core.LongPath(path))
+	if err != nil {
+		return sum, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	for {
+		if err := ctx.Err(); err != nil {
+			return sum, err
+		}
+		if _, err := io.CopyN(h, f, hashChunk); err == io.EOF {
+			break
+		} else if err != nil {
+			return sum, err
+		}
+	}
+	copy(sum[:], h.Sum(nil))
+	return sum, nil
+}
+
+// removeDuplicate drops entry from every set, discarding sets left with a
+// single file, after the entry was deleted.
+func removeDuplicate(sets []DuplicateSet, entry *DirEntry) []DuplicateSet {
+	out := sets[:0]
+	for _, set := range sets {
+		for i, f := range set.Files {
+			if f != entry {
+				continue
+			}
+			set.Files = append(set.Files[:i], set.Files[i+1:]...)
+			switch {
+			case set.Keep > i:
+				set.Keep--
+			case set.Keep >= len(set.Files):
+				set.Keep = 0
+			}
+			break
+		}
+		if len(set.Files) >= 2 {
+			out = append(out, set)
+		}
+	}
+	return out
+}
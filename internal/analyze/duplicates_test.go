@@ -0,0 +1,153 @@
+package analyze
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	same := strings.Repeat("a", minDuplicateSize)
+	files := map[string]string{
+		"one.bin":                        same,
+		filepath.Join("sub", "two.bin"):  same,
+		"other.bin":                      strings.Repeat("b", minDuplicateSize),
+		"bigger.bin":                     same + "!",
+		filepath.Join("sub", "tiny.txt"): "x",
+		"tiny.txt":                       "x",
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chtimes(filepath.Join(dir, "sub", "two.bin"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := NewScanner(2, nil).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// progress runs on the hashing goroutines and may arrive out of order.
+	var (
+		mu   sync.Mutex
+		most DuplicateProgress
+	)
+	sets, err := FindDuplicates(context.Background(), root, false, func(p DuplicateProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+		if p.FilesDone > most.FilesDone {
+			most = p
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 1 || len(sets[0].Files) != 2 {
+		t.Fatalf("got %+v, want one set of two files", sets)
+	}
+	if sets[0].Files[0].Name != "two.bin" {
+		t.Errorf("oldest copy = %s, want two.bin first", sets[0].Files[0].Name)
+	}
+	if got := sets[0].Reclaimable(); got != minDuplicateSize {
+		t.Errorf("Reclaimable() = %d, want %d", got, minDuplicateSize)
+	}
+	// Tiny files are below the threshold; bigger.bin has a unique size.
+	if most.FilesDone != 3 || most.FilesTotal != 3 {
+		t.Errorf("progress = %d/%d files, want 3/3", most.FilesDone, most.FilesTotal)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := FindDuplicates(ctx, root, false, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled search err = %v, want context.Canceled", err)
+	}
+}
+
+func TestRemoveDuplicate(t *testing.T) {
+	a, b, c := &DirEntry{Name: "a"}, &DirEntry{Name: "b"}, &DirEntry{Name: "c"}
+	sets := []DuplicateSet{
+		{Size: 10, Files: []*DirEntry{a, b, c}, Keep: 2},
+		{Size: 5, Files: []*DirEntry{b, &DirEntry{Name: "d"}}},
+	}
+
+	sets = removeDuplicate(sets, b)
+	if len(sets) != 1 {
+		t.Fatalf("got %d sets, want the two-file set dropped", len(sets))
+	}
+	if sets[0].Keep != 1 || sets[0].Files[sets[0].Keep] != c {
+		t.Errorf("kept copy moved: Keep = %d", sets[0].Keep)
+	}
+	if extras := sets[0].Extras(); len(extras) != 1 || extras[0] != a {
+		t.Errorf("Extras() = %v, want [a]", extras)
+	}
+}
+
+func TestFindDuplicates_HardLinksCountOnce(t *testing.T) {
+	dir := t.TempDir()
+	same := strings.Repeat("a", minDuplicateSize)
+	for _, name := range []string{"link.bin", "one.bin", "copy.bin", "lonely.bin"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(same), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// link.bin and one.bin are two names for one file; copy.bin and
+	// lonely.bin share a file index but live on different volumes.
+	ids := map[string]fileID{
+		"link.bin":   {volume: 1, index: 10},
+		"one.bin":    {volume: 1, index: 10},
+		"copy.bin":   {volume: 1, index: 20},
+		"lonely.bin": {volume: 2, index: 20},
+	}
+	prev := fileIdentity
+	fileIdentity = func(path string) (fileID, error) { return ids[filepath.Base(path)], nil }
+	defer func() { fileIdentity = prev }()
+
+	root, err := NewScanner(2, nil).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total DuplicateProgress
+	var mu sync.Mutex
+	sets, err := FindDuplicates(context.Background(), root, false, func(p DuplicateProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+		total = p
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 1 || len(sets[0].Files) != 3 {
+		t.Fatalf("got %+v, want one set of three distinct files", sets)
+	}
+	for _, f := range sets[0].Files {
+		if f.Name == "one.bin" {
+			t.Error("one.bin reported alongside its hard link link.bin")
+		}
+	}
+	if total.FilesTotal != 3 {
+		t.Errorf("FilesTotal = %d, want each file hashed once", total.FilesTotal)
+	}
+
+	// Names for one file are not duplicates at all.
+	for name := range ids {
+		ids[name] = fileID{volume: 3, index: 30}
+	}
+	if sets, _ := FindDuplicates(context.Background(), root, false, nil); len(sets) != 0 {
+		t.Errorf("got %+v, want no sets when every copy is a hard link", sets)
+	}
+}
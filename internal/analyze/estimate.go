@@ -0,0 +1,77 @@
+package analyze
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+)
+
+// ─── Scan size estimate ──────────────────────────────────────────────────────
+
+// estimateDepthFactor scales the entries found per sampled directory up to
+// the unsampled levels below. Real trees vary widely, so the estimate is
+// only good enough for a rough progress bar.
+const estimateDepthFactor = 4
+
+// maxScanProgress is where ScanProgress stops when the scan outgrows its
+// estimate, so the bar never claims to be done early.
+const maxScanProgress = 0.99
+
+// EstimateEntries guesses how many entries a full Scan of rootPath will
+// count by reading only the top two directory levels, skipping the same
+// excluded names and reparse points. It returns 0 when rootPath cannot be
+// read or ctx is canceled.
+func (s *Scanner) EstimateEntries(ctx context.Context, rootPath string) int64 {
+	top, err := os.ReadDir(core.LongPath(rootPath))
+	if err != nil {
+		return 0
+	}
+
+	var level1, level2, sampled, deeper int64
+	for _, e := range top {
+		level1++
+		childPath := filepath.Join(rootPath, e.Name())
+		if !e.IsDir() || s.exclude[strings.ToLower(e.Name())] || isReparsePoint(childPath) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return 0
+		}
+		children, err := os.ReadDir(core.LongPath(childPath))
+		if err != nil {
+			continue
+		}
+		sampled++
+		for _, c := range children {
+			level2++
+			if c.IsDir() {
+				deeper++
+			}
+		}
+	}
+	return extrapolateEntries(level1, level2, sampled, deeper)
+}
+
+// extrapolateEntries assumes each of the deeper directories below the
+// sample holds as many entries as a sampled one did, scaled by
+// estimateDepthFactor for the levels under them.
+func extrapolateEntries(level1, level2, sampled, deeper int64) int64 {
+	est := level1 + level2
+	if sampled > 0 {
+		est += deeper * level2 / sampled * estimateDepthFactor
+	}
+	return est
+}
+
+// ScanProgress converts a running ScannedCount into a completion fraction
+// against an EstimateEntries result. It stays below 1 however far the
+// estimate was off, and is 0 when there is no estimate.
+func ScanProgress(scanned, estimate int64) float64 {
+	if estimate <= 0 {
+		return 0
+	}
+	return min(float64(scanned)/float64(estimate), maxScanProgress)
+}
@@ -0,0 +1,47 @@
+package analyze
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEstimateEntries(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{"a/x/1", "a/y", "b/z", "skip/w"} {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.FromSlash(p)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "top.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Level 1: a, b, skip, top.txt. Level 2 (skip excluded): x, y, z, all
+	// directories, so 3 more dirs of 1.5 entries each, times the depth factor.
+	got := NewScanner(1, []string{"skip"}).EstimateEntries(context.Background(), dir)
+	if want := extrapolateEntries(4, 3, 2, 3); got != want {
+		t.Errorf("EstimateEntries = %d, want %d", got, want)
+	}
+	if got := NewScanner(1, nil).EstimateEntries(context.Background(), filepath.Join(dir, "missing")); got != 0 {
+		t.Errorf("EstimateEntries of a missing root = %d, want 0", got)
+	}
+}
+
+func TestScanProgress(t *testing.T) {
+	for _, tc := range []struct {
+		scanned, estimate int64
+		want              float64
+	}{
+		{0, 100, 0},
+		{50, 100, 0.5},
+		{100, 100, maxScanProgress},
+		{5000, 100, maxScanProgress}, // estimate far too low
+		{10, 0, 0},                   // no estimate yet
+	} {
+		if got := ScanProgress(tc.scanned, tc.estimate); got != tc.want {
+			t.Errorf("ScanProgress(%d, %d) = %v, want %v", tc.scanned, tc.estimate, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package analyze
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/jsonutil"
+)
+
+// ─── JSON export / import ────────────────────────────────────────────────────
+
+// ExportJSON writes the scan tree rooted at root to path as indented JSON,
+// in a jsonutil envelope. Parent pointers are tagged json:"-" so the tree
+// serializes without cycles.
+func ExportJSON(root *DirEntry, path string) error {
+	if root == nil {
+		return fmt.Errorf("nothing to export")
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("cannot create export directory %s: %w", dir, err)
+		}
+	}
+
+	data, err := jsonutil.Marshal(root)
+	if err != nil {
+		return fmt.Errorf("cannot encode scan tree: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("cannot write export file %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteJSON encodes the scan tree rooted at root to w as indented JSON, in
+// a jsonutil envelope.
+func WriteJSON(w io.Writer, root *DirEntry) error {
+	if root == nil {
+		return fmt.Errorf("nothing to export")
+	}
+	if err := jsonutil.Write(w, root); err != nil {
+		return fmt.Errorf("cannot encode scan tree: %w", err)
+	}
+	return nil
+}
+
+// ImportJSON loads a scan tree previously written by ExportJSON and
+// re-links Parent pointers so the tree can be navigated in the TUI. Exports
+// from before the envelope was added still load.
+func ImportJSON(path string) (*DirEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read import file %s: %w", path, err)
+	}
+
+	var root DirEntry
+	if _, err := jsonutil.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("cannot parse import file %s: %w", path, err)
+	}
+	if root.Path == "" && len(root.Children) == 0 {
+		return nil, fmt.Errorf("import file %s does not contain a scan tree", path)
+	}
+
+	rebuildParents(&root, nil)
+	return &root, nil
+}
@@ -0,0 +1,78 @@
+package analyze
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ─── File Type Breakdown ─────────────────────────────────────────────────────
+
+// ExtNone groups files that have no extension.
+const ExtNone = "(none)"
+
+// ExtStat totals the files sharing one lowercased extension.
+type ExtStat struct {
+	Ext   string
+	Size  int64
+	Count int64
+	Files []*DirEntry // sorted by size descending
+}
+
+// FileTypeBreakdown groups every file under root by lowercased extension
+// and returns the groups sorted by total size descending. It works on the
+// existing scan tree and does no I/O. Links are skipped, and so are
+// protected system entries unless withSystem is set.
+func FileTypeBreakdown(root *DirEntry, withSystem bool) []ExtStat {
+	byExt := make(map[string]*ExtStat)
+
+	var walk func(e *DirEntry)
+	walk = func(e *DirEntry) {
+		if e.IsReparse || (e.IsSystem && !withSystem) {
+			return
+		}
+		if e.IsDir {
+			for _, child := range e.Children {
+				walk(child)
+			}
+			return
+		}
+		ext := fileExt(e.Name)
+		st, ok := byExt[ext]
+		if !ok {
+			st = &ExtStat{Ext: ext}
+			byExt[ext] = st
+		}
+		st.Size += e.Size
+		st.Count++
+		st.Files = append(st.Files, e)
+	}
+	if root != nil {
+		walk(root)
+	}
+
+	stats := make([]ExtStat, 0, len(byExt))
+	for _, st := range byExt {
+		sort.Slice(st.Files, func(i, j int) bool {
+			return st.Files[i].Size > st.Files[j].Size
+		})
+		stats = append(stats, *st)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Size != stats[j].Size {
+			return stats[i].Size > stats[j].Size
+		}
+		return stats[i].Ext < stats[j].Ext
+	})
+	return stats
+}
+
+// fileExt returns name's lowercased extension, or ExtNone. Dotfiles such
+// as ".gitignore" have no extension.
+func fileExt(name string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == "" || len(ext) == len(name) {
+		return ExtNone
+	}
+	return ext
+}
@@ -0,0 +1,59 @@
+package analyze
+
+import "github.com/lakshaymaurya-felt/purewin/internal/ui"
+
+// ─── Key Bindings ────────────────────────────────────────────────────────────
+// The footer hints and the ? overlay are both built from these.
+
+var (
+	keyNav      = ui.KeyBinding{Keys: "↑↓", Help: "nav"}
+	keyPage     = ui.KeyBinding{Keys: "PgUp/PgDn", Help: "page"}
+	keyTopEnd   = ui.KeyBinding{Keys: "g/G", Help: "top/end"}
+	keyDrill    = ui.KeyBinding{Keys: "→", Help: "drill"}
+	keyBack     = ui.KeyBinding{Keys: "←", Help: "back"}
+	keyCrumb    = ui.KeyBinding{Keys: "1-9", Help: "jump to crumb"}
+	keyBiggest  = ui.KeyBinding{Keys: "b", Help: "biggest"}
+	keySearch   = ui.KeyBinding{Keys: "/", Help: "search"}
+	keyTypes    = ui.KeyBinding{Keys: "t", Help: "types"}
+	keyDupes    = ui.KeyBinding{Keys: "d", Help: "duplicates"}
+	keyOpen     = ui.KeyBinding{Keys: "Enter", Help: "open"}
+	keyCopy     = ui.KeyBinding{Keys: "c", Help: "copy path"}
+	keySelect   = ui.KeyBinding{Keys: "space", Help: "select"}
+	keyDelete   = ui.KeyBinding{Keys: "⌫", Help: "delete"}
+	keyDelMode  = ui.KeyBinding{Keys: "R", Help: "del mode"}
+	keyLarge    = ui.KeyBinding{Keys: "L", Help: "large"}
+	keyUpRow    = ui.KeyBinding{Keys: ".", Help: "\"..\" row"}
+	keyCounts   = ui.KeyBinding{Keys: "#", Help: "file counts"}
+	keySystem   = ui.KeyBinding{Keys: "S", Help: "system files"}
+	keyRescan   = ui.KeyBinding{Keys: "r", Help: "rescan"}
+	keyElevate  = ui.KeyBinding{Keys: "A", Help: "rescan as admin"}
+	keyQuit     = ui.KeyBinding{Keys: "q", Help: "quit"}
+	keyClick    = ui.KeyBinding{Keys: "click", Help: "select, again to drill"}
+	keyWheel    = ui.KeyBinding{Keys: "wheel", Help: "scroll"}
+	keyConfirm  = ui.KeyBinding{Keys: "Enter", Help: "confirm delete"}
+	keyTypeNav  = ui.KeyBinding{Keys: "→", Help: "largest files"}
+	keyTypeBack = ui.KeyBinding{Keys: "←", Help: "types"}
+	keyDupeNav  = ui.KeyBinding{Keys: "→", Help: "copies"}
+	keyDupeBack = ui.KeyBinding{Keys: "←", Help: "sets"}
+	keyDupeKeep = ui.KeyBinding{Keys: "space", Help: "keep this copy"}
+	keyDupeDel  = ui.KeyBinding{Keys: "⌫", Help: "delete extra copies"}
+
+	keySearchNav    = ui.KeyBinding{Keys: "↑↓", Help: "navigate"}
+	keySearchSelect = ui.KeyBinding{Keys: "Enter", Help: "select"}
+	keySearchCopy   = ui.KeyBinding{Keys: "^Y", Help: "copy path"}
+	keySearchPaths  = ui.KeyBinding{Keys: "^P", Help: "name/path"}
+	keySearchRegex  = ui.KeyBinding{Keys: "^R", Help: "regex"}
+	keySearchCancel = ui.KeyBinding{Keys: "Esc", Help: "cancel"}
+)
+
+// helpGroups lists every analyzer binding for the help overlay.
+var helpGroups = []ui.KeyGroup{
+	{Title: "Navigate", Bindings: []ui.KeyBinding{keyNav, keyPage, keyTopEnd, keyDrill, keyBack, keyCrumb, keyBiggest}},
+	{Title: "Inspect", Bindings: []ui.KeyBinding{keyOpen, keyCopy, keySearch, keyTypes, keyDupes, keyLarge, keySystem, keyUpRow, keyCounts, keyRescan, keyElevate}},
+	{Title: "Delete", Bindings: []ui.KeyBinding{keySelect, keyDelete, keyConfirm, keyDelMode}},
+	{Title: "Search", Bindings: []ui.KeyBinding{keySearchNav, keySearchSelect, keySearchCopy, keySearchPaths, keySearchRegex, keySearchCancel}},
+	{Title: "File types", Bindings: []ui.KeyBinding{keyNav, keyTypeNav, keyOpen, keyTypeBack}},
+	{Title: "Duplicates", Bindings: []ui.KeyBinding{keyNav, keyDupeNav, keyDupeKeep, keyDupeDel, keyConfirm, keyDupeBack}},
+	{Title: "Mouse", Bindings: []ui.KeyBinding{keyClick, keyWheel}},
+	{Title: "General", Bindings: []ui.KeyBinding{ui.KeyHelp, keyQuit}},
+}
@@ -1,9 +1,12 @@
 package analyze
 
 import (
+	"context"
+	"errors"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"time"
 	"unicode/utf8"
 
@@ -22,15 +25,118 @@ const searchDebounce = 150 * time.Millisecond
 // ─── Messages ────────────────────────────────────────────────────────────────
 
 type deleteResultMsg struct {
-	path  string
+	entry *DirEntry
 	freed int64
 	err   error
 }
 
-func deleteEntry(entry *DirEntry) tea.Cmd {
+// deleteProgressMsg carries a permanent delete's progress; ch delivers the
+// next progress update or the final deleteResultMsg.
+type deleteProgressMsg struct {
+	progress core.DeleteProgress
+	ch       <-chan tea.Msg
+}
+
+// deleteEntry removes entry, via the Recycle Bin unless permanent is set.
+// Permanent deletes stream deleteProgressMsg updates before the result.
+func deleteEntry(entry *DirEntry, permanent bool) tea.Cmd {
+	if !permanent {
+		return func() tea.Msg {
+			freed, err := core.SafeDeleteToRecycleBin(entry.Path, false)
+			return deleteResultMsg{entry: entry, freed: freed, err: err}
+		}
+	}
+
+	ch := make(chan tea.Msg, 1)
+	go func() {
+		freed, err := core.SafeDeleteWithProgress(entry.Path, false, func(p core.DeleteProgress) {
+			// Drop updates the UI hasn't caught up with; the next one
+			// supersedes them anyway.
+			select {
+			case ch <- deleteProgressMsg{progress: p, ch: ch}:
+			default:
+			}
+		})
+		ch <- deleteResultMsg{entry: entry, freed: freed, err: err}
+	}()
+	return waitFor(ch)
+}
+
+// waitFor delivers the next message from a running delete or duplicate
+// search.
+func waitFor(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+type rescanResultMsg struct {
+	target    *DirEntry
+	fresh     *DirEntry
+	truncated string // why a limit stopped the rescan; "" = complete
+	err       error
+}
+
+// rescanEntry scans target's path with scanner into a detached tree so the
+// live tree is only mutated on the Update goroutine. The scan stops if ctx
+// is canceled.
+func rescanEntry(ctx context.Context, target *DirEntry, scanner *Scanner) tea.Cmd {
+	return func() tea.Msg {
+		fresh, err := scanner.Scan(ctx, target.Path)
+		return rescanResultMsg{target: target, fresh: fresh, truncated: scanner.Truncated(), err: err}
+	}
+}
+
+// dupeProgressMsg carries a duplicate search's progress; ch delivers the
+// next update or the final dupeResultMsg. gen is the search's dupesGen.
+type dupeProgressMsg struct {
+	gen      int
+	progress DuplicateProgress
+	ch       <-chan tea.Msg
+}
+
+type dupeResultMsg struct {
+	gen  int
+	sets []DuplicateSet
+	err  error
+}
+
+// findDuplicates hashes the duplicate candidates under root in the
+// background. Candidates are collected here, on the Update goroutine, so
+// the search never walks the tree while it is being changed.
+func findDuplicates(ctx context.Context, root *DirEntry, withSystem bool, gen int) tea.Cmd {
+	candidates := duplicateCandidates(root, withSystem)
+	ch := make(chan tea.Msg, 1)
+	go func() {
+		sets, err := hashDuplicates(ctx, candidates, func(p DuplicateProgress) {
+			select {
+			case ch <- dupeProgressMsg{gen: gen, progress: p, ch: ch}:
+			default:
+			}
+		})
+		ch <- dupeResultMsg{gen: gen, sets: sets, err: err}
+	}()
+	return waitFor(ch)
+}
+
+type copyResultMsg struct {
+	path string
+	err  error
+}
+
+// clearStatusMsg hides the footer status once its display time is over.
+// seq guards against clearing a newer status.
+type clearStatusMsg struct {
+	seq int
+}
+
+// statusDuration is how long transient footer messages stay visible.
+const statusDuration = 2 * time.Second
+
+// copyPath copies path to the clipboard.
+func copyPath(path string) tea.Cmd {
 	return func() tea.Msg {
-		freed, err := core.SafeDelete(entry.Path, false)
-		return deleteResultMsg{path: entry.Path, freed: freed, err: err}
+		return copyResultMsg{path: path, err: core.CopyToClipboard(path)}
 	}
 }
 
@@ -38,29 +144,82 @@ func deleteEntry(entry *DirEntry) tea.Cmd {
 
 // AnalyzeModel is the bubbletea Model for the disk analyzer TUI.
 type AnalyzeModel struct {
-	root          *DirEntry
-	current       *DirEntry   // directory being displayed
-	cursor        int         // selected item index
-	breadcrumb    []*DirEntry // navigation history stack
-	width         int
-	height        int
-	offset        int  // viewport scroll offset
-	largeOnly     bool // filter: show only >100MB
-	confirmDelete bool // two-key delete: Backspace then Enter
-	quitting      bool
-	err           error
-	maxDepth      int   // 0 = unlimited
-	minSize       int64 // 0 = show all
+	root            *DirEntry
+	current         *DirEntry   // directory being displayed
+	cursor          int         // selected item index
+	breadcrumb      []*DirEntry // navigation history stack
+	width           int
+	height          int
+	offset          int  // viewport scroll offset
+	largeOnly       bool // filter: show only >100MB
+	confirmDelete   bool // two-key delete: Backspace then Enter
+	permanentDelete bool // false = move to Recycle Bin
+	quitting        bool
+	err             error
+	ctx             context.Context // canceled on quit, stopping rescans
+	cancel          context.CancelFunc
+	maxDepth        int   // 0 = unlimited
+	minSize         int64 // 0 = show all
+	exclude         []string
+	showReparse     bool     // rescans keep junctions/symlinks as leaves
+	followLinks     bool     // rescans follow junctions/symlinks
+	hideParentRow   bool     // no ".." row at the top of the list (. key)
+	showCounts      bool     // file counts on directory rows at any width (# key)
+	showSystem      bool     // list and count protected system entries (S key)
+	deniedCount     int64    // entries the scan could not read
+	elevateArgs     []string // args for an elevated rescan; nil = no offer
+	truncated       string   // why a limited scan stopped early; "" = complete
+	rescanning      bool     // subtree rescan in flight
+	deleting        bool     // delete in flight
+	deleteStart     time.Time
+	deleteProgress  core.DeleteProgress
+
+	// Rescan limits, matching the initial scan; zero means unlimited.
+	maxEntries int64
+	maxTime    time.Duration
+
+	// File type breakdown state
+	typesMode   bool      // showing the by-extension breakdown
+	types       []ExtStat // breakdown of the current directory
+	typesCursor int       // cursor within types
+	typesExt    *ExtStat  // drilled-into extension; nil = extension list
+	filesCursor int       // cursor within typesExt.Files
+
+	// Duplicate finder state
+	dupesMode      bool               // showing duplicate sets
+	dupesRunning   bool               // hashing in flight
+	dupesCancel    context.CancelFunc // stops the running search
+	dupesGen       int                // current search; older results are stale
+	dupesProgress  DuplicateProgress
+	dupes          []DuplicateSet
+	dupesCursor    int  // cursor within dupes
+	dupesOpen      bool // showing the files of dupes[dupesCursor]
+	dupeFileCursor int  // cursor within the open set's files
+	dupesConfirm   bool // two-key delete of the open set's extra copies
+
+	// Multi-select state
+	selected   map[string]*DirEntry // entries in the current directory, by path
+	batch      []*DirEntry          // selected entries still to delete
+	batchTotal int                  // size of the running batch
+	status     string               // transient footer message (e.g. "copied")
+	statusSeq  int
+
+	// showHelp covers the view with the key binding overlay.
+	showHelp bool
 
 	// Search state
 	searching     bool           // true when in search mode
 	searchQuery   string         // current search input
 	searchResults []SearchResult // cached search results
 	searchCursor  int            // cursor within search results
+	searchPaths   bool           // match full relative paths instead of names
+	searchRegex   bool           // interpret the query as a regular expression
+	searchErr     error          // regex compile error for the current query
 }
 
 // NewAnalyzeModel creates an AnalyzeModel rooted at the given scan result.
 func NewAnalyzeModel(root *DirEntry, maxDepth int, minSize int64) AnalyzeModel {
+	ctx, cancel := context.WithCancel(context.Background())
 	return AnalyzeModel{
 		root:     root,
 		current:  root,
@@ -68,9 +227,63 @@ func NewAnalyzeModel(root *DirEntry, maxDepth int, minSize int64) AnalyzeModel {
 		height:   24,
 		maxDepth: maxDepth,
 		minSize:  minSize,
+		ctx:      ctx,
+		cancel:   cancel,
 	}
 }
 
+// WithPermanentDelete sets the initial delete mode; the user can still
+// toggle it in the TUI.
+func (m AnalyzeModel) WithPermanentDelete(permanent bool) AnalyzeModel {
+	m.permanentDelete = permanent
+	return m
+}
+
+// WithExclude sets the directory names skipped when rescanning a subtree,
+// matching the exclusions used for the initial scan.
+func (m AnalyzeModel) WithExclude(exclude []string) AnalyzeModel {
+	m.exclude = exclude
+	return m
+}
+
+// WithReparsePoints makes rescans keep junctions and symlinks as leaves,
+// matching the initial scan.
+func (m AnalyzeModel) WithReparsePoints(show bool) AnalyzeModel {
+	m.showReparse = show
+	return m
+}
+
+// WithFollowJunctions makes rescans follow junctions and symlinks,
+// matching the initial scan.
+func (m AnalyzeModel) WithFollowJunctions(follow bool) AnalyzeModel {
+	m.followLinks = follow
+	return m
+}
+
+// WithElevatedRescan offers an "A" key that re-launches the analyzer as
+// administrator with args, because denied entries were left unscanned.
+func (m AnalyzeModel) WithElevatedRescan(denied int64, args []string) AnalyzeModel {
+	m.deniedCount = denied
+	m.elevateArgs = args
+	return m
+}
+
+// WithLimits applies the initial scan's --max-entries and --max-time limits
+// to rescans. Zero disables a limit.
+func (m AnalyzeModel) WithLimits(maxEntries int64, maxTime time.Duration) AnalyzeModel {
+	m.maxEntries = maxEntries
+	m.maxTime = maxTime
+	return m
+}
+
+// WithTruncated flags the tree as partial because a scan limit stopped it;
+// note, such as "scan truncated at 5,000,000 entries", is shown in the
+// footer.
+func (m AnalyzeModel) WithTruncated(note string) AnalyzeModel {
+	m.truncated = note
+	return m
+}
+
 func (m AnalyzeModel) Init() tea.Cmd {
 	return nil
 }
@@ -83,7 +296,34 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case tea.MouseMsg:
+		if m.showHelp {
+			return m, nil
+		}
+		return m.updateMouse(msg)
+
 	case tea.KeyMsg:
+		// The help overlay swallows keys until it is dismissed.
+		if m.showHelp {
+			switch msg.String() {
+			case "?", "esc":
+				m.showHelp = false
+			case "q", "ctrl+c":
+				return m.quit()
+			}
+			return m, nil
+		}
+		if msg.String() == "?" && !m.searching && !m.confirmDelete && !m.dupesConfirm {
+			m.showHelp = true
+			return m, nil
+		}
+		if m.typesMode {
+			return m.updateTypes(msg)
+		}
+		if m.dupesMode {
+			return m.updateDupes(msg)
+		}
+
 		// Search mode input handling.
 		if m.searching {
 			switch msg.Type {
@@ -91,6 +331,7 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searching = false
 				m.searchQuery = ""
 				m.searchResults = nil
+				m.searchErr = nil
 				m.searchCursor = 0
 				return m, nil
 			case tea.KeyEnter:
@@ -99,6 +340,7 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.searching = false
 					m.searchQuery = ""
 					m.searchResults = nil
+					m.searchErr = nil
 					m.searchCursor = 0
 				}
 				return m, nil
@@ -112,6 +354,23 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.searchCursor++
 				}
 				return m, nil
+			case tea.KeyCtrlP:
+				m.searchPaths = !m.searchPaths
+				m.searchCursor = 0
+				return m, func() tea.Msg {
+					return searchTickMsg{query: m.searchQuery}
+				}
+			case tea.KeyCtrlY:
+				if m.searchCursor >= 0 && m.searchCursor < len(m.searchResults) {
+					return m, copyPath(m.searchResults[m.searchCursor].Entry.Path)
+				}
+				return m, nil
+			case tea.KeyCtrlR:
+				m.searchRegex = !m.searchRegex
+				m.searchCursor = 0
+				return m, func() tea.Msg {
+					return searchTickMsg{query: m.searchQuery}
+				}
 			case tea.KeyBackspace:
 				if len(m.searchQuery) > 0 {
 					_, size := utf8.DecodeLastRuneInString(m.searchQuery)
@@ -136,9 +395,12 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.confirmDelete {
 			if msg.String() == "enter" {
 				m.confirmDelete = false
+				if len(m.selected) > 0 {
+					return m, m.startBatchDelete()
+				}
 				items := m.visibleItems()
 				if m.cursor >= 0 && m.cursor < len(items) {
-					return m, deleteEntry(items[m.cursor])
+					return m, m.startDelete(items[m.cursor])
 				}
 			}
 			m.confirmDelete = false
@@ -147,13 +409,21 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg.String() {
 		case "q", "ctrl+c":
-			m.quitting = true
-			return m, tea.Quit
+			return m.quit()
+
+		case "A":
+			// Re-launch elevated for a complete scan; this window closes.
+			if m.elevateArgs != nil {
+				if err := core.LaunchElevated(m.elevateArgs); err != nil {
+					m.err = err
+					return m, nil
+				}
+				return m.quit()
+			}
 
 		case "esc":
 			// In normal mode, esc also quits
-			m.quitting = true
-			return m, tea.Quit
+			return m.quit()
 
 		case "up", "k":
 			if m.cursor > 0 {
@@ -168,51 +438,167 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.ensureVisible()
 			}
 
+		case "g", "home":
+			m.cursor = 0
+			m.ensureVisible()
+
+		case "G", "end":
+			if n := len(m.visibleItems()); n > 0 {
+				m.cursor = n - 1
+				m.ensureVisible()
+			}
+
+		case "pgup":
+			m.cursor -= m.viewportHeight()
+			if m.cursor < 0 {
+				m.cursor = 0
+			}
+			m.ensureVisible()
+
+		case "pgdown":
+			if n := len(m.visibleItems()); n > 0 {
+				m.cursor += m.viewportHeight()
+				if m.cursor > n-1 {
+					m.cursor = n - 1
+				}
+				m.ensureVisible()
+			}
+
+		case "b":
+			// Jump to the biggest item in the current directory.
+			items := m.visibleItems()
+			biggest := -1
+			for i, item := range items {
+				if !item.up && (biggest < 0 || item.Size > items[biggest].Size) {
+					biggest = i
+				}
+			}
+			if biggest >= 0 {
+				m.cursor = biggest
+				m.ensureVisible()
+			}
+
 		case "right", "l":
 			// Drill into a directory.
 			items := m.visibleItems()
 			if m.cursor >= 0 && m.cursor < len(items) {
-				entry := items[m.cursor]
-				if entry.IsDir && len(entry.Children) > 0 {
-					m.breadcrumb = append(m.breadcrumb, m.current)
-					m.current = entry
-					m.cursor = 0
-					m.offset = 0
-				}
+				m.drillInto(items[m.cursor])
 			}
 
 		case "enter":
-			// Open file/folder location in Explorer.
+			// Open file/folder location in Explorer; on ".." go up instead.
 			items := m.visibleItems()
 			if m.cursor >= 0 && m.cursor < len(items) {
-				openInExplorer(items[m.cursor].Path)
+				if items[m.cursor].up {
+					m.goUp()
+				} else {
+					openInExplorer(items[m.cursor].Path)
+				}
 			}
 
-		case "left", "h":
-			// Go up to parent directory.
-			if len(m.breadcrumb) > 0 {
-				m.current = m.breadcrumb[len(m.breadcrumb)-1]
-				m.breadcrumb = m.breadcrumb[:len(m.breadcrumb)-1]
-				m.cursor = 0
-				m.offset = 0
+		case "c":
+			// Copy the selected path to the clipboard.
+			items := m.visibleItems()
+			if m.cursor >= 0 && m.cursor < len(items) {
+				return m, copyPath(items[m.cursor].Path)
 			}
 
+		case "left", "h":
+			m.goUp()
+
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			// Jump straight to that level of the breadcrumb trail.
+			m.jumpToCrumb(int(msg.String()[0] - '1'))
+
+		case ".":
+			// Show or hide the ".." row.
+			m.hideParentRow = !m.hideParentRow
+			m.cursor = 0
+			m.offset = 0
+
+		case "#":
+			// Show file counts on directory rows even on narrow terminals.
+			m.showCounts = !m.showCounts
+
 		case "backspace":
-			// First key of two-key delete confirmation.
+			// First key of two-key delete confirmation, for the selection
+			// if there is one. Links and protected system entries cannot
+			// be deleted from here.
 			items := m.visibleItems()
-			if m.cursor >= 0 && m.cursor < len(items) {
+			switch {
+			case m.deleting:
+			case len(m.selected) > 0:
+				m.confirmDelete = true
+			case m.cursor >= 0 && m.cursor < len(items) && items[m.cursor].Deletable():
 				m.confirmDelete = true
 			}
 
+		case " ":
+			// Toggle the entry under the cursor and move down.
+			items := m.visibleItems()
+			if m.cursor >= 0 && m.cursor < len(items) && items[m.cursor].Deletable() {
+				m.toggleSelected(items[m.cursor])
+				if m.cursor < len(items)-1 {
+					m.cursor++
+					m.ensureVisible()
+				}
+			}
+
+		case "r":
+			// Rescan just the current directory.
+			if !m.rescanning && m.current != nil && m.current.IsDir {
+				m.rescanning = true
+				m.err = nil
+				return m, rescanEntry(m.ctx, m.current, m.newScanner())
+			}
+
+		case "S":
+			// Show or hide protected system entries and retotal the tree.
+			m.showSystem = !m.showSystem
+			Recalculate(m.root, m.showSystem)
+			m.clearSelection()
+			m.cursor = 0
+			m.offset = 0
+
+		case "R":
+			// Toggle between Recycle Bin and permanent delete.
+			m.permanentDelete = !m.permanentDelete
+
 		case "L":
 			m.largeOnly = !m.largeOnly
 			m.cursor = 0
 			m.offset = 0
 
+		case "t":
+			// Break the current subtree down by file extension.
+			m.types = FileTypeBreakdown(m.current, m.showSystem)
+			m.typesMode = true
+			m.typesCursor = 0
+			m.typesExt = nil
+			return m, nil
+
+		case "d":
+			// Find duplicate files in the current subtree. Hashing is
+			// slow, so it only runs on request.
+			if !m.dupesRunning && !m.rescanning && !m.deleting {
+				ctx, cancel := context.WithCancel(m.ctx)
+				m.dupesMode = true
+				m.dupesRunning = true
+				m.dupesCancel = cancel
+				m.dupesGen++
+				m.dupesProgress = DuplicateProgress{}
+				m.dupes = nil
+				m.dupesCursor = 0
+				m.dupesOpen = false
+				m.err = nil
+				return m, findDuplicates(ctx, m.current, m.showSystem, m.dupesGen)
+			}
+
 		case "/":
 			m.searching = true
 			m.searchQuery = ""
 			m.searchResults = nil
+			m.searchErr = nil
 			m.searchCursor = 0
 			return m, nil
 		}
@@ -222,7 +608,8 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case searchTickMsg:
 		// Only execute search if query hasn't changed since the tick was scheduled (debounce).
 		if m.searching && msg.query == m.searchQuery {
-			m.searchResults = SearchTreeBounded(m.root, m.searchQuery, 50)
+			m.searchResults, m.searchErr = SearchTreeWithOptions(m.root, m.searchQuery, 50,
+				SearchOptions{ByPath: m.searchPaths, Regex: m.searchRegex})
 			// Clamp cursor — results may be shorter than the old list the user was navigating.
 			if m.searchCursor >= len(m.searchResults) {
 				m.searchCursor = 0
@@ -230,11 +617,73 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case copyResultMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.statusSeq++
+		m.status = "copied " + msg.path
+		seq := m.statusSeq
+		return m, tea.Tick(statusDuration, func(time.Time) tea.Msg {
+			return clearStatusMsg{seq: seq}
+		})
+
+	case clearStatusMsg:
+		if msg.seq == m.statusSeq {
+			m.status = ""
+		}
+		return m, nil
+
+	case deleteProgressMsg:
+		m.deleteProgress = msg.progress
+		return m, waitFor(msg.ch)
+
 	case deleteResultMsg:
+		m.deleting = false
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.removeEntry(msg.entry)
+			m.dropDuplicate(msg.entry)
+		}
+		// Continue a batch even past failures; the last error stays shown.
+		if len(m.batch) > 0 {
+			next := m.batch[0]
+			m.batch = m.batch[1:]
+			return m, m.startDelete(next)
+		}
+		m.batchTotal = 0
+		return m, nil
+
+	case dupeProgressMsg:
+		if msg.gen == m.dupesGen {
+			m.dupesProgress = msg.progress
+		}
+		return m, waitFor(msg.ch)
+
+	case dupeResultMsg:
+		// A search the user closed has already been cleaned up.
+		if msg.gen != m.dupesGen {
+			return m, nil
+		}
+		m.dupesRunning = false
+		m.dupesCancel = nil
+		if msg.err != nil && !errors.Is(msg.err, context.Canceled) {
+			m.err = msg.err
+		}
+		if m.dupesMode && msg.err == nil {
+			m.dupes = msg.sets
+		}
+		return m, nil
+
+	case rescanResultMsg:
+		m.rescanning = false
 		if msg.err != nil {
 			m.err = msg.err
 		} else {
-			m.removeEntry(msg.path)
+			m.applyRescan(msg.target, msg.fresh)
+			m.truncated = msg.truncated
 		}
 		return m, nil
 	}
@@ -242,6 +691,201 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// quit stops any scan still running and ends the program.
+func (m AnalyzeModel) quit() (tea.Model, tea.Cmd) {
+	m.quitting = true
+	if m.cancel != nil {
+		m.cancel()
+	}
+	return m, tea.Quit
+}
+
+// updateTypes handles keys in the file type breakdown: navigate the
+// extension list, drill into an extension's largest files, and back out.
+func (m AnalyzeModel) updateTypes(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	cursor, n := &m.typesCursor, len(m.types)
+	if m.typesExt != nil {
+		cursor, n = &m.filesCursor, len(m.typesExt.Files)
+	}
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m.quit()
+
+	case "up", "k":
+		if *cursor > 0 {
+			*cursor--
+		}
+
+	case "down", "j":
+		if *cursor < n-1 {
+			*cursor++
+		}
+
+	case "right", "l", "enter":
+		switch {
+		case m.typesExt == nil && m.typesCursor < len(m.types):
+			m.typesExt = &m.types[m.typesCursor]
+			m.filesCursor = 0
+		case m.typesExt != nil && m.filesCursor < len(m.typesExt.Files):
+			openInExplorer(m.typesExt.Files[m.filesCursor].Path)
+		}
+
+	case "left", "h", "esc", "t":
+		if m.typesExt != nil {
+			m.typesExt = nil
+		} else {
+			m.typesMode = false
+			m.types = nil
+		}
+	}
+	return m, nil
+}
+
+// updateDupes handles keys in the duplicate finder: browse the sets, open
+// one to pick the copy to keep, and delete the other copies.
+func (m AnalyzeModel) updateDupes(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Only Enter confirms deleting the extra copies; any other key cancels.
+	if m.dupesConfirm {
+		m.dupesConfirm = false
+		if msg.String() == "enter" && m.dupesCursor < len(m.dupes) {
+			return m, m.deleteExtras(m.dupes[m.dupesCursor])
+		}
+		return m, nil
+	}
+
+	cursor, n := &m.dupesCursor, len(m.dupes)
+	if m.dupesOpen {
+		cursor, n = &m.dupeFileCursor, len(m.dupes[m.dupesCursor].Files)
+	}
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m.quit()
+
+	case "up", "k":
+		if *cursor > 0 {
+			*cursor--
+		}
+
+	case "down", "j":
+		if *cursor < n-1 {
+			*cursor++
+		}
+
+	case "right", "l", "enter":
+		switch {
+		case !m.dupesOpen && m.dupesCursor < len(m.dupes):
+			m.dupesOpen = true
+			m.dupeFileCursor = 0
+		case m.dupesOpen:
+			openInExplorer(m.dupes[m.dupesCursor].Files[m.dupeFileCursor].Path)
+		}
+
+	case " ":
+		// Keep the copy under the cursor instead of the oldest.
+		if m.dupesOpen {
+			m.dupes[m.dupesCursor].Keep = m.dupeFileCursor
+		}
+
+	case "backspace":
+		if !m.deleting && m.dupesCursor < len(m.dupes) {
+			m.dupesConfirm = true
+		}
+
+	case "left", "h", "esc", "d":
+		if m.dupesOpen {
+			m.dupesOpen = false
+		} else {
+			m.closeDupes()
+		}
+	}
+	return m, nil
+}
+
+// closeDupes leaves the duplicate finder, stopping a search in progress.
+// Bumping dupesGen makes the stopped search's late messages stale, so a
+// new search can start at once.
+func (m *AnalyzeModel) closeDupes() {
+	if m.dupesCancel != nil {
+		m.dupesCancel()
+		m.dupesCancel = nil
+	}
+	m.dupesGen++
+	m.dupesRunning = false
+	m.dupesMode = false
+	m.dupesOpen = false
+	m.dupesConfirm = false
+	m.dupes = nil
+}
+
+// dropDuplicate removes a deleted entry from the duplicate sets. A set
+// that no longer has duplicates disappears, returning to the set list.
+func (m *AnalyzeModel) dropDuplicate(entry *DirEntry) {
+	if len(m.dupes) == 0 {
+		return
+	}
+	before := len(m.dupes)
+	m.dupes = removeDuplicate(m.dupes, entry)
+	if len(m.dupes) != before {
+		m.dupesOpen = false
+	}
+	if m.dupesCursor >= len(m.dupes) {
+		m.dupesCursor = max(len(m.dupes)-1, 0)
+	}
+	if m.dupesOpen && m.dupeFileCursor >= len(m.dupes[m.dupesCursor].Files) {
+		m.dupeFileCursor = len(m.dupes[m.dupesCursor].Files) - 1
+	}
+}
+
+// deleteExtras deletes every copy in set but the kept one, as a batch.
+func (m *AnalyzeModel) deleteExtras(set DuplicateSet) tea.Cmd {
+	extras := set.Extras()
+	m.batch = append(m.batch[:0], extras[1:]...)
+	m.batchTotal = len(extras)
+	return m.startDelete(extras[0])
+}
+
+// mouseScrollLines is how many rows one wheel notch scrolls.
+const mouseScrollLines = 3
+
+// updateMouse handles mouse input on the directory list: the wheel scrolls
+// the viewport, a click moves the cursor to a row, and clicking the
+// highlighted directory again drills into it. Clicking a breadcrumb jumps
+// back to that directory. Other modes are keyboard-only.
+func (m AnalyzeModel) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.searching || m.typesMode || m.dupesMode || m.confirmDelete {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scrollBy(-mouseScrollLines)
+	case tea.MouseButtonWheelDown:
+		m.scrollBy(mouseScrollLines)
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		if level, ok := m.crumbAt(msg.X, msg.Y); ok {
+			m.jumpToCrumb(level)
+			return m, nil
+		}
+		items := m.visibleItems()
+		row := msg.Y - m.bodyTop()
+		i := m.offset + row
+		if row < 0 || row >= m.viewportHeight() || i >= len(items) {
+			return m, nil
+		}
+		if i == m.cursor {
+			m.drillInto(items[i])
+		} else {
+			m.cursor = i
+		}
+	}
+	return m, nil
+}
+
 // View delegates to view.go renderView.
 func (m AnalyzeModel) View() string {
 	return m.renderView()
@@ -259,6 +903,69 @@ func (m *AnalyzeModel) ensureVisible() {
 	}
 }
 
+// scrollBy moves the viewport n rows, dragging the cursor along so it
+// stays on screen.
+func (m *AnalyzeModel) scrollBy(n int) {
+	vh := m.viewportHeight()
+	m.offset += n
+	if maxOffset := len(m.visibleItems()) - vh; m.offset > maxOffset {
+		m.offset = maxOffset
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+	if m.cursor < m.offset {
+		m.cursor = m.offset
+	}
+	if m.cursor >= m.offset+vh {
+		m.cursor = m.offset + vh - 1
+	}
+}
+
+// drillInto makes entry the current directory, if it is a directory with
+// children. Drilling into the ".." row goes up instead.
+func (m *AnalyzeModel) drillInto(entry *DirEntry) {
+	if entry.up {
+		m.goUp()
+		return
+	}
+	if !entry.IsDir || len(entry.Children) == 0 {
+		return
+	}
+	m.breadcrumb = append(m.breadcrumb, m.current)
+	m.current = entry
+	m.clearSelection()
+	m.cursor = 0
+	m.offset = 0
+}
+
+// goUp returns to the parent directory, if there is one.
+func (m *AnalyzeModel) goUp() {
+	m.jumpToCrumb(len(m.breadcrumb) - 1)
+}
+
+// jumpToCrumb returns to the directory at the given breadcrumb level, 0
+// being the scan root. Levels at or below the current directory are ignored.
+func (m *AnalyzeModel) jumpToCrumb(level int) {
+	if level < 0 || level >= len(m.breadcrumb) {
+		return
+	}
+	m.current = m.breadcrumb[level]
+	m.breadcrumb = m.breadcrumb[:level]
+	m.clearSelection()
+	m.cursor = 0
+	m.offset = 0
+}
+
+// newScanner returns a scanner configured like the one that built the tree.
+func (m AnalyzeModel) newScanner() *Scanner {
+	return NewScanner(8, m.exclude).
+		WithReparsePoints(m.showReparse).
+		WithFollowJunctions(m.followLinks).
+		WithSystemFiles(m.showSystem).
+		WithLimits(m.maxEntries, m.maxTime)
+}
+
 func (m *AnalyzeModel) viewportHeight() int {
 	h := m.height - 8 // header (4) + footer (3) + padding
 	if h < 1 {
@@ -268,7 +975,8 @@ func (m *AnalyzeModel) viewportHeight() int {
 }
 
 // visibleItems returns the children of the current directory, optionally
-// filtered to only entries ≥100 MiB.
+// filtered to only entries ≥100 MiB. Below the root, a ".." row for the
+// parent directory comes first unless it is hidden.
 func (m AnalyzeModel) visibleItems() []*DirEntry {
 	if m.current == nil {
 		return nil
@@ -281,7 +989,21 @@ func (m AnalyzeModel) visibleItems() []*DirEntry {
 	}
 
 	var out []*DirEntry
+	if n := len(m.breadcrumb); n > 0 && !m.hideParentRow {
+		parent := m.breadcrumb[n-1]
+		out = append(out, &DirEntry{
+			Name:  "..",
+			Path:  parent.Path,
+			Size:  parent.Size,
+			IsDir: true,
+			up:    true,
+		})
+	}
 	for _, c := range m.current.Children {
+		// Protected system entries are hidden until S shows them.
+		if c.IsSystem && !m.showSystem {
+			continue
+		}
 		// Filter by minimum size.
 		if m.minSize > 0 && c.Size < m.minSize {
 			continue
@@ -299,22 +1021,21 @@ func (m AnalyzeModel) visibleItems() []*DirEntry {
 	return out
 }
 
-// removeEntry deletes an entry from the current Children slice and
-// recalculates the parent size.
-func (m *AnalyzeModel) removeEntry(path string) {
-	if m.current == nil {
+// removeEntry deletes an entry from its parent's Children slice and
+// recalculates sizes and counts up the Parent chain.
+func (m *AnalyzeModel) removeEntry(entry *DirEntry) {
+	parent := entry.Parent
+	if parent == nil {
 		return
 	}
-	for i, c := range m.current.Children {
-		if c.Path == path {
-			m.current.Children = append(m.current.Children[:i], m.current.Children[i+1:]...)
-			// Recalculate current directory size.
-			var total int64
-			for _, child := range m.current.Children {
-				total += child.Size
+	for i, c := range parent.Children {
+		if c == entry {
+			parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
+			// Recalculate totals up to the root.
+			for p := parent; p != nil; p = p.Parent {
+				p.aggregate(m.showSystem)
 			}
-			m.current.Size = total
-			if m.cursor >= len(m.current.Children) && m.cursor > 0 {
+			if parent == m.current && m.cursor >= len(m.visibleItems()) && m.cursor > 0 {
 				m.cursor--
 			}
 			return
@@ -322,6 +1043,108 @@ func (m *AnalyzeModel) removeEntry(path string) {
 	}
 }
 
+// startDelete begins deleting entry and resets the progress display.
+func (m *AnalyzeModel) startDelete(entry *DirEntry) tea.Cmd {
+	m.deleting = true
+	m.deleteStart = time.Now()
+	m.deleteProgress = core.DeleteProgress{}
+	return deleteEntry(entry, m.permanentDelete)
+}
+
+// startBatchDelete queues every selected entry, clears the selection, and
+// deletes the first; deleteResultMsg starts each following one.
+func (m *AnalyzeModel) startBatchDelete() tea.Cmd {
+	m.batch = m.batch[:0]
+	for _, e := range m.selected {
+		m.batch = append(m.batch, e)
+	}
+	sort.Slice(m.batch, func(i, j int) bool { return m.batch[i].Size > m.batch[j].Size })
+	m.batchTotal = len(m.batch)
+	m.clearSelection()
+
+	first := m.batch[0]
+	m.batch = m.batch[1:]
+	return m.startDelete(first)
+}
+
+// toggleSelected adds entry to the selection, or removes it if present.
+func (m *AnalyzeModel) toggleSelected(entry *DirEntry) {
+	if m.selected == nil {
+		m.selected = make(map[string]*DirEntry)
+	}
+	if _, ok := m.selected[entry.Path]; ok {
+		delete(m.selected, entry.Path)
+	} else {
+		m.selected[entry.Path] = entry
+	}
+}
+
+// clearSelection empties the selection, e.g. when leaving the directory.
+func (m *AnalyzeModel) clearSelection() {
+	m.selected = nil
+}
+
+// selectedSize returns the combined size of the selected entries.
+func (m AnalyzeModel) selectedSize() int64 {
+	var total int64
+	for _, e := range m.selected {
+		total += e.Size
+	}
+	return total
+}
+
+// applyRescan swaps target's children for a freshly scanned tree, then
+// recomputes sizes up the Parent chain to the root. The cursor stays on the
+// same name when it still exists.
+func (m *AnalyzeModel) applyRescan(target, fresh *DirEntry) {
+	// If the user drilled below target while the scan ran, those nodes are
+	// about to be replaced — step back out to target first.
+	for i, crumb := range m.breadcrumb {
+		if crumb == target {
+			m.current = target
+			m.breadcrumb = m.breadcrumb[:i]
+			m.cursor = 0
+			m.offset = 0
+			break
+		}
+	}
+
+	var selected string
+	items := m.visibleItems()
+	if m.cursor >= 0 && m.cursor < len(items) {
+		selected = items[m.cursor].Name
+	}
+
+	// Rescanned entries are new objects, so selections would be stale.
+	m.clearSelection()
+	target.Children = fresh.Children
+	for _, child := range target.Children {
+		child.Parent = target
+	}
+	target.Size = fresh.Size
+	target.FileCount = fresh.FileCount
+	target.DirCount = fresh.DirCount
+	target.ModTime = fresh.ModTime
+	target.Scanned = true
+
+	for p := target.Parent; p != nil; p = p.Parent {
+		p.aggregate(m.showSystem)
+		sort.Slice(p.Children, func(i, j int) bool {
+			return p.Children[i].Size > p.Children[j].Size
+		})
+	}
+
+	items = m.visibleItems()
+	m.cursor = 0
+	for i, item := range items {
+		if item.Name == selected {
+			m.cursor = i
+			break
+		}
+	}
+	m.ensureVisible()
+}
+
 // currentDepth returns how many levels deep the current directory is from root.
 func (m AnalyzeModel) currentDepth() int {
 	return len(m.breadcrumb)
@@ -339,15 +1162,16 @@ func (m *AnalyzeModel) navigateToEntry(entry *DirEntry) {
 	}
 
 	m.breadcrumb = trail
+	m.clearSelection()
 	if entry.Parent != nil {
 		m.current = entry.Parent
 	} else {
 		m.current = m.root
 	}
 
-	// Find entry index in parent's children
+	// Find entry index among the listed rows
 	m.cursor = 0
-	for i, child := range m.current.Children {
+	for i, child := range m.visibleItems() {
 		if child == entry {
 			m.cursor = i
 			break
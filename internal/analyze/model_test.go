@@ -0,0 +1,209 @@
+package analyze
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ---------------------------------------------------------------------------
+// Mouse tests
+// ---------------------------------------------------------------------------
+
+func TestUpdateMouse_ClickAndScroll(t *testing.T) {
+	root := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true}
+	for i := 0; i < 10; i++ {
+		dir := &DirEntry{Name: string(rune('a' + i)), IsDir: true, Parent: root}
+		dir.Children = []*DirEntry{{Name: "f", Size: 1, Parent: dir}}
+		root.Children = append(root.Children, dir)
+	}
+	m := NewAnalyzeModel(root, 0, 0)
+	m.height = 12 // viewport of 4 rows
+
+	click := func(m AnalyzeModel, row int) AnalyzeModel {
+		next, _ := m.updateMouse(tea.MouseMsg{
+			Button: tea.MouseButtonLeft,
+			Action: tea.MouseActionPress,
+			Y:      m.bodyTop() + row,
+		})
+		return next.(AnalyzeModel)
+	}
+
+	m = click(m, 2)
+	if m.cursor != 2 || m.current != root {
+		t.Fatalf("first click: cursor %d, current %s; want 2, root", m.cursor, m.current.Name)
+	}
+	if m = click(m, 9); m.cursor != 2 {
+		t.Errorf("click below the list moved the cursor to %d", m.cursor)
+	}
+	if m = click(m, 2); m.current != root.Children[2] {
+		t.Errorf("second click on the cursor row should drill in, current = %s", m.current.Name)
+	}
+
+	m = NewAnalyzeModel(root, 0, 0)
+	m.height = 12
+	next, _ := m.updateMouse(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	m = next.(AnalyzeModel)
+	if m.offset != 3 || m.cursor != 3 {
+		t.Errorf("wheel down: offset %d, cursor %d; want 3, 3", m.offset, m.cursor)
+	}
+	for i := 0; i < 5; i++ {
+		next, _ = m.updateMouse(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+		m = next.(AnalyzeModel)
+	}
+	if m.offset != 6 {
+		t.Errorf("offset = %d, want it clamped to 6", m.offset)
+	}
+}
+
+func TestJumpToCrumb(t *testing.T) {
+	root := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true}
+	dirs := []*DirEntry{root}
+	for _, name := range []string{"a", "b", "c"} {
+		parent := dirs[len(dirs)-1]
+		child := &DirEntry{Name: name, Path: parent.Path + `\` + name, IsDir: true, Parent: parent}
+		child.Children = []*DirEntry{{Name: "f", Size: 1, Parent: child}}
+		parent.Children = append(parent.Children, child)
+		dirs = append(dirs, child)
+	}
+	drillAll := func() AnalyzeModel {
+		m := NewAnalyzeModel(root, 0, 0)
+		for _, d := range dirs[1:] {
+			m.drillInto(d)
+		}
+		return m
+	}
+
+	m := drillAll()
+	if m.current.Name != "c" || len(m.breadcrumb) != 3 {
+		t.Fatalf("drilled to %s with %d crumbs, want c with 3", m.current.Name, len(m.breadcrumb))
+	}
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	m = next.(AnalyzeModel)
+	if m.current.Name != "a" || len(m.breadcrumb) != 1 {
+		t.Errorf("2 jumped to %s with %d crumbs, want a with 1", m.current.Name, len(m.breadcrumb))
+	}
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("5")})
+	if m = next.(AnalyzeModel); m.current.Name != "a" {
+		t.Errorf("a digit past the trail moved to %s", m.current.Name)
+	}
+
+	// Clicking "1 root" in the header goes back to the root.
+	m = drillAll()
+	row := m.bodyTop() - 2
+	if _, ok := m.crumbAt(2, row); ok {
+		t.Error("click left of the trail hit a crumb")
+	}
+	next, _ = m.updateMouse(tea.MouseMsg{Button: tea.MouseButtonLeft, Action: tea.MouseActionPress, X: 4, Y: row})
+	if m = next.(AnalyzeModel); m.current != root {
+		t.Errorf("click on the first crumb left current at %s, want root", m.current.Name)
+	}
+}
+
+func TestParentRow_GoesUp(t *testing.T) {
+	root := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true, Size: 30}
+	sub := &DirEntry{Name: "sub", Path: `C:\root\sub`, IsDir: true, Size: 20, Parent: root}
+	sub.Children = []*DirEntry{{Name: "f", Path: `C:\root\sub\f`, Size: 20, Parent: sub}}
+	root.Children = []*DirEntry{sub, {Name: "g", Path: `C:\root\g`, Size: 10, Parent: root}}
+	m := NewAnalyzeModel(root, 0, 0)
+
+	key := func(m AnalyzeModel, k string) AnalyzeModel {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if k == "enter" {
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		next, _ := m.Update(msg)
+		return next.(AnalyzeModel)
+	}
+
+	if items := m.visibleItems(); items[0].up {
+		t.Fatal(`".." row listed at the scan root`)
+	}
+	m.drillInto(sub)
+	items := m.visibleItems()
+	if len(items) != 2 || !items[0].up || items[0].Size != root.Size {
+		t.Fatalf(`want ".." with the parent's %d bytes first, got %v`, root.Size, items)
+	}
+	if items[0].Deletable() {
+		t.Error(`".." row is deletable`)
+	}
+	if m = key(m, "b"); m.cursor != 1 {
+		t.Errorf("b jumped to row %d, want the biggest real entry", m.cursor)
+	}
+
+	m.cursor = 0
+	if m = key(m, "enter"); m.current != root {
+		t.Errorf(`enter on ".." left current at %s, want root`, m.current.Name)
+	}
+
+	m.drillInto(sub)
+	m = key(m, ".")
+	if items := m.visibleItems(); len(items) != 1 || items[0].up {
+		t.Errorf(`"." did not hide the ".." row: %v`, items)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Duplicate finder tests
+// ---------------------------------------------------------------------------
+
+func TestCloseDupes_DropsStaleResults(t *testing.T) {
+	root := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true}
+	m := NewAnalyzeModel(root, 0, 0)
+	update := func(m AnalyzeModel, msg tea.Msg) AnalyzeModel {
+		next, _ := m.Update(msg)
+		return next.(AnalyzeModel)
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if !m.dupesRunning {
+		t.Fatal("d did not start a search")
+	}
+	stale := m.dupesGen
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.dupesMode || m.dupesRunning {
+		t.Fatalf("esc left dupesMode %v, dupesRunning %v", m.dupesMode, m.dupesRunning)
+	}
+
+	// A new search can start at once; the old one's messages are ignored.
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if !m.dupesRunning || m.dupesGen == stale {
+		t.Fatal("second d did not start a fresh search")
+	}
+	m = update(m, dupeProgressMsg{gen: stale, progress: DuplicateProgress{FilesTotal: 9}})
+	sets := []DuplicateSet{{Size: 1, Files: []*DirEntry{{Name: "a"}, {Name: "b"}}}}
+	m = update(m, dupeResultMsg{gen: stale, sets: sets})
+	if !m.dupesRunning || m.dupes != nil || m.dupesProgress.FilesTotal != 0 {
+		t.Errorf("stale messages changed the running search: running %v, dupes %v, progress %+v",
+			m.dupesRunning, m.dupes, m.dupesProgress)
+	}
+
+	m = update(m, dupeResultMsg{gen: m.dupesGen, sets: sets})
+	if m.dupesRunning || len(m.dupes) != 1 {
+		t.Errorf("current result: running %v, %d sets; want done with 1", m.dupesRunning, len(m.dupes))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Rescan tests
+// ---------------------------------------------------------------------------
+
+func TestRescanResult_SetsTruncated(t *testing.T) {
+	root := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true}
+	m := NewAnalyzeModel(root, 0, 0)
+	rescan := func(note string) {
+		m.rescanning = true
+		fresh := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true}
+		next, _ := m.Update(rescanResultMsg{target: root, fresh: fresh, truncated: note})
+		m = next.(AnalyzeModel)
+	}
+
+	rescan("scan truncated at 4 entries")
+	if m.truncated != "scan truncated at 4 entries" {
+		t.Errorf("truncated = %q after a limited rescan", m.truncated)
+	}
+	rescan("")
+	if m.truncated != "" {
+		t.Errorf("truncated = %q after a complete rescan, want it cleared", m.truncated)
+	}
+}
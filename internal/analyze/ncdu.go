@@ -0,0 +1,142 @@
+package analyze
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ─── ncdu export ─────────────────────────────────────────────────────────────
+// ncdu's JSON dump is a nested array-of-arrays:
+//
+//	[1, 2, {"progname":..., "progver":..., "timestamp":...},
+//	  [{"name":"C:\\Users\\me", "asize":456, "dsize":456},
+//	    {"name":"file.txt", "asize":123, "dsize":123},
+//	    [{"name":"subdir", "asize":333, "dsize":333}, ...children...]]]
+//
+// A directory is an array whose first element describes the directory itself
+// and whose remaining elements are its children. Directories carry the
+// aggregated size of everything under them, as the analyzer shows it.
+
+const (
+	ncduMajorVersion = 1
+	ncduMinorVersion = 2
+)
+
+// ncduInfo is the per-entry object in an ncdu dump.
+type ncduInfo struct {
+	Name     string `json:"name"`
+	ASize    int64  `json:"asize,omitempty"`
+	DSize    int64  `json:"dsize,omitempty"`
+	MTime    int64  `json:"mtime,omitempty"`
+	Excluded string `json:"excluded,omitempty"`
+	NotReg   bool   `json:"notreg,omitempty"`
+}
+
+// ExportNcdu writes the scan tree in ncdu's JSON export format so it can be
+// browsed with `ncdu -f out.json`. skipped lists directories the scanner did
+// not descend into; they are emitted with ncdu's "excluded" flag so the
+// import shows them rather than silently omitting them.
+func ExportNcdu(root *DirEntry, skipped []SkippedEntry, progVersion, path string) error {
+	if root == nil {
+		return fmt.Errorf("nothing to export")
+	}
+
+	// Group skipped entries under their parent directory (case-insensitive).
+	skippedByParent := make(map[string][]SkippedEntry)
+	for _, sk := range skipped {
+		parent := strings.ToLower(filepath.Dir(sk.Path))
+		skippedByParent[parent] = append(skippedByParent[parent], sk)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("cannot create export file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+
+	header, _ := json.Marshal(map[string]interface{}{
+		"progname":  "purewin",
+		"progver":   progVersion,
+		"timestamp": time.Now().Unix(),
+	})
+	fmt.Fprintf(w, "[%d,%d,%s,\n", ncduMajorVersion, ncduMinorVersion, header)
+
+	if err := writeNcduEntry(w, root, true, skippedByParent); err != nil {
+		return fmt.Errorf("cannot write export file %s: %w", path, err)
+	}
+	w.WriteString("]\n")
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("cannot write export file %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// writeNcduEntry emits a file as an object and a directory as an array.
+// The root directory uses its full path as its name, as ncdu expects.
+func writeNcduEntry(w *bufio.Writer, e *DirEntry, isRoot bool, skipped map[string][]SkippedEntry) error {
+	info := ncduInfo{Name: e.Name}
+	if isRoot {
+		info.Name = e.Path
+	}
+	if !e.ModTime.IsZero() {
+		info.MTime = e.ModTime.Unix()
+	}
+
+	info.ASize = e.Size
+	info.DSize = e.Size
+	if !e.IsDir {
+		return writeJSON(w, info)
+	}
+
+	w.WriteString("[")
+	if err := writeJSON(w, info); err != nil {
+		return err
+	}
+
+	for _, child := range e.Children {
+		// Links are emitted below from the skipped list.
+		if child.IsReparse {
+			continue
+		}
+		w.WriteString(",\n")
+		if err := writeNcduEntry(w, child, false, skipped); err != nil {
+			return err
+		}
+	}
+
+	for _, sk := range skipped[strings.ToLower(e.Path)] {
+		w.WriteString(",\n")
+		ex := ncduInfo{Name: filepath.Base(sk.Path)}
+		switch sk.Reason {
+		case SkipReparsePoint:
+			// Junctions point elsewhere — closest ncdu notion is "other filesystem".
+			ex.Excluded = "otherfs"
+			ex.NotReg = true
+		default:
+			ex.Excluded = "pattern"
+		}
+		if err := writeJSON(w, ex); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.WriteString("]")
+	return err
+}
+
+func writeJSON(w *bufio.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
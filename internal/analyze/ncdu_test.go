@@ -0,0 +1,62 @@
+package analyze
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportNcdu_Sizes(t *testing.T) {
+	root := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true, Size: 30}
+	sub := &DirEntry{Name: "sub", Path: `C:\root\sub`, IsDir: true, Size: 20, Parent: root}
+	sub.Children = []*DirEntry{{Name: "b.bin", Path: `C:\root\sub\b.bin`, Size: 20, Parent: sub}}
+	root.Children = []*DirEntry{{Name: "a.txt", Path: `C:\root\a.txt`, Size: 10, Parent: root}, sub}
+
+	path := filepath.Join(t.TempDir(), "out.json")
+	if err := ExportNcdu(root, nil, "test", path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dump []json.RawMessage
+	if err := json.Unmarshal(data, &dump); err != nil {
+		t.Fatalf("export is not valid JSON: %v\n%s", err, data)
+	}
+	if len(dump) != 4 {
+		t.Fatalf("dump has %d elements, want version, version, header, root", len(dump))
+	}
+
+	// Collect every entry's sizes, directories and files alike.
+	sizes := make(map[string][2]int64)
+	var walk func(raw json.RawMessage)
+	walk = func(raw json.RawMessage) {
+		var dir []json.RawMessage
+		if json.Unmarshal(raw, &dir) == nil {
+			for _, item := range dir {
+				walk(item)
+			}
+			return
+		}
+		var info ncduInfo
+		if err := json.Unmarshal(raw, &info); err != nil {
+			t.Fatalf("bad entry %s: %v", raw, err)
+		}
+		sizes[info.Name] = [2]int64{info.ASize, info.DSize}
+	}
+	walk(dump[3])
+
+	want := map[string][2]int64{
+		`C:\root`: {30, 30},
+		"a.txt":   {10, 10},
+		"sub":     {20, 20},
+		"b.bin":   {20, 20},
+	}
+	for name, w := range want {
+		if got, ok := sizes[name]; !ok || got != w {
+			t.Errorf("%s asize/dsize = %v, want %v", name, got, w)
+		}
+	}
+}
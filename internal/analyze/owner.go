@@ -0,0 +1,106 @@
+package analyze
+
+import (
+	"path"
+	"strings"
+)
+
+// ─── Folder Ownership Hints ──────────────────────────────────────────────────
+
+// ownerPathPatterns map trailing path segments (lowercase, "/"-separated,
+// glob syntax) to the app that creates them. They are checked before
+// ownerNames so ambiguous names like "Code" or "Edge" need their parent.
+var ownerPathPatterns = []struct {
+	pattern string
+	owner   string
+}{
+	{"google/chrome", "Google Chrome"},
+	{"microsoft/edge", "Microsoft Edge"},
+	{"mozilla/firefox", "Firefox"},
+	{"bravesoftware/brave-browser", "Brave"},
+	{"opera software/*", "Opera"},
+	{"microsoft/teams", "Microsoft Teams"},
+	{"microsoft/onedrive", "OneDrive"},
+	{"microsoft/windows/inetcache", "Windows (internet cache)"},
+	{"microsoft/windows/explorer", "Windows Explorer (thumbnails)"},
+	{"roaming/code", "VS Code"},
+	{"programs/microsoft vs code", "VS Code"},
+	{"packages/canonicalgroup*", "WSL (Ubuntu)"},
+	{"packages/thedebianproject*", "WSL (Debian)"},
+	{"packages/microsoftcorporationii.windowssubsystemforlinux*", "WSL"},
+	{"packages/microsoft.windowsterminal*", "Windows Terminal"},
+	{"local/docker", "Docker"},
+	{"programdata/docker*", "Docker"},
+	{"programdata/package cache", "Visual Studio installers"},
+	{"nvidia/dxcache", "NVIDIA shader cache"},
+	{"nvidia/glcache", "NVIDIA shader cache"},
+	{"d3dscache", "DirectX shader cache"},
+}
+
+// ownerNames map exact directory names (lowercase) to the app that creates
+// them, for names distinctive enough to need no parent context.
+var ownerNames = map[string]string{
+	"discord":              "Discord",
+	"slack":                "Slack",
+	"spotify":              "Spotify",
+	"zoom":                 "Zoom",
+	"steam":                "Steam",
+	"steamlibrary":         "Steam",
+	"epic games":           "Epic Games",
+	"epicgameslauncher":    "Epic Games",
+	"docker desktop":       "Docker",
+	"wsl":                  "WSL",
+	"jetbrains":            "JetBrains IDEs",
+	"npm-cache":            "npm",
+	"yarn":                 "Yarn",
+	"pnpm":                 "pnpm",
+	"go-build":             "Go",
+	".gradle":              "Gradle",
+	".m2":                  "Maven",
+	".cargo":               "Rust (Cargo)",
+	".rustup":              "Rust (rustup)",
+	".nuget":               "NuGet",
+	"nuget":                "NuGet",
+	".vscode":              "VS Code",
+	"pip":                  "Python (pip)",
+	"pypoetry":             "Python (Poetry)",
+	".conda":               "Conda",
+	"anaconda3":            "Conda",
+	"miniconda3":           "Conda",
+	"node_modules":         "Node.js",
+	"$recycle.bin":         "Recycle Bin",
+	"softwaredistribution": "Windows Update",
+	"windows.old":          "Previous Windows install",
+}
+
+// FolderOwner guesses which app created the directory at p, from its name
+// and parent folders. It returns "" when the folder is not recognized.
+// This is display-only and never affects scanning.
+func FolderOwner(p string) string {
+	norm := strings.ToLower(strings.ReplaceAll(p, `\`, "/"))
+	norm = strings.TrimSuffix(norm, "/")
+	if norm == "" {
+		return ""
+	}
+
+	for _, op := range ownerPathPatterns {
+		n := strings.Count(op.pattern, "/") + 1
+		if matched, err := path.Match(op.pattern, trailingSegments(norm, n)); err == nil && matched {
+			return op.owner
+		}
+	}
+
+	return ownerNames[path.Base(norm)]
+}
+
+// trailingSegments returns the last n "/"-separated segments of p.
+func trailingSegments(p string, n int) string {
+	idx := len(p)
+	for ; n > 0; n-- {
+		idx = strings.LastIndex(p[:idx], "/")
+		if idx < 0 {
+			return p
+		}
+	}
+	return p[idx+1:]
+}
@@ -2,14 +2,21 @@ package analyze
 
 import (
 	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
 )
 
 // DirEntry represents a file or directory in the scan tree.
@@ -22,11 +29,70 @@ type DirEntry struct {
 	Parent   *DirEntry   `json:"-"`
 	ModTime  time.Time   `json:"mod_time"`
 	Scanned  bool        `json:"scanned"`
+
+	// FileCount and DirCount are the files and subdirectories anywhere
+	// beneath a directory (zero for files).
+	FileCount int64 `json:"file_count,omitempty"`
+	DirCount  int64 `json:"dir_count,omitempty"`
+
+	// IsReparse marks a junction or symlink kept as a zero-size leaf (see
+	// Scanner.WithReparsePoints); Target is where it points.
+	IsReparse bool   `json:"is_reparse,omitempty"`
+	Target    string `json:"target,omitempty"`
+
+	// Followed marks a junction or symlink whose target was scanned as if
+	// it were a directory here (see Scanner.WithFollowJunctions); Target
+	// is the resolved path. Its contents count toward the totals.
+	Followed bool `json:"followed,omitempty"`
+
+	// IsSystem marks a protected OS entry with both the HIDDEN and SYSTEM
+	// attributes, such as pagefile.sys or System Volume Information. It is
+	// kept in the tree but left out of its parent's totals unless system
+	// files are counted (see Scanner.WithSystemFiles).
+	IsSystem bool `json:"is_system,omitempty"`
+
+	// up marks the synthetic ".." row the TUI lists first below the root;
+	// it stands for the parent directory and is never part of the tree.
+	up bool
+}
+
+// Deletable reports whether the analyzer may delete e. Links are shown for
+// information only, protected system entries belong to Windows, and the
+// ".." row is not a real entry. A followed link is not deletable either:
+// removing it would drop the link, not free its target's space.
+func (e *DirEntry) Deletable() bool {
+	return !e.IsReparse && !e.Followed && !e.IsSystem && !e.up
 }
 
-// IsOld returns true if the entry hasn't been modified in 6+ months.
+// OldThreshold is how long an entry must go unmodified before IsOld
+// reports it as old. Set from the analyze --old-after flag.
+var OldThreshold = 180 * 24 * time.Hour
+
+// IsOld returns true if the entry hasn't been modified within OldThreshold
+// (6 months by default).
 func (e *DirEntry) IsOld() bool {
-	return time.Since(e.ModTime) > 180*24*time.Hour
+	return e.IsOlderThan(OldThreshold)
+}
+
+// IsOlderThan returns true if the entry hasn't been modified within d.
+func (e *DirEntry) IsOlderThan(d time.Duration) bool {
+	return time.Since(e.ModTime) > d
+}
+
+// FormatAgeShort renders a duration in the largest whole unit that divides
+// it evenly, in the form accepted by core.ParseAge (e.g. "6mo", "1y", "90d").
+func FormatAgeShort(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	switch {
+	case days > 0 && days%365 == 0:
+		return fmt.Sprintf("%dy", days/365)
+	case days > 0 && days%30 == 0:
+		return fmt.Sprintf("%dmo", days/30)
+	case days > 0 && days%7 == 0:
+		return fmt.Sprintf("%dw", days/7)
+	default:
+		return fmt.Sprintf("%dd", days)
+	}
 }
 
 // Percentage returns the entry's size as a percentage of its parent's size.
@@ -37,16 +103,62 @@ func (e *DirEntry) Percentage(parentSize int64) float64 {
 	return float64(e.Size) / float64(parentSize) * 100
 }
 
+// SkipReason explains why a directory was left out of the scan tree.
+type SkipReason string
+
+const (
+	// SkipExcluded marks a directory matched by the --exclude list.
+	SkipExcluded SkipReason = "excluded"
+
+	// SkipReparsePoint marks a junction or symlink that was not followed.
+	SkipReparsePoint SkipReason = "reparse"
+
+	// SkipLinkCycle marks a junction or symlink that --follow-junctions
+	// left alone because its target is already part of the scan, loops
+	// back to an ancestor, or lies too many links deep.
+	SkipLinkCycle SkipReason = "link-cycle"
+)
+
+// SkippedEntry records a directory that exists on disk but was not scanned.
+type SkippedEntry struct {
+	Path   string
+	Reason SkipReason
+}
+
 // Scanner performs parallel recursive directory scanning.
 type Scanner struct {
-	sem          chan struct{}
+	workers      int
 	exclude      map[string]bool
 	mu           sync.Mutex
 	warnings     []string
+	skipped      []SkippedEntry
 	scannedCount atomic.Int64
+	showReparse  bool
+	countSystem  bool
+
+	// followLinks scans the targets of junctions and symlinks. visited
+	// holds the resolved, lower-cased roots scanned so far (the scan root
+	// and every followed target), guarded by mu.
+	followLinks bool
+	visited     []string
+
+	// Optional limits; zero means unlimited. stop ends the scan early with
+	// a cause, and truncated records why it ended.
+	maxEntries int64
+	maxTime    time.Duration
+	stop       context.CancelCauseFunc
+	truncated  string
+
+	deniedCount    atomic.Int64 // entries unreadable for lack of permission
+	deniedTopLevel atomic.Bool  // a direct child of the root was unreadable
 }
 
-// NewScanner creates a scanner with bounded concurrency.
+// ElevationDeniedThreshold is how many permission-denied entries make a
+// scan incomplete enough to suggest rescanning as administrator.
+const ElevationDeniedThreshold = 20
+
+// NewScanner creates a scanner that reads at most maxConcurrency
+// directories at once.
 // exclude is a list of directory names (case-insensitive) to skip.
 func NewScanner(maxConcurrency int, exclude []string) *Scanner {
 	if maxConcurrency <= 0 {
@@ -57,11 +169,62 @@ func NewScanner(maxConcurrency int, exclude []string) *Scanner {
 		excMap[strings.ToLower(e)] = true
 	}
 	return &Scanner{
-		sem:     make(chan struct{}, maxConcurrency),
+		workers: maxConcurrency,
 		exclude: excMap,
 	}
 }
 
+// WithReparsePoints makes the scanner keep junctions and symlinks in the
+// tree as zero-size leaves instead of dropping them. They are never followed.
+func (s *Scanner) WithReparsePoints(show bool) *Scanner {
+	s.showReparse = show
+	return s
+}
+
+// WithFollowJunctions makes the scanner descend into junctions and
+// symlinks to directories, counting their targets' contents. A link is
+// still left alone if its target overlaps the scan root or a target
+// already followed, so nothing is counted twice, or if it is more than
+// maxFollowDepth links deep.
+func (s *Scanner) WithFollowJunctions(follow bool) *Scanner {
+	s.followLinks = follow
+	return s
+}
+
+// maxFollowDepth caps how many followed links may be nested in one path.
+const maxFollowDepth = 8
+
+// WithSystemFiles makes protected system entries (see DirEntry.IsSystem)
+// count toward their parents' totals. They are flagged either way.
+func (s *Scanner) WithSystemFiles(count bool) *Scanner {
+	s.countSystem = count
+	return s
+}
+
+// WithLimits stops the scan gracefully after maxEntries entries or once
+// maxTime has passed, whichever comes first. Zero disables a limit. A
+// truncated scan still returns a consistent tree of what it read; see
+// Truncated.
+func (s *Scanner) WithLimits(maxEntries int64, maxTime time.Duration) *Scanner {
+	s.maxEntries = maxEntries
+	s.maxTime = maxTime
+	return s
+}
+
+// errEntryLimit and errTimeLimit are the causes a limited scan stops with.
+var (
+	errEntryLimit = errors.New("entry limit reached")
+	errTimeLimit  = errors.New("time limit reached")
+)
+
+// Truncated returns why the last scan stopped at a limit, such as "scan
+// truncated at 5,000,000 entries", or "" if it ran to completion.
+func (s *Scanner) Truncated() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.truncated
+}
+
 // Warnings returns any warnings accumulated during scanning.
 func (s *Scanner) Warnings() []string {
 	s.mu.Lock()
@@ -69,12 +232,49 @@ func (s *Scanner) Warnings() []string {
 	return append([]string(nil), s.warnings...)
 }
 
+// Skipped returns the directories that were deliberately not descended into.
+func (s *Scanner) Skipped() []SkippedEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SkippedEntry(nil), s.skipped...)
+}
+
 // ScannedCount returns the number of entries scanned so far.
 func (s *Scanner) ScannedCount() int64 {
 	return s.scannedCount.Load()
 }
 
+// DeniedCount returns how many entries could not be read for lack of
+// permission.
+func (s *Scanner) DeniedCount() int64 {
+	return s.deniedCount.Load()
+}
+
+// NeedsElevation reports whether permission errors left enough of the tree
+// unscanned that an elevated rescan would give a materially larger total:
+// more than ElevationDeniedThreshold denials, or any unreadable top-level
+// directory.
+func (s *Scanner) NeedsElevation() bool {
+	return s.deniedCount.Load() > ElevationDeniedThreshold || s.deniedTopLevel.Load()
+}
+
+// addDenied records a permission error reading entry (a directory) or one
+// of its children.
+func (s *Scanner) addDenied(dir *DirEntry, readingDir bool) {
+	s.deniedCount.Add(1)
+	if readingDir && dir.Parent != nil && dir.Parent.Parent == nil {
+		s.deniedTopLevel.Store(true)
+	}
+}
+
+func (s *Scanner) addSkipped(path string, reason SkipReason) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skipped = append(s.skipped, SkippedEntry{Path: path, Reason: reason})
+}
+
 func (s *Scanner) addWarning(msg string) {
+	core.LogWarn("scan: %s", msg)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if len(s.warnings) < 500 {
@@ -82,34 +282,54 @@ func (s *Scanner) addWarning(msg string) {
 	}
 }
 
-// isReparsePoint returns true if the path is a Windows junction or symlink
-// (FILE_ATTRIBUTE_REPARSE_POINT). Must be checked to avoid infinite recursion.
-func isReparsePoint(path string) bool {
+// File attribute bits (FILE_ATTRIBUTE_*).
+const (
+	fileAttributeHidden       = 0x0002
+	fileAttributeSystem       = 0x0004
+	fileAttributeReparsePoint = 0x0400
+)
+
+// fileAttributes returns path's Windows attribute bits, or false if they
+// cannot be read.
+func fileAttributes(path string) (uint32, bool) {
 	pathp, err := syscall.UTF16PtrFromString(path)
 	if err != nil {
-		return false
+		return 0, false
 	}
 	attrs, err := syscall.GetFileAttributes(pathp)
 	if err != nil {
-		return false
+		return 0, false
 	}
-	const fileAttributeReparsePoint = 0x0400
-	return attrs&fileAttributeReparsePoint != 0
+	return attrs, true
+}
+
+// isReparsePoint returns true if the path is a Windows junction or symlink
+// (FILE_ATTRIBUTE_REPARSE_POINT). Must be checked to avoid infinite recursion.
+func isReparsePoint(path string) bool {
+	attrs, ok := fileAttributes(path)
+	return ok && attrs&fileAttributeReparsePoint != 0
 }
 
-// longPath adds the \\?\ prefix for paths exceeding MAX_PATH on Windows.
-func longPath(path string) string {
-	if len(path) >= 260 && !strings.HasPrefix(path, `\\?\`) {
-		return `\\?\` + filepath.Clean(path)
+// isProtectedSystem returns true if the entry has both the HIDDEN and
+// SYSTEM attributes. The directory listing usually carries the attributes
+// already, which saves a GetFileAttributes call per file.
+func isProtectedSystem(path string, info fs.FileInfo) bool {
+	const mask = fileAttributeHidden | fileAttributeSystem
+	if data, ok := info.Sys().(*syscall.Win32FileAttributeData); ok {
+		return data.FileAttributes&mask == mask
 	}
-	return path
+	attrs, ok := fileAttributes(path)
+	return ok && attrs&mask == mask
 }
 
-// Scan performs a parallel recursive scan of the given root path.
-func (s *Scanner) Scan(rootPath string) (*DirEntry, error) {
+// Scan performs a parallel recursive scan of the given root path. If ctx
+// is canceled the scan stops promptly and returns the partial tree, totaled
+// from what was read so far, along with ctx.Err(). A scan stopped by
+// WithLimits returns its partial tree the same way but with a nil error.
+func (s *Scanner) Scan(ctx context.Context, rootPath string) (*DirEntry, error) {
 	rootPath = filepath.Clean(rootPath)
 
-	info, err := os.Lstat(longPath(rootPath))
+	info, err := os.Lstat(core.LongPath(rootPath))
 	if err != nil {
 		return nil, err
 	}
@@ -127,43 +347,208 @@ func (s *Scanner) Scan(rootPath string) (*DirEntry, error) {
 		return root, nil
 	}
 
-	s.scanDir(root)
+	s.mu.Lock()
+	s.visited = nil
+	s.mu.Unlock()
+	if s.followLinks {
+		// A root reached through a link is known by its real path.
+		if real, err := resolveLink(rootPath); err == nil {
+			s.claimTarget(real)
+		} else {
+			s.claimTarget(rootPath)
+		}
+	}
+
+	// The limits cancel scanCtx with a cause, which tells them apart from
+	// the caller canceling ctx.
+	scanCtx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+	s.stop = stop
+	if s.maxTime > 0 {
+		var cancel context.CancelFunc
+		scanCtx, cancel = context.WithTimeoutCause(scanCtx, s.maxTime, errTimeLimit)
+		defer cancel()
+	}
+
+	s.scanTree(scanCtx, root)
 	s.calculateSizes(root)
+	if err := ctx.Err(); err != nil {
+		return root, err
+	}
+	switch context.Cause(scanCtx) {
+	case errEntryLimit:
+		s.truncate(fmt.Sprintf("scan truncated at %s entries", formatCount(s.maxEntries)))
+		return root, nil
+	case errTimeLimit:
+		s.truncate(fmt.Sprintf("scan truncated after %s", s.maxTime))
+		return root, nil
+	}
 	root.Scanned = true
 
 	return root, nil
 }
 
-// scanDir recursively scans a directory, using the semaphore only during I/O
-// to prevent deadlocks from nested goroutine semaphore acquisition.
-func (s *Scanner) scanDir(entry *DirEntry) {
-	dirPath := longPath(entry.Path)
+// truncate records that a limit cut the scan short.
+func (s *Scanner) truncate(msg string) {
+	s.mu.Lock()
+	s.truncated = msg
+	s.mu.Unlock()
+	s.addWarning(msg)
+}
+
+// ─── Worker pool ─────────────────────────────────────────────────────────────
+
+// dirQueue is the work list shared by the scan workers. Workers add the
+// subdirectories they find, so the queue cannot be a bounded channel: a
+// worker blocked on a full channel would never drain it.
+type dirQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	dirs    []*DirEntry
+	pending int // directories queued or being read
+}
+
+func newDirQueue() *dirQueue {
+	q := &dirQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+func (q *dirQueue) push(dir *DirEntry) {
+	q.mu.Lock()
+	q.dirs = append(q.dirs, dir)
+	q.pending++
+	q.mu.Unlock()
+	q.cond.Signal()
+}
+
+// pop waits for a directory to read. It reports false once the queue is
+// empty and no worker is still reading, so nothing more can arrive.
+func (q *dirQueue) pop() (*DirEntry, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.dirs) == 0 && q.pending > 0 {
+		q.cond.Wait()
+	}
+	if len(q.dirs) == 0 {
+		return nil, false
+	}
+	// Last in, first out: going depth-first keeps the queue short.
+	dir := q.dirs[len(q.dirs)-1]
+	q.dirs = q.dirs[:len(q.dirs)-1]
+	return dir, true
+}
 
-	// Hold semaphore only during the ReadDir I/O.
-	s.sem <- struct{}{}
-	entries, err := os.ReadDir(dirPath)
-	<-s.sem
+// done marks a popped directory as read.
+func (q *dirQueue) done() {
+	q.mu.Lock()
+	q.pending--
+	last := q.pending == 0
+	q.mu.Unlock()
+	if last {
+		q.cond.Broadcast()
+	}
+}
 
+// scanTree reads every directory under root with a fixed pool of workers,
+// so a tree with tens of thousands of directories still runs only
+// s.workers goroutines.
+func (s *Scanner) scanTree(ctx context.Context, root *DirEntry) {
+	q := newDirQueue()
+	q.push(root)
+
+	var wg sync.WaitGroup
+	for range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				dir, ok := q.pop()
+				if !ok {
+					return
+				}
+				s.scanDir(ctx, dir, q)
+				if dir != root {
+					dir.Scanned = ctx.Err() == nil
+				}
+				q.done()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// scanDir reads one directory, adding its entries as children and queuing
+// its subdirectories. Once ctx is canceled it reads nothing and queues
+// nothing more, so the workers drain the queue and stop.
+func (s *Scanner) scanDir(ctx context.Context, entry *DirEntry, q *dirQueue) {
+	if ctx.Err() != nil {
+		return
+	}
+	entries, err := os.ReadDir(core.LongPath(entry.Path))
 	if err != nil {
 		s.addWarning("cannot read " + entry.Path + ": " + err.Error())
+		if errors.Is(err, fs.ErrPermission) {
+			s.addDenied(entry, true)
+		}
 		return
 	}
 
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
 	for _, e := range entries {
 		childPath := filepath.Join(entry.Path, e.Name())
-		s.scannedCount.Add(1)
+		if n := s.scannedCount.Add(1); s.maxEntries > 0 && n > s.maxEntries {
+			s.stop(errEntryLimit)
+			return
+		}
 
 		// Skip excluded directories.
 		if e.IsDir() && s.exclude[strings.ToLower(e.Name())] {
+			s.addSkipped(childPath, SkipExcluded)
 			continue
 		}
 
-		// NEVER follow junction points / reparse points — infinite recursion risk.
+		// Junction points / reparse points are only followed on request,
+		// and then only to targets not already scanned — infinite
+		// recursion risk.
 		if e.IsDir() && isReparsePoint(childPath) {
-			s.addWarning("skipping junction/reparse: " + childPath)
+			if target, ok := s.followTarget(entry, childPath); ok {
+				child := &DirEntry{
+					Path:     childPath,
+					Name:     e.Name(),
+					IsDir:    true,
+					Parent:   entry,
+					Target:   target,
+					Followed: true,
+				}
+				if info, err := e.Info(); err == nil {
+					child.ModTime = info.ModTime()
+				}
+				if ctx.Err() == nil {
+					q.push(child)
+				}
+				entry.Children = append(entry.Children, child)
+				continue
+			}
+			s.addSkipped(childPath, SkipReparsePoint)
+			if !s.showReparse {
+				s.addWarning("skipping junction/reparse: " + childPath)
+				continue
+			}
+			link := &DirEntry{
+				Path:      childPath,
+				Name:      e.Name(),
+				IsDir:     true,
+				Parent:    entry,
+				Scanned:   true,
+				IsReparse: true,
+			}
+			if target, err := os.Readlink(core.LongPath(childPath)); err == nil {
+				link.Target = target
+			}
+			if info, err := e.Info(); err == nil {
+				link.ModTime = info.ModTime()
+			}
+			entry.Children = append(entry.Children, link)
 			continue
 		}
 
@@ -171,50 +556,118 @@ func (s *Scanner) scanDir(entry *DirEntry) {
 		if err != nil {
 			// Permission denied or other error — skip, don't fail.
 			s.addWarning("cannot stat " + childPath + ": " + err.Error())
+			if errors.Is(err, fs.ErrPermission) {
+				s.addDenied(entry, false)
+			}
 			continue
 		}
 
 		child := &DirEntry{
-			Path:    childPath,
-			Name:    e.Name(),
-			IsDir:   e.IsDir(),
-			Parent:  entry,
-			ModTime: info.ModTime(),
+			Path:     childPath,
+			Name:     e.Name(),
+			IsDir:    e.IsDir(),
+			Parent:   entry,
+			ModTime:  info.ModTime(),
+			IsSystem: isProtectedSystem(childPath, info),
 		}
 
 		if !e.IsDir() {
 			child.Size = info.Size()
 			child.Scanned = true
-		} else {
-			wg.Add(1)
-			go func(dir *DirEntry) {
-				defer wg.Done()
-				s.scanDir(dir)
-				dir.Scanned = true
-			}(child)
+		} else if ctx.Err() == nil {
+			q.push(child)
 		}
-
-		mu.Lock()
 		entry.Children = append(entry.Children, child)
-		mu.Unlock()
 	}
+}
 
-	wg.Wait()
+// followTarget decides whether the link at path, found in dir, should be
+// followed, and claims its resolved target if so. A target that contains
+// or lies inside anything already scanned is refused, which breaks cycles
+// and keeps a directory reached two ways from counting twice.
+func (s *Scanner) followTarget(dir *DirEntry, path string) (string, bool) {
+	if !s.followLinks {
+		return "", false
+	}
+	depth := 1
+	for p := dir; p != nil; p = p.Parent {
+		if p.Followed {
+			depth++
+		}
+	}
+	if depth > maxFollowDepth {
+		s.addSkipped(path, SkipLinkCycle)
+		s.addWarning("not following " + path + ": too many nested links")
+		return "", false
+	}
+
+	target, err := resolveLink(path)
+	if err != nil {
+		s.addWarning("cannot resolve " + path + ": " + err.Error())
+		return "", false
+	}
+	if !s.claimTarget(target) {
+		s.addSkipped(path, SkipLinkCycle)
+		s.addWarning("not following " + path + ": " + target + " is already scanned")
+		return "", false
+	}
+	return target, true
+}
+
+// resolveLink returns the real path that path leads to, following every
+// link along the way.
+func resolveLink(path string) (string, error) {
+	target, err := filepath.EvalSymlinks(core.LongPath(path))
+	if err != nil {
+		return "", err
+	}
+	return core.StripLongPath(target), nil
+}
+
+// claimTarget records target as scanned unless it overlaps a path already
+// recorded, reporting whether it was recorded.
+func (s *Scanner) claimTarget(target string) bool {
+	key := strings.ToLower(filepath.Clean(target))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.visited {
+		if pathWithin(key, v) || pathWithin(v, key) {
+			return false
+		}
+	}
+	s.visited = append(s.visited, key)
+	return true
 }
 
-// calculateSizes walks the tree bottom-up, summing sizes from children,
-// then sorts each level by size descending.
+// pathWithin reports whether path is dir or lies beneath it. Both must be
+// cleaned and lower-cased.
+func pathWithin(path, dir string) bool {
+	if path == dir {
+		return true
+	}
+	if !strings.HasSuffix(dir, string(filepath.Separator)) {
+		dir += string(filepath.Separator)
+	}
+	return strings.HasPrefix(path, dir)
+}
+
+// calculateSizes totals the scanned tree; see Recalculate.
 func (s *Scanner) calculateSizes(entry *DirEntry) {
+	Recalculate(entry, s.countSystem)
+}
+
+// Recalculate walks the tree bottom-up, summing sizes and counts from
+// children, then sorts each level by size descending. withSystem decides
+// whether protected system entries count toward the totals.
+func Recalculate(entry *DirEntry, withSystem bool) {
 	if !entry.IsDir {
 		return
 	}
 
-	var total int64
 	for _, child := range entry.Children {
-		s.calculateSizes(child)
-		total += child.Size
+		Recalculate(child, withSystem)
 	}
-	entry.Size = total
+	entry.aggregate(withSystem)
 
 	// Sort children by size descending after all sizes are known.
 	sort.Slice(entry.Children, func(i, j int) bool {
@@ -222,16 +675,65 @@ func (s *Scanner) calculateSizes(entry *DirEntry) {
 	})
 }
 
+// aggregate recomputes a directory's Size, FileCount, and DirCount from its
+// direct children, whose own totals must already be correct. Protected
+// system children are skipped unless withSystem is set.
+func (e *DirEntry) aggregate(withSystem bool) {
+	var size, files, dirs int64
+	for _, child := range e.Children {
+		if child.IsSystem && !withSystem {
+			continue
+		}
+		size += child.Size
+		if child.IsReparse {
+			continue
+		}
+		if child.IsDir {
+			dirs += 1 + child.DirCount
+			files += child.FileCount
+		} else {
+			files++
+		}
+	}
+	e.Size, e.FileCount, e.DirCount = size, files, dirs
+}
+
 // ─── Fuzzy Search ────────────────────────────────────────────────────────────
 
+// Fuzzy match bonuses for literal matches. They dwarf the per-rune
+// subsequence bonuses so "report.pdf" always outranks "r_e_p_o_r_t".
+const (
+	fuzzyPrefixBonus    = 200 // str starts with pattern
+	fuzzySubstringBonus = 100 // pattern appears contiguously in str
+)
+
 // fuzzyMatch returns true if all characters in pattern appear in str in order (case-insensitive).
-// Also returns a score — higher is better (consecutive matches, start-of-word bonuses).
+// Also returns a score — higher is better (prefix and substring matches first,
+// then consecutive matches and start-of-word bonuses for scattered subsequences).
 // Operates on runes to correctly handle multi-byte UTF-8 characters.
 func fuzzyMatch(str, pattern string) (bool, int) {
 	// Case-insensitive
-	strRunes := []rune(strings.ToLower(str))
-	patRunes := []rune(strings.ToLower(pattern))
+	lowerStr := strings.ToLower(str)
+	lowerPat := strings.ToLower(pattern)
+
+	ok, score := fuzzySubsequence([]rune(lowerStr), []rune(lowerPat))
+	if !ok || lowerPat == "" {
+		return ok, score
+	}
 
+	switch {
+	case strings.HasPrefix(lowerStr, lowerPat):
+		score += fuzzyPrefixBonus
+	case strings.Contains(lowerStr, lowerPat):
+		score += fuzzySubstringBonus
+	}
+
+	return true, score
+}
+
+// fuzzySubsequence scores an in-order (possibly scattered) match of
+// patRunes within strRunes. Both must already be lower-cased.
+func fuzzySubsequence(strRunes, patRunes []rune) (bool, int) {
 	if len(patRunes) == 0 {
 		return true, 0
 	}
@@ -262,6 +764,46 @@ func fuzzyMatch(str, pattern string) (bool, int) {
 	return pIdx == len(patRunes), score
 }
 
+// fuzzyPathMatch matches pattern against relPath (an entry's path relative
+// to the search root). Matches near the end of the path score higher: a
+// match within the name itself earns the full fuzzyMatch score on top, and a
+// literal substring earns a bonus that grows the closer it ends to the name.
+func fuzzyPathMatch(relPath, name, pattern string) (bool, int) {
+	lowerPath := strings.ToLower(relPath)
+	lowerPat := strings.ToLower(pattern)
+
+	ok, score := fuzzySubsequence([]rune(lowerPath), []rune(lowerPat))
+	if !ok || lowerPat == "" {
+		return ok, score
+	}
+
+	if nameOK, nameScore := fuzzyMatch(name, pattern); nameOK {
+		score += nameScore
+	}
+	if idx := strings.LastIndex(lowerPath, lowerPat); idx >= 0 {
+		score += fuzzySubstringBonus * (idx + len(lowerPat)) / len(lowerPath)
+	}
+
+	return true, score
+}
+
+// ClosestMatch returns the candidate that best fuzzy-matches input, or ""
+// when nothing matches. Both directions are tried so that missing letters
+// ("ChromCache") and extra letters ("ChromeCaches") are both suggested.
+func ClosestMatch(input string, candidates []string) string {
+	best, bestScore := "", -1
+	for _, c := range candidates {
+		ok, score := fuzzyMatch(c, input)
+		if !ok {
+			ok, score = fuzzyMatch(input, c)
+		}
+		if ok && score > bestScore {
+			best, bestScore = c, score
+		}
+	}
+	return best
+}
+
 // SearchResult holds a matched entry and its score.
 type SearchResult struct {
 	Entry *DirEntry
@@ -298,11 +840,60 @@ func (h *searchHeap) Pop() interface{} {
 	return item
 }
 
+// SearchOptions selects how a search query is interpreted.
+type SearchOptions struct {
+	ByPath bool // match the path relative to the search root, not just the name
+	Regex  bool // treat the query as a Go regular expression instead of fuzzy
+}
+
 // SearchTreeBounded performs fuzzy search across the entire tree using a min-heap
 // to keep memory bounded at O(maxResults). Returns matches sorted by score desc.
 func SearchTreeBounded(root *DirEntry, query string, maxResults int) []SearchResult {
+	results, _ := SearchTreeWithOptions(root, query, maxResults, SearchOptions{})
+	return results
+}
+
+// SearchTreeByPath is like SearchTreeBounded but matches the query against
+// each entry's path relative to root instead of just its name, so entries
+// can be found by typing part of a parent directory.
+func SearchTreeByPath(root *DirEntry, query string, maxResults int) []SearchResult {
+	results, _ := SearchTreeWithOptions(root, query, maxResults, SearchOptions{ByPath: true})
+	return results
+}
+
+// SearchTreeWithOptions is SearchTreeBounded with a configurable match mode.
+// In regex mode the query is compiled once; an invalid expression is
+// returned as an error. Regex matches that start earlier rank higher.
+func SearchTreeWithOptions(root *DirEntry, query string, maxResults int, opts SearchOptions) ([]SearchResult, error) {
 	if query == "" || root == nil || maxResults <= 0 {
-		return nil
+		return nil, nil
+	}
+
+	var re *regexp.Regexp
+	if opts.Regex {
+		var err error
+		if re, err = regexp.Compile(query); err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+	}
+
+	match := func(entry *DirEntry) (bool, int) {
+		target := entry.Name
+		if opts.ByPath {
+			target = strings.TrimLeft(strings.TrimPrefix(entry.Path, root.Path), `\/`)
+		}
+		switch {
+		case re != nil:
+			loc := re.FindStringIndex(target)
+			if loc == nil {
+				return false, 0
+			}
+			return true, -loc[0]
+		case opts.ByPath:
+			return fuzzyPathMatch(target, entry.Name, query)
+		default:
+			return fuzzyMatch(target, query)
+		}
 	}
 
 	h := &searchHeap{}
@@ -310,7 +901,7 @@ func SearchTreeBounded(root *DirEntry, query string, maxResults int) []SearchRes
 
 	var search func(entry *DirEntry)
 	search = func(entry *DirEntry) {
-		if matched, score := fuzzyMatch(entry.Name, query); matched {
+		if matched, score := match(entry); matched {
 			if h.Len() < maxResults {
 				heap.Push(h, SearchResult{Entry: entry, Score: score})
 			} else if score > (*h)[0].Score || (score == (*h)[0].Score && entry.Size > (*h)[0].Entry.Size) {
@@ -336,7 +927,7 @@ func SearchTreeBounded(root *DirEntry, query string, maxResults int) []SearchRes
 		results[i] = heap.Pop(h).(SearchResult)
 	}
 
-	return results
+	return results, nil
 }
 
 // SearchTree is a convenience alias for SearchTreeBounded.
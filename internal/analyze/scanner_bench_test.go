@@ -0,0 +1,67 @@
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// wideTree creates a directory with n subdirectories holding one small file
+// each: the shape that used to start a goroutine per subdirectory.
+func wideTree(b *testing.B, n int) string {
+	b.Helper()
+	dir := b.TempDir()
+	for i := range n {
+		sub := filepath.Join(dir, fmt.Sprintf("d%05d", i))
+		if err := os.Mkdir(sub, 0o755); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(sub, "f.txt"), []byte("x"), 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// BenchmarkScanWide reports the peak goroutine count while scanning 5000
+// sibling directories. With the worker pool it stays near the worker count
+// instead of growing with the number of directories.
+func BenchmarkScanWide(b *testing.B) {
+	dir := wideTree(b, 5000)
+	base := runtime.NumGoroutine()
+
+	var peak atomic.Int64
+	stop := make(chan struct{})
+	sampled := make(chan struct{})
+	go func() {
+		defer close(sampled)
+		for {
+			if n := int64(runtime.NumGoroutine()); n > peak.Load() {
+				peak.Store(n)
+			}
+			select {
+			case <-stop:
+				return
+			case <-time.After(50 * time.Microsecond):
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewScanner(8, nil).Scan(context.Background(), dir); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	close(stop)
+	<-sampled
+
+	// Exclude the goroutines that existed before, including the sampler.
+	b.ReportMetric(float64(peak.Load()-int64(base)-1), "peak-goroutines")
+}
@@ -0,0 +1,503 @@
+package analyze
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/muesli/termenv"
+)
+
+// ---------------------------------------------------------------------------
+// fuzzyMatch tests
+// ---------------------------------------------------------------------------
+
+func TestFuzzyMatch_Ordering(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		better  string
+		worse   string
+	}{
+		{"substring beats scattered", "report", "my-report.pdf", "r_e_p_o_r_t"},
+		{"prefix beats substring", "report", "report.pdf", "my-report.pdf"},
+		{"prefix beats scattered", "report", "report.pdf", "r_e_p_o_r_t"},
+		{"case-insensitive prefix", "node", "Node_Modules", "n.o.d.e"},
+		{"substring beats word-start subsequence", "cache", "webcache", "c-a-c-h-e"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			okBetter, scoreBetter := fuzzyMatch(tt.better, tt.pattern)
+			okWorse, scoreWorse := fuzzyMatch(tt.worse, tt.pattern)
+			if !okBetter || !okWorse {
+				t.Fatalf("expected both to match %q: %q=%v, %q=%v",
+					tt.pattern, tt.better, okBetter, tt.worse, okWorse)
+			}
+			if scoreBetter <= scoreWorse {
+				t.Errorf("%q (score %d) should rank above %q (score %d) for %q",
+					tt.better, scoreBetter, tt.worse, scoreWorse, tt.pattern)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatch_SubsequenceFallback(t *testing.T) {
+	tests := []struct {
+		str     string
+		pattern string
+		want    bool
+	}{
+		{"r_e_p_o_r_t", "report", true},
+		{"package-lock.json", "pkjson", true},
+		{"report.pdf", "", true},
+		{"report.pdf", "fdp", false},
+		{"abc", "abcd", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := fuzzyMatch(tt.str, tt.pattern); got != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.str, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Path search tests
+// ---------------------------------------------------------------------------
+
+func TestSearchTreeByPath(t *testing.T) {
+	root := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true}
+	docs := &DirEntry{Name: "docs", Path: `C:\root\docs`, IsDir: true, Parent: root}
+	report := &DirEntry{Name: "q3.pdf", Path: `C:\root\docs\q3.pdf`, Parent: docs}
+	docsFile := &DirEntry{Name: "docs.txt", Path: `C:\root\other\docs.txt`, Parent: root}
+	docs.Children = []*DirEntry{report}
+	root.Children = []*DirEntry{docs, docsFile}
+
+	if got := SearchTreeBounded(root, "docsq3", 10); len(got) != 0 {
+		t.Errorf("name search should not match across directories, got %d result(s)", len(got))
+	}
+
+	got := SearchTreeByPath(root, "docsq3", 10)
+	if len(got) != 1 || got[0].Entry != report {
+		t.Fatalf("path search for %q should find only %s, got %v", "docsq3", report.Path, got)
+	}
+
+	// "docs" matches all three paths; the file named docs.txt should beat
+	// entries that only match through a parent directory.
+	got = SearchTreeByPath(root, "docs", 10)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 results for %q, got %d", "docs", len(got))
+	}
+	if got[len(got)-1].Entry != report {
+		t.Errorf("match only in parent directory should rank last, got %s", got[len(got)-1].Entry.Path)
+	}
+	if got[0].Entry == report {
+		t.Errorf("name match should rank above parent-only match")
+	}
+}
+
+func TestSearchTreeWithOptions_Regex(t *testing.T) {
+	root := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true}
+	root.Children = []*DirEntry{
+		{Name: "report-2024.pdf", Path: `C:\root\report-2024.pdf`, Parent: root},
+		{Name: "old-report.pdf", Path: `C:\root\old-report.pdf`, Parent: root},
+		{Name: "notes.txt", Path: `C:\root\notes.txt`, Parent: root},
+	}
+
+	got, err := SearchTreeWithOptions(root, `report.*\.pdf$`, 10, SearchOptions{Regex: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(got))
+	}
+	if got[0].Entry.Name != "report-2024.pdf" {
+		t.Errorf("match at start of name should rank first, got %s", got[0].Entry.Name)
+	}
+
+	if _, err := SearchTreeWithOptions(root, `report(`, 10, SearchOptions{Regex: true}); err == nil {
+		t.Error("expected an error for an invalid regex")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Age threshold tests
+// ---------------------------------------------------------------------------
+
+func TestFormatAgeShort(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{180 * day, "6mo"},
+		{365 * day, "1y"},
+		{14 * day, "2w"},
+		{90 * day, "3mo"},
+		{45 * day, "45d"},
+	}
+	for _, tt := range tests {
+		if got := FormatAgeShort(tt.in); got != tt.want {
+			t.Errorf("FormatAgeShort(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsOld_UsesThreshold(t *testing.T) {
+	defer func(prev time.Duration) { OldThreshold = prev }(OldThreshold)
+
+	entry := &DirEntry{ModTime: time.Now().Add(-60 * 24 * time.Hour)}
+	if entry.IsOld() {
+		t.Error("60-day-old entry should not be old under the 6mo default")
+	}
+	OldThreshold = 30 * 24 * time.Hour
+	if !entry.IsOld() {
+		t.Error("60-day-old entry should be old with a 30-day threshold")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// FolderOwner tests
+// ---------------------------------------------------------------------------
+
+func TestFolderOwner(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{`C:\Users\me\AppData\Local\Google\Chrome`, "Google Chrome"},
+		{`C:\Users\me\AppData\Roaming\discord`, "Discord"},
+		{`C:\Users\me\AppData\Roaming\Code`, "VS Code"},
+		{`C:\Users\me\AppData\Local\Packages\CanonicalGroupLimited.Ubuntu_79rhkp1fndgsc`, "WSL (Ubuntu)"},
+		{`C:\Users\me\AppData\Local\Docker\`, "Docker"},
+		{`C:\Users\me\Projects\Code`, ""},
+		{`C:\Users\me\AppData\Local\SomethingUnknown`, ""},
+		{``, ""},
+	}
+	for _, tt := range tests {
+		if got := FolderOwner(tt.path); got != tt.want {
+			t.Errorf("FolderOwner(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Count aggregation tests
+// ---------------------------------------------------------------------------
+
+func TestCalculateSizes_Counts(t *testing.T) {
+	root := &DirEntry{Name: "root", IsDir: true}
+	sub := &DirEntry{Name: "sub", IsDir: true, Parent: root}
+	empty := &DirEntry{Name: "empty", IsDir: true, Parent: sub}
+	sub.Children = []*DirEntry{
+		{Name: "a", Size: 10, Parent: sub},
+		{Name: "b", Size: 20, Parent: sub},
+		empty,
+	}
+	root.Children = []*DirEntry{sub, {Name: "c", Size: 5, Parent: root}}
+
+	(&Scanner{}).calculateSizes(root)
+
+	if root.Size != 35 || root.FileCount != 3 || root.DirCount != 2 {
+		t.Errorf("root = %d bytes, %d files, %d dirs; want 35, 3, 2", root.Size, root.FileCount, root.DirCount)
+	}
+	if sub.FileCount != 2 || sub.DirCount != 1 {
+		t.Errorf("sub = %d files, %d dirs; want 2, 1", sub.FileCount, sub.DirCount)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Scan cancellation tests
+// ---------------------------------------------------------------------------
+
+func TestScan_Canceled(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"a", "b", filepath.Join("a", "deep")} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a", "deep", "f.txt"), []byte("data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	full, err := NewScanner(2, nil).Scan(context.Background(), dir)
+	if err != nil || !full.Scanned || full.Size != 4 {
+		t.Fatalf("uncanceled scan = %d bytes, scanned %v, err %v; want 4, true, nil", full.Size, full.Scanned, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	partial, err := NewScanner(2, nil).Scan(ctx, dir)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled scan err = %v, want context.Canceled", err)
+	}
+	if partial == nil || partial.Scanned || len(partial.Children) != 0 {
+		t.Errorf("canceled scan should return the unread root, got %+v", partial)
+	}
+}
+
+func TestScan_EntryLimit(t *testing.T) {
+	dir := t.TempDir()
+	for i := range 10 {
+		name := filepath.Join(dir, strings.Repeat("f", i+1)+".txt")
+		if err := os.WriteFile(name, []byte("12345"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanner := NewScanner(1, nil).WithLimits(4, 0)
+	root, err := scanner.Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("a limited scan should stop gracefully, got %v", err)
+	}
+	if got := scanner.Truncated(); got != "scan truncated at 4 entries" {
+		t.Errorf("Truncated() = %q", got)
+	}
+	if len(root.Children) != 4 || root.Size != 20 || root.FileCount != 4 {
+		t.Errorf("partial tree = %d children, %d bytes, %d files; want 4, 20, 4",
+			len(root.Children), root.Size, root.FileCount)
+	}
+
+	roomy := NewScanner(1, nil).WithLimits(100, time.Hour)
+	if _, err := roomy.Scan(context.Background(), dir); err != nil || roomy.Truncated() != "" {
+		t.Errorf("scan within its limits: err %v, truncated %q", err, roomy.Truncated())
+	}
+}
+
+func TestRescan_KeepsLimits(t *testing.T) {
+	dir := t.TempDir()
+	for i := range 10 {
+		name := filepath.Join(dir, strings.Repeat("f", i+1)+".txt")
+		if err := os.WriteFile(name, []byte("12345"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := &DirEntry{Name: filepath.Base(dir), Path: dir, IsDir: true}
+	m := NewAnalyzeModel(root, 0, 0).WithLimits(4, 0)
+	msg := rescanEntry(context.Background(), root, m.newScanner())().(rescanResultMsg)
+	if msg.err != nil {
+		t.Fatalf("limited rescan: %v", msg.err)
+	}
+	if msg.truncated != "scan truncated at 4 entries" {
+		t.Errorf("rescan truncated = %q, want the entry limit", msg.truncated)
+	}
+	if len(msg.fresh.Children) != 4 {
+		t.Errorf("rescan read %d entries, want 4", len(msg.fresh.Children))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Scan estimate tests
+// ---------------------------------------------------------------------------
+
+func TestFollowJunctions_ClaimTarget(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "Scan")
+	s := NewScanner(1, nil).WithFollowJunctions(true)
+	if !s.claimTarget(base) {
+		t.Fatal("first claim refused")
+	}
+	for _, tc := range []struct {
+		path string
+		want bool
+	}{
+		{filepath.Join(base, "Sub"), false},        // inside the scan
+		{strings.ToUpper(base), false},             // same place, other case
+		{filepath.Dir(base), false},                // loops back to an ancestor
+		{base + "2", true},                         // sibling sharing a prefix
+		{filepath.Join(base+"2", "Deeper"), false}, // inside a followed target
+		{filepath.Join(filepath.Dir(base), "Other"), true},
+	} {
+		if got := s.claimTarget(tc.path); got != tc.want {
+			t.Errorf("claimTarget(%s) = %v, want %v", tc.path, got, tc.want)
+		}
+	}
+
+	// Links nested maxFollowDepth deep are not followed, whatever the target.
+	dir := &DirEntry{Path: base}
+	for i := 0; i < maxFollowDepth; i++ {
+		dir = &DirEntry{Path: filepath.Join(dir.Path, "link"), Followed: true, Parent: dir}
+	}
+	if _, ok := s.followTarget(dir, filepath.Join(dir.Path, "link")); ok {
+		t.Error("followed a link past maxFollowDepth")
+	}
+	if sk := s.Skipped(); len(sk) != 1 || sk[0].Reason != SkipLinkCycle {
+		t.Errorf("skipped = %+v, want one %s entry", sk, SkipLinkCycle)
+	}
+}
+
+func TestRecalculate_SystemEntries(t *testing.T) {
+	root := &DirEntry{Name: "C:", IsDir: true}
+	root.Children = []*DirEntry{
+		{Name: "pagefile.sys", Size: 16 << 30, IsSystem: true, Parent: root},
+		{Name: "Users", Size: 4 << 30, Parent: root},
+	}
+
+	Recalculate(root, false)
+	if root.Size != 4<<30 || root.FileCount != 1 {
+		t.Errorf("without system files: %d bytes, %d files; want %d, 1", root.Size, root.FileCount, int64(4<<30))
+	}
+	Recalculate(root, true)
+	if root.Size != 20<<30 || root.FileCount != 2 {
+		t.Errorf("with system files: %d bytes, %d files; want %d, 2", root.Size, root.FileCount, int64(20<<30))
+	}
+}
+
+func TestSystemEntries_ToggleAndProtect(t *testing.T) {
+	root := &DirEntry{Name: "C:", Path: `C:\`, IsDir: true}
+	root.Children = []*DirEntry{
+		{Name: "hiberfil.sys", Path: `C:\hiberfil.sys`, Size: 8 << 30, IsSystem: true, Parent: root},
+		{Name: "Users", Path: `C:\Users`, Size: 1 << 30, Parent: root},
+	}
+	Recalculate(root, false)
+	m := NewAnalyzeModel(root, 0, 0)
+
+	if items := m.visibleItems(); len(items) != 1 || items[0].Name != "Users" {
+		t.Fatalf("system entry listed by default: %v", items)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	m = updated.(AnalyzeModel)
+	items := m.visibleItems()
+	if len(items) != 2 || items[0].Name != "hiberfil.sys" {
+		t.Fatalf("S did not list the system entry first: %v", items)
+	}
+	if root.Size != 9<<30 {
+		t.Errorf("root = %d bytes after S, want %d", root.Size, int64(9<<30))
+	}
+
+	// The cursor is on hiberfil.sys: it can be neither selected nor deleted.
+	for _, key := range []string{" ", "backspace"} {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		if key == "backspace" {
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		}
+		updated, _ = m.Update(msg)
+		m = updated.(AnalyzeModel)
+	}
+	if len(m.selected) != 0 || m.confirmDelete {
+		t.Error("protected system entry was selected or offered for deletion")
+	}
+}
+
+func TestFormatFileCount(t *testing.T) {
+	tests := map[int64]string{1: "1 file", 999: "999 files", 12403: "12,403 files", 1000000: "1,000,000 files"}
+	for n, want := range tests {
+		if got := formatFileCount(n); got != want {
+			t.Errorf("formatFileCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestFormatETA(t *testing.T) {
+	tests := map[time.Duration]string{
+		-time.Second:      "0s",
+		45 * time.Second:  "45s",
+		190 * time.Second: "3m 10s",
+		time.Hour + 5*time.Minute + 20*time.Second: "1h 5m",
+	}
+	for d, want := range tests {
+		if got := formatETA(d); got != want {
+			t.Errorf("formatETA(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// FileTypeBreakdown tests
+// ---------------------------------------------------------------------------
+
+func TestFileTypeBreakdown(t *testing.T) {
+	root := &DirEntry{Name: "root", IsDir: true}
+	sub := &DirEntry{Name: "sub", IsDir: true}
+	sub.Children = []*DirEntry{
+		{Name: "b.MP4", Size: 300},
+		{Name: "Makefile", Size: 5},
+	}
+	root.Children = []*DirEntry{
+		{Name: "a.mp4", Size: 100},
+		{Name: "disk.iso", Size: 250},
+		{Name: ".gitignore", Size: 1},
+		sub,
+	}
+
+	stats := FileTypeBreakdown(root, false)
+	if len(stats) != 3 {
+		t.Fatalf("got %d groups, want 3: %+v", len(stats), stats)
+	}
+	if stats[0].Ext != ".mp4" || stats[0].Size != 400 || stats[0].Count != 2 {
+		t.Errorf("first group = %s %d bytes %d files, want .mp4 400 2", stats[0].Ext, stats[0].Size, stats[0].Count)
+	}
+	if stats[0].Files[0].Name != "b.MP4" {
+		t.Errorf("largest .mp4 = %s, want b.MP4", stats[0].Files[0].Name)
+	}
+	if stats[2].Ext != ExtNone || stats[2].Count != 2 {
+		t.Errorf("last group = %s with %d files, want %s with 2", stats[2].Ext, stats[2].Count, ExtNone)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// NO_COLOR rendering tests
+// ---------------------------------------------------------------------------
+
+func TestRenderView_NoColor(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	defer lipgloss.SetColorProfile(prev)
+
+	root := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true, Size: 300 << 20}
+	root.Children = []*DirEntry{
+		{Name: "big.iso", Path: `C:\root\big.iso`, Size: 200 << 20, Parent: root},
+		{Name: "small.txt", Path: `C:\root\small.txt`, Size: largeFileSize - 1, Parent: root},
+	}
+	view := NewAnalyzeModel(root, 0, 0).View()
+
+	if strings.Contains(view, "\x1b[") {
+		t.Error("view contains ANSI escapes under NO_COLOR")
+	}
+	if !strings.Contains(view, "big.iso") || strings.Count(view, "[large]") != 1 {
+		t.Errorf("want exactly one [large] marker, for big.iso:\n%s", view)
+	}
+	if !strings.Contains(view, ui.IconBlock) {
+		t.Error("cursor glyph missing under NO_COLOR")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Name truncation tests
+// ---------------------------------------------------------------------------
+
+func TestRenderView_TruncatesNamesByWidth(t *testing.T) {
+	cjk := strings.Repeat("微信开发者工具", 5) // 35 runes, 3 bytes each
+	emoji := strings.Repeat("🦊", 30)    // 30 runes, 4 bytes each
+	root := &DirEntry{Name: "root", Path: `C:\root`, IsDir: true, Size: 3 << 20}
+	root.Children = []*DirEntry{
+		{Name: cjk, Path: `C:\root\` + cjk, Size: 2 << 20, Parent: root},
+		{Name: emoji, Path: `C:\root\` + emoji, Size: 1 << 20, Parent: root},
+	}
+	view := NewAnalyzeModel(root, 0, 0).View()
+
+	if !utf8.ValidString(view) {
+		t.Fatal("view contains invalid UTF-8")
+	}
+	// The default 80-column view leaves 22 cells for a name, and these
+	// characters are two cells wide.
+	for _, want := range []string{
+		string([]rune(cjk)[:10]) + "…",
+		string([]rune(emoji)[:10]) + "…",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view lacks truncated name %q:\n%s", want, view)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package analyze
+
+import (
+	"sort"
+	"time"
+)
+
+// StaleExcludeDirs are directory names skipped when looking for stale files
+// in a user profile: application data and the per-user Recycle Bin are not
+// things the user archives by hand.
+var StaleExcludeDirs = []string{"AppData", "$Recycle.Bin"}
+
+// FindStaleFiles returns every file under root that is at least minSize
+// bytes and older than olderThan (see DirEntry.IsOlderThan), sorted by size
+// descending.
+func FindStaleFiles(root *DirEntry, minSize int64, olderThan time.Duration) []*DirEntry {
+	var stale []*DirEntry
+
+	var walk func(entry *DirEntry)
+	walk = func(entry *DirEntry) {
+		if entry.IsSystem {
+			return // belongs to Windows, never a cleanup candidate
+		}
+		if !entry.IsDir {
+			if entry.Size >= minSize && entry.IsOlderThan(olderThan) {
+				stale = append(stale, entry)
+			}
+			return
+		}
+		for _, child := range entry.Children {
+			walk(child)
+		}
+	}
+	if root != nil {
+		walk(root)
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].Size > stale[j].Size
+	})
+	return stale
+}
@@ -47,6 +47,11 @@ func printEntry(entry *DirEntry, prefix string, isLast bool, depth int, maxDepth
 		return
 	}
 
+	// Protected system entries aren't in the totals, so leave them out too.
+	if entry.IsSystem {
+		return
+	}
+
 	// ASCII tree connector characters for maximum compatibility.
 	connector := "+-- "
 	childPrefix := "|   "
@@ -67,6 +72,12 @@ func printEntry(entry *DirEntry, prefix string, isLast bool, depth int, maxDepth
 	if entry.IsDir {
 		dirMarker = "/"
 	}
+	if entry.IsReparse {
+		dirMarker = " -> " + entry.Target
+	}
+	if entry.Followed {
+		dirMarker = "/ -> " + entry.Target
+	}
 
 	fmt.Printf("  %s%s%s%s  %s\n", prefix, connector, entry.Name, dirMarker, sizeStr)
 
@@ -97,3 +108,48 @@ func printEntry(entry *DirEntry, prefix string, isLast bool, depth int, maxDepth
 		}
 	}
 }
+
+// plainTopN is the number of entries listed by PrintPlainList.
+const plainTopN = 50
+
+// PrintPlainList prints the largest entries anywhere under root as a flat,
+// tab-separated list (size in bytes, human size, path). Output is sorted by
+// size then path so it is stable across runs — suitable for scripts and CI.
+// Respects depth and minSize filters.
+func PrintPlainList(root *DirEntry, maxDepth int, minSize int64) {
+	if root == nil {
+		return
+	}
+
+	var entries []*DirEntry
+	var walk func(e *DirEntry, depth int)
+	walk = func(e *DirEntry, depth int) {
+		for _, child := range e.Children {
+			if maxDepth > 0 && depth+1 > maxDepth {
+				return
+			}
+			if child.IsSystem {
+				continue
+			}
+			if child.Size >= minSize {
+				entries = append(entries, child)
+			}
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Size != entries[j].Size {
+			return entries[i].Size > entries[j].Size
+		}
+		return entries[i].Path < entries[j].Path
+	})
+	if len(entries) > plainTopN {
+		entries = entries[:plainTopN]
+	}
+
+	for _, e := range entries {
+		fmt.Printf("%d\t%s\t%s\n", e.Size, core.FormatSize(e.Size), e.Path)
+	}
+}
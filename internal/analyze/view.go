@@ -3,7 +3,7 @@ package analyze
 import (
 	"fmt"
 	"strings"
-	"unicode/utf8"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
@@ -11,16 +11,28 @@ import (
 
 // ─── Color tokens ────────────────────────────────────────────────────────────
 
-// Short aliases for readability in render functions.
-// Coral accent gives the analyzer its own visual identity.
-var (
-	clrDim    = ui.ColorMuted
-	clrDir    = ui.ColorCoral // coral for analyzer directories
-	clrFile   = ui.ColorText
-	clrOld    = ui.ColorMuted
-	clrLarge  = ui.ColorWarning
+// Short aliases for readability in render functions, refreshed whenever a
+// theme is applied. Coral accent gives the analyzer its own visual identity.
+var clrDim, clrDir, clrFile, clrOld, clrLarge, clrCursor, clrLink lipgloss.AdaptiveColor
+
+// largeFileSize is the size from which a file is highlighted as large.
+const largeFileSize = 100 << 20
+
+func init() {
+	setColors()
+	ui.OnThemeChange(setColors)
+}
+
+// setColors points the aliases at the active palette.
+func setColors() {
+	clrDim = ui.ColorMuted
+	clrDir = ui.ColorCoral // coral for analyzer directories
+	clrFile = ui.ColorText
+	clrOld = ui.ColorMuted
+	clrLarge = ui.ColorWarning
 	clrCursor = ui.ColorPrimary
-)
+	clrLink = ui.ColorTeal
+}
 
 // ─── Top-level view ──────────────────────────────────────────────────────────
 
@@ -28,9 +40,9 @@ func (m AnalyzeModel) renderView() string {
 	if m.quitting {
 		return ""
 	}
-	w := m.width
-	if w < 40 {
-		w = 40
+	w := m.viewWidth()
+	if m.showHelp {
+		return ui.RenderHelp("Disk Analyzer keys", helpGroups, w, m.height)
 	}
 
 	var s strings.Builder
@@ -41,6 +53,10 @@ func (m AnalyzeModel) renderView() string {
 		s.WriteString(m.renderSearchInput(w))
 		s.WriteString("\n")
 		s.WriteString(m.renderSearchResults(w))
+	} else if m.typesMode {
+		s.WriteString(m.renderTypes(w))
+	} else if m.dupesMode {
+		s.WriteString(m.renderDupes(w))
 	} else {
 		s.WriteString(m.renderBody(w))
 	}
@@ -50,6 +66,20 @@ func (m AnalyzeModel) renderView() string {
 	return s.String()
 }
 
+// viewWidth is the width the view renders at.
+func (m AnalyzeModel) viewWidth() int {
+	if m.width < 40 {
+		return 40
+	}
+	return m.width
+}
+
+// bodyTop is the screen row of the first list entry, used to map mouse
+// clicks to entries. The header box can wrap, so it is measured.
+func (m AnalyzeModel) bodyTop() int {
+	return lipgloss.Height(m.renderHeader(m.viewWidth()))
+}
+
 // ─── Header ──────────────────────────────────────────────────────────────────
 
 func (m AnalyzeModel) renderHeader(w int) string {
@@ -64,14 +94,9 @@ func (m AnalyzeModel) renderHeader(w int) string {
 		Render(fmt.Sprintf("  %s    %s", m.current.Path, sizeStr))
 
 	// Breadcrumb trail.
-	var crumbs []string
-	for _, bc := range m.breadcrumb {
-		crumbs = append(crumbs, bc.Name)
-	}
-	crumbs = append(crumbs, m.current.Name)
 	bcStr := lipgloss.NewStyle().
 		Foreground(ui.ColorMuted).
-		Render("  " + strings.Join(crumbs, " "+ui.IconChevron+" "))
+		Render("  " + strings.Join(m.crumbLabels(), crumbSep))
 
 	inner := lipgloss.JoinVertical(lipgloss.Left, title, pathLine, bcStr)
 
@@ -82,6 +107,48 @@ func (m AnalyzeModel) renderHeader(w int) string {
 		Render(inner)
 }
 
+// crumbSep separates the entries of the breadcrumb trail.
+var crumbSep = " " + ui.IconChevron + " "
+
+// crumbLabels returns the breadcrumb trail from the scan root to the
+// current directory. Once drilled in, the first nine are numbered for the
+// digit keys that jump to them.
+func (m AnalyzeModel) crumbLabels() []string {
+	trail := append(append([]*DirEntry(nil), m.breadcrumb...), m.current)
+	labels := make([]string, len(trail))
+	for i, e := range trail {
+		labels[i] = e.Name
+		if len(m.breadcrumb) > 0 && i < 9 {
+			labels[i] = fmt.Sprintf("%d %s", i+1, e.Name)
+		}
+	}
+	return labels
+}
+
+// crumbAt maps a click at screen column x, row y to the breadcrumb level
+// under it, an index into m.breadcrumb. Only the first line of the trail is
+// mapped when it wraps, and the current directory is not a target.
+func (m AnalyzeModel) crumbAt(x, y int) (int, bool) {
+	w := m.viewWidth()
+	labels := m.crumbLabels()
+	trail := lipgloss.NewStyle().Width(w - 2).Render("  " + strings.Join(labels, crumbSep))
+	// The trail is the last line(s) inside the header box.
+	row := lipgloss.Height(m.renderHeader(w)) - 1 - lipgloss.Height(trail)
+	if y != row {
+		return 0, false
+	}
+
+	col := 3 // border + indent
+	for i, label := range labels[:len(labels)-1] {
+		lw := lipgloss.Width(label)
+		if x >= col && x < col+lw {
+			return i, true
+		}
+		col += lw + lipgloss.Width(crumbSep)
+	}
+	return 0, false
+}
+
 // ─── Body (file list) ────────────────────────────────────────────────────────
 
 func (m AnalyzeModel) renderBody(w int) string {
@@ -104,8 +171,17 @@ func (m AnalyzeModel) renderBody(w int) string {
 	parentSize := m.current.Size
 	var lines []string
 
+	// The ".." row is not numbered.
+	first := 1
+	if items[0].up {
+		first = 0
+	}
 	for i := m.offset; i < len(items) && i < m.offset+vh; i++ {
-		lines = append(lines, m.renderEntry(i+1, items[i], parentSize, barWidth, i == m.cursor))
+		if items[i].up {
+			lines = append(lines, m.renderUpEntry(items[i], barWidth, i == m.cursor))
+			continue
+		}
+		lines = append(lines, m.renderEntry(i+first, items[i], parentSize, barWidth, i == m.cursor))
 	}
 
 	// Scrollbar hint.
@@ -132,6 +208,12 @@ func (m AnalyzeModel) renderEntry(num int, entry *DirEntry, parentSize int64, ba
 	if entry.IsDir {
 		icon = ui.IconFolder
 	}
+	if entry.IsReparse {
+		icon = ui.IconArrow
+	}
+	if _, ok := m.selected[entry.Path]; ok {
+		icon = ui.SuccessStyle().Render(ui.IconCheck)
+	}
 
 	// ── Name ─────────────────────────────────────────────────
 	nameColor := clrFile
@@ -141,19 +223,55 @@ func (m AnalyzeModel) renderEntry(num int, entry *DirEntry, parentSize int64, ba
 	if entry.IsOld() {
 		nameColor = clrOld
 	}
-	if !entry.IsDir && entry.Size >= 100*(1<<20) {
+	if !entry.IsDir && entry.Size >= largeFileSize {
 		nameColor = clrLarge
 	}
+	if entry.IsReparse {
+		nameColor = clrLink
+	}
+	if entry.IsSystem {
+		nameColor = clrDim
+	}
+
+	// Known app folders get a dim "· Owner" hint after the name; links
+	// show their target and protected system entries say so.
+	owner := ""
+	switch {
+	case entry.IsSystem:
+		owner = "system, protected"
+	case entry.IsReparse:
+		owner = "link"
+		if entry.Target != "" {
+			owner = ui.IconArrow + " " + entry.Target
+		}
+	case entry.Followed:
+		owner = ui.IconArrow + " " + entry.Target
+	case entry.IsDir:
+		owner = FolderOwner(entry.Path)
+	}
+
+	// File count, on wide terminals so names aren't squeezed, or always
+	// when # turned counts on.
+	count := ""
+	if entry.IsDir && entry.FileCount > 0 && (m.width >= 100 || m.showCounts) {
+		count = "  " + lipgloss.NewStyle().Foreground(clrDim).Render(formatFileCount(entry.FileCount))
+	}
 
 	maxName := m.width - barWidth - 38
+	if owner != "" {
+		maxName -= lipgloss.Width(owner) + 3
+	}
+	if m.width < 100 {
+		maxName -= lipgloss.Width(count)
+	}
 	if maxName < 12 {
 		maxName = 12
 	}
-	name := entry.Name
-	if len(name) > maxName {
-		name = name[:maxName-1] + "…"
+	name := ui.Truncate(entry.Name, maxName)
+	nameStr := lipgloss.NewStyle().Foreground(nameColor).Bold(entry.IsDir && !entry.IsReparse).Render(name)
+	if owner != "" {
+		nameStr += lipgloss.NewStyle().Foreground(clrDim).Render(" · " + owner)
 	}
-	nameStr := lipgloss.NewStyle().Foreground(nameColor).Bold(entry.IsDir).Render(name)
 
 	// ── Metadata columns ─────────────────────────────────────
 	numStr := lipgloss.NewStyle().Foreground(clrDim).Render(fmt.Sprintf("%3d.", num))
@@ -162,27 +280,315 @@ func (m AnalyzeModel) renderEntry(num int, entry *DirEntry, parentSize int64, ba
 
 	age := "     "
 	if entry.IsOld() {
-		age = ui.TagWarningStyle().Render(" >6mo ")
+		age = ui.TagWarningStyle().Render(" >" + FormatAgeShort(OldThreshold) + " ")
+	}
+	// Large files are otherwise marked only by color.
+	if !entry.IsDir && entry.Size >= largeFileSize && ui.NoColor() {
+		age += " [large]"
 	}
 
 	// ── Assemble ─────────────────────────────────────────────
-	line := fmt.Sprintf("  %s %s  %s  %s %s  %s  %s",
-		numStr, bar, pctStr, icon, nameStr, sizeStr, age)
+	line := fmt.Sprintf("  %s %s  %s  %s %s  %s  %s%s",
+		numStr, bar, pctStr, icon, nameStr, sizeStr, age, count)
 
 	if selected {
 		cursor := lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(ui.IconBlock)
 		line = " " + cursor + line[2:]
 		if m.confirmDelete {
+			prompt := "Press Enter to " + m.deleteVerb()
+			if n := len(m.selected); n > 0 {
+				prompt = fmt.Sprintf("%d selected (%s): press Enter to %s",
+					n, ui.FormatSizePlain(m.selectedSize()), m.deleteVerb())
+			}
 			line += lipgloss.NewStyle().
 				Foreground(ui.ColorError).
 				Bold(true).
-				Render("  " + ui.IconWarning + " Press Enter to delete")
+				Render("  " + ui.IconWarning + " " + prompt)
 		}
 	}
 
 	return line
 }
 
+// renderUpEntry draws the ".." row in the name column, with the parent
+// directory it leads to and that directory's total size for context.
+func (m AnalyzeModel) renderUpEntry(entry *DirEntry, barWidth int, selected bool) string {
+	parent := m.breadcrumb[len(m.breadcrumb)-1]
+	name := lipgloss.NewStyle().Foreground(clrDir).Bold(true).Render("..")
+	dest := lipgloss.NewStyle().Foreground(clrDim).Render(" · up to " + ui.Truncate(parent.Name, 40))
+
+	line := fmt.Sprintf("  %4s %s  %6s  %s %s%s  %s",
+		"", strings.Repeat(" ", barWidth), "", ui.IconFolder, name, dest, ui.FormatSize(entry.Size))
+	if selected {
+		cursor := lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(ui.IconBlock)
+		line = " " + cursor + line[2:]
+	}
+	return line
+}
+
+// formatFileCount renders n with thousands separators, e.g. "12,403 files".
+func formatFileCount(n int64) string {
+	if n == 1 {
+		return formatCount(n) + " file"
+	}
+	return formatCount(n) + " files"
+}
+
+// formatCount renders n with thousands separators, e.g. "5,000,000".
+func formatCount(n int64) string {
+	digits := fmt.Sprintf("%d", n)
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// renderDeleteProgress shows a running delete's bar, rate, and rough ETA.
+// Recycle Bin moves report no progress and get a plain tag.
+func (m AnalyzeModel) renderDeleteProgress() string {
+	p := m.deleteProgress
+	if p.BytesTotal <= 0 {
+		return "  " + ui.TagAccentStyle().Render(" deleting… ")
+	}
+
+	pct := float64(p.BytesFreed) / float64(p.BytesTotal) * 100
+	line := fmt.Sprintf("  %s %s / %s", ui.GradientBar(pct, 20),
+		ui.FormatSizePlain(p.BytesFreed), ui.FormatSizePlain(p.BytesTotal))
+
+	elapsed := time.Since(m.deleteStart).Seconds()
+	if elapsed > 0 && p.BytesFreed > 0 {
+		rate := float64(p.BytesFreed) / elapsed
+		eta := time.Duration(float64(p.BytesTotal-p.BytesFreed) / rate * float64(time.Second))
+		line += fmt.Sprintf(" · %s/s · ETA %s", ui.FormatSizePlain(int64(rate)), formatETA(eta))
+	}
+	if p.FilesRemoved > 0 {
+		line += " · " + formatFileCount(p.FilesRemoved)
+	}
+	return lipgloss.NewStyle().Foreground(ui.ColorTextDim).Render(line)
+}
+
+// formatETA rounds d to a short human form: "45s", "3m 10s", "1h 5m".
+func formatETA(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// deleteVerb describes what confirming a delete will do in the current mode.
+func (m AnalyzeModel) deleteVerb() string {
+	if m.permanentDelete {
+		return "delete permanently"
+	}
+	return "move to Recycle Bin"
+}
+
+// ─── File Types ──────────────────────────────────────────────────────────────
+
+// renderTypes shows the by-extension breakdown, or the largest files of the
+// drilled-into extension.
+func (m AnalyzeModel) renderTypes(w int) string {
+	if m.typesExt != nil {
+		return m.renderTypeFiles(w)
+	}
+	if len(m.types) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(ui.ColorMuted).
+			Italic(true).
+			Render("  (no files)")
+	}
+
+	barWidth := 20
+	if w > 110 {
+		barWidth = 30
+	}
+	vh := m.viewportHeight()
+	start := 0
+	if m.typesCursor >= vh {
+		start = m.typesCursor - vh + 1
+	}
+
+	var lines []string
+	for i := start; i < len(m.types) && i < start+vh; i++ {
+		st := m.types[i]
+		pct := 0.0
+		if m.current.Size > 0 {
+			pct = float64(st.Size) / float64(m.current.Size) * 100
+		}
+		ext := lipgloss.NewStyle().Foreground(clrDir).Bold(true).Render(ui.PadRight(st.Ext, 10))
+		count := lipgloss.NewStyle().Foreground(clrDim).Render(formatFileCount(st.Count))
+		line := fmt.Sprintf("  %s  %5.1f%%  %s  %s  %s",
+			ui.GradientBar(pct, barWidth), pct, ext, ui.FormatSize(st.Size), count)
+		if i == m.typesCursor {
+			cursor := lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(ui.IconBlock)
+			line = " " + cursor + line[2:]
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderTypeFiles lists the drilled-into extension's files, largest first.
+func (m AnalyzeModel) renderTypeFiles(w int) string {
+	st := m.typesExt
+	title := lipgloss.NewStyle().Foreground(clrDir).Bold(true).
+		Render(fmt.Sprintf("  %s  %s in %s", st.Ext, ui.FormatSizePlain(st.Size), formatFileCount(st.Count)))
+	lines := []string{title}
+
+	vh := m.viewportHeight() - 1
+	if vh < 1 {
+		vh = 1
+	}
+	start := 0
+	if m.filesCursor >= vh {
+		start = m.filesCursor - vh + 1
+	}
+
+	maxPath := w - 20
+	if maxPath < 20 {
+		maxPath = 20
+	}
+	for i := start; i < len(st.Files) && i < start+vh; i++ {
+		f := st.Files[i]
+		path := ui.TruncateLeft(f.Path, maxPath)
+		line := fmt.Sprintf("  %10s  %s", ui.FormatSizePlain(f.Size),
+			lipgloss.NewStyle().Foreground(clrFile).Render(path))
+		if i == m.filesCursor {
+			cursor := lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(ui.IconBlock)
+			line = " " + cursor + line[2:]
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ─── Duplicates ──────────────────────────────────────────────────────────────
+
+// renderDupes shows hashing progress, the duplicate sets largest savings
+// first, or the copies in the opened set.
+func (m AnalyzeModel) renderDupes(w int) string {
+	dim := lipgloss.NewStyle().Foreground(ui.ColorMuted).Italic(true)
+	if m.dupesRunning {
+		p := m.dupesProgress
+		pct := 0.0
+		if p.BytesTotal > 0 {
+			pct = float64(p.BytesDone) / float64(p.BytesTotal) * 100
+		}
+		return lipgloss.NewStyle().Foreground(ui.ColorTextDim).Render(fmt.Sprintf(
+			"  Hashing same-size files… %s %s / %s · %s of %s",
+			ui.GradientBar(pct, 20), ui.FormatSizePlain(p.BytesDone), ui.FormatSizePlain(p.BytesTotal),
+			formatCount(p.FilesDone), formatFileCount(p.FilesTotal)))
+	}
+	if len(m.dupes) == 0 {
+		return dim.Render(fmt.Sprintf("  (no duplicate files of %s or more)", ui.FormatSizePlain(minDuplicateSize)))
+	}
+	if m.dupesOpen {
+		return m.renderDupeFiles(w)
+	}
+
+	var total int64
+	for _, set := range m.dupes {
+		total += set.Reclaimable()
+	}
+	title := lipgloss.NewStyle().Foreground(clrDir).Bold(true).Render(fmt.Sprintf(
+		"  %d duplicate sets · %s reclaimable", len(m.dupes), ui.FormatSizePlain(total)))
+	lines := []string{title}
+
+	vh := m.viewportHeight() - 1
+	if vh < 1 {
+		vh = 1
+	}
+	start := 0
+	if m.dupesCursor >= vh {
+		start = m.dupesCursor - vh + 1
+	}
+
+	maxName := w - 44
+	if maxName < 12 {
+		maxName = 12
+	}
+	for i := start; i < len(m.dupes) && i < start+vh; i++ {
+		set := m.dupes[i]
+		copies := lipgloss.NewStyle().Foreground(clrDim).Render(
+			fmt.Sprintf("%d × %s", len(set.Files), ui.FormatSizePlain(set.Size)))
+		name := lipgloss.NewStyle().Foreground(clrFile).Render(ui.Truncate(set.Files[0].Name, maxName))
+		line := fmt.Sprintf("  %10s  %-16s  %s", ui.FormatSizePlain(set.Reclaimable()), copies, name)
+		if i == m.dupesCursor {
+			cursor := lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(ui.IconBlock)
+			line = " " + cursor + line[2:]
+			if m.dupesConfirm {
+				line += m.renderDupeConfirm(set)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderDupeFiles lists the copies in the open set, marking the one kept.
+func (m AnalyzeModel) renderDupeFiles(w int) string {
+	set := m.dupes[m.dupesCursor]
+	title := lipgloss.NewStyle().Foreground(clrDir).Bold(true).Render(fmt.Sprintf(
+		"  %d copies of %s · %s reclaimable", len(set.Files), ui.FormatSizePlain(set.Size),
+		ui.FormatSizePlain(set.Reclaimable())))
+	if m.dupesConfirm {
+		title += m.renderDupeConfirm(set)
+	}
+	lines := []string{title}
+
+	vh := m.viewportHeight() - 1
+	if vh < 1 {
+		vh = 1
+	}
+	start := 0
+	if m.dupeFileCursor >= vh {
+		start = m.dupeFileCursor - vh + 1
+	}
+
+	maxPath := w - 30
+	if maxPath < 20 {
+		maxPath = 20
+	}
+	for i := start; i < len(set.Files) && i < start+vh; i++ {
+		f := set.Files[i]
+		mark := ui.TagStyle().Render(" keep ")
+		if i != set.Keep {
+			mark = lipgloss.NewStyle().Foreground(ui.ColorError).Render("  " + ui.IconError + "   ")
+		}
+		line := fmt.Sprintf("  %s  %s  %s", mark, f.ModTime.Format("2006-01-02"),
+			lipgloss.NewStyle().Foreground(clrFile).Render(ui.TruncateLeft(f.Path, maxPath)))
+		if i == m.dupeFileCursor {
+			cursor := lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(ui.IconBlock)
+			line = " " + cursor + line[2:]
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderDupeConfirm is the prompt shown while deleting a set's extra
+// copies awaits Enter.
+func (m AnalyzeModel) renderDupeConfirm(set DuplicateSet) string {
+	prompt := fmt.Sprintf("%d extra copies (%s): press Enter to %s",
+		len(set.Files)-1, ui.FormatSizePlain(set.Reclaimable()), m.deleteVerb())
+	return lipgloss.NewStyle().
+		Foreground(ui.ColorError).
+		Bold(true).
+		Render("  " + ui.IconWarning + " " + prompt)
+}
+
 // ─── Search UI ───────────────────────────────────────────────────────────────
 
 func (m AnalyzeModel) renderSearchInput(w int) string {
@@ -190,6 +596,11 @@ func (m AnalyzeModel) renderSearchInput(w int) string {
 		Foreground(ui.ColorCoral).
 		Bold(true).
 		Render("  / ")
+	if m.searchRegex {
+		prompt += lipgloss.NewStyle().
+			Foreground(ui.ColorCoral).
+			Render("regex: ")
+	}
 
 	query := lipgloss.NewStyle().
 		Foreground(ui.ColorText).
@@ -199,7 +610,12 @@ func (m AnalyzeModel) renderSearchInput(w int) string {
 		Foreground(ui.ColorCoral).
 		Render("▎")
 
-	return prompt + query + cursor
+	mode := ui.TagStyle().Render(" name ")
+	if m.searchPaths {
+		mode = ui.TagAccentStyle().Render(" path ")
+	}
+
+	return prompt + query + cursor + "  " + mode
 }
 
 func (m AnalyzeModel) renderSearchResults(w int) string {
@@ -210,6 +626,12 @@ func (m AnalyzeModel) renderSearchResults(w int) string {
 			Render("  Type to search across all files and directories…")
 	}
 
+	if m.searchErr != nil {
+		return lipgloss.NewStyle().
+			Foreground(ui.ColorError).
+			Render("  " + ui.IconError + " " + m.searchErr.Error())
+	}
+
 	if len(m.searchResults) == 0 {
 		return lipgloss.NewStyle().
 			Foreground(ui.ColorMuted).
@@ -245,16 +667,12 @@ func (m AnalyzeModel) renderSearchResults(w int) string {
 		// Show parent path for context
 		parentPath := ""
 		if entry.Parent != nil {
-			parentPath = entry.Parent.Path
-			// Truncate long paths — rune-safe to avoid splitting multi-byte UTF-8.
+			// Truncate long paths by display width, keeping the tail.
 			maxPathLen := w - 50
 			if maxPathLen < 20 {
 				maxPathLen = 20
 			}
-			if runeCount := utf8.RuneCountInString(parentPath); runeCount > maxPathLen {
-				runes := []rune(parentPath)
-				parentPath = "…" + string(runes[runeCount-maxPathLen+1:])
-			}
+			parentPath = ui.TruncateLeft(entry.Parent.Path, maxPathLen)
 		}
 
 		name := lipgloss.NewStyle().Foreground(nameColor).Bold(entry.IsDir).Render(entry.Name)
@@ -294,37 +712,102 @@ func (m AnalyzeModel) renderFooter(w int) string {
 				Render("  "+ui.IconError+" "+m.err.Error()))
 	}
 
+	// Transient status (e.g. after copying a path).
+	if m.status != "" {
+		parts = append(parts,
+			ui.SuccessStyle().Render("  "+ui.IconSuccess+" "+m.status))
+	}
+
+	if m.typesMode {
+		hints := ui.Hints(keyNav, keyTypeNav, keyBack, ui.KeyHelp, keyQuit)
+		if m.typesExt != nil {
+			hints = ui.Hints(keyNav, keyOpen, keyTypeBack, ui.KeyHelp, keyQuit)
+		}
+		hintStr := strings.Join(hints, " "+ui.IconPipe+" ")
+		parts = append(parts, ui.HintBarStyle().Render("  "+hintStr))
+		return strings.Join(parts, "\n")
+	}
+
+	if m.dupesMode {
+		if m.deleting {
+			progress := m.renderDeleteProgress()
+			if m.batchTotal > 1 {
+				progress = fmt.Sprintf("  %d/%d%s", m.batchTotal-len(m.batch), m.batchTotal, progress)
+			}
+			parts = append(parts, progress)
+		}
+		hints := ui.Hints(keyNav, keyDupeNav, keyDupeDel, keyBack, ui.KeyHelp, keyQuit)
+		if m.dupesOpen {
+			hints = ui.Hints(keyNav, keyDupeKeep, keyDupeDel, keyOpen, keyDupeBack, ui.KeyHelp, keyQuit)
+		}
+		hintStr := strings.Join(hints, " "+ui.IconPipe+" ")
+		parts = append(parts, ui.HintBarStyle().Render("  "+hintStr))
+		return strings.Join(parts, "\n")
+	}
+
 	if m.searching {
 		// Search mode hints
-		hints := []string{
-			"↑↓ navigate",
-			"Enter select",
-			"Esc cancel",
-		}
+		hints := ui.Hints(keySearchNav, keySearchSelect, keySearchCopy,
+			keySearchPaths, keySearchRegex, keySearchCancel)
 		hintStr := strings.Join(hints, " "+ui.IconPipe+" ")
 		parts = append(parts, ui.HintBarStyle().Render("  "+hintStr))
 		return strings.Join(parts, "\n")
 	}
 
-	// Filter indicator.
+	if m.rescanning {
+		parts = append(parts,
+			"  "+ui.TagAccentStyle().Render(" rescanning… "))
+	}
+
+	if m.deleting {
+		progress := m.renderDeleteProgress()
+		if m.batchTotal > 1 {
+			done := m.batchTotal - len(m.batch)
+			progress = fmt.Sprintf("  %d/%d%s", done, m.batchTotal, progress)
+		}
+		parts = append(parts, progress)
+	}
+
+	if n := len(m.selected); n > 0 {
+		parts = append(parts, "  "+ui.TagStyle().Render(
+			fmt.Sprintf(" %d selected · %s ", n, ui.FormatSizePlain(m.selectedSize()))))
+	}
+
+	// Incomplete-scan notice with the elevated rescan offer.
+	if m.elevateArgs != nil {
+		parts = append(parts,
+			ui.WarningStyle().Render(fmt.Sprintf("  %s %d entries unreadable without admin — press A to rescan as admin",
+				ui.IconWarning, m.deniedCount)))
+	}
+
+	if m.truncated != "" {
+		parts = append(parts,
+			ui.WarningStyle().Render(fmt.Sprintf("  %s %s — totals cover only what was scanned",
+				ui.IconWarning, m.truncated)))
+	}
+
+	// Filter indicators.
 	if m.largeOnly {
 		parts = append(parts,
 			"  "+ui.TagWarningStyle().Render(" >100 MiB filter "))
 	}
-
-	// Normal mode keybindings — ADD "/ search"
-	hints := []string{
-		"↑↓ nav",
-		"→ drill",
-		"← back",
-		"/ search",
-		"Enter open",
-		"⌫ delete",
-		"L large",
-		"q quit",
+	if m.showSystem {
+		parts = append(parts,
+			"  "+ui.TagWarningStyle().Render(" system files counted "))
 	}
+
+	// Normal mode keybindings; ? lists the rest.
+	hints := ui.Hints(keyNav, keyDrill, keyBack, keyTopEnd, keyBiggest,
+		keySearch, keyTypes, keyDupes, keyOpen, keyCopy, keySelect, keyDelete,
+		keyDelMode, keyLarge, keySystem, keyRescan, ui.KeyHelp, keyQuit)
 	hintStr := strings.Join(hints, " "+ui.IconPipe+" ")
-	parts = append(parts, ui.HintBarStyle().Render("  "+hintStr))
+
+	// Delete mode indicator shares the hint line to keep the footer height.
+	mode := ui.TagStyle().Render(" recycle bin ")
+	if m.permanentDelete {
+		mode = ui.TagErrorStyle().Render(" permanent ")
+	}
+	parts = append(parts, "  "+mode+ui.HintBarStyle().Render(" "+hintStr))
 
 	return strings.Join(parts, "\n")
 }
@@ -14,6 +14,7 @@ type browserDef struct {
 	name    string   // Human-readable browser name.
 	base    string   // Base "User Data" directory.
 	subdirs []string // Cache subdirectories within each profile.
+	flat    bool     // base is itself the profile (Opera) — no profile discovery.
 }
 
 // ─── Browser Cache Scanning ──────────────────────────────────────────────────
@@ -22,9 +23,12 @@ type browserDef struct {
 // directories across ALL profiles (Default, Profile 1, Profile 2, …).
 //
 // Only cache directories are touched — bookmarks, passwords, cookies,
-// history, extensions, and settings are NEVER included.
-func ScanBrowserCaches(wl *whitelist.Whitelist) []CleanItem {
+// history, extensions, and settings are NEVER included. Cache directories
+// are not temp directories, so only opts.ProbeLocked applies.
+func ScanBrowserCaches(wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
+	dirOpts := ScanOptions{ProbeLocked: opts.ProbeLocked}
 	local := os.Getenv("LOCALAPPDATA")
+	roaming := os.Getenv("APPDATA")
 
 	browsers := []browserDef{
 		{
@@ -56,6 +60,34 @@ func ScanBrowserCaches(wl *whitelist.Whitelist) []CleanItem {
 				"GPUCache",
 			},
 		},
+		{
+			name: "Vivaldi",
+			base: filepath.Join(local, "Vivaldi", "User Data"),
+			subdirs: []string{
+				"Cache",
+				"Code Cache",
+				"GPUCache",
+			},
+		},
+	}
+
+	// Opera keeps one profile directly in "Opera Stable": the disk cache
+	// lives under LOCALAPPDATA, the code/GPU caches under APPDATA.
+	for _, edition := range []string{"Opera Stable", "Opera GX Stable"} {
+		browsers = append(browsers,
+			browserDef{
+				name:    "Opera",
+				base:    filepath.Join(local, "Opera Software", edition),
+				subdirs: []string{"Cache"},
+				flat:    true,
+			},
+			browserDef{
+				name:    "Opera",
+				base:    filepath.Join(roaming, "Opera Software", edition),
+				subdirs: []string{"Code Cache", "GPUCache"},
+				flat:    true,
+			},
+		)
 	}
 
 	var items []CleanItem
@@ -66,7 +98,10 @@ func ScanBrowserCaches(wl *whitelist.Whitelist) []CleanItem {
 			continue // Browser not installed.
 		}
 
-		profiles := discoverChromiumProfiles(b.base)
+		profiles := []string{b.base}
+		if !b.flat {
+			profiles = discoverChromiumProfiles(b.base)
+		}
 		for _, profile := range profiles {
 			for _, subdir := range b.subdirs {
 				cacheDir := filepath.Join(profile, subdir)
@@ -74,14 +109,14 @@ func ScanBrowserCaches(wl *whitelist.Whitelist) []CleanItem {
 					continue
 				}
 				desc := b.name + " cache"
-				dirItems := scanDirectory(cacheDir, "browser", desc, wl)
+				dirItems := walkDirectory(cacheDir, "browser", desc, wl, dirOpts)
 				items = append(items, dirItems...)
 			}
 		}
 	}
 
 	// Firefox uses a different profile structure.
-	firefoxItems := scanFirefoxCaches(local, wl)
+	firefoxItems := scanFirefoxCaches(local, wl, dirOpts)
 	items = append(items, firefoxItems...)
 
 	return items
@@ -130,7 +165,7 @@ func discoverChromiumProfiles(userDataDir string) []string {
 // scanFirefoxCaches scans Firefox cache2 directories across all profiles.
 // Only the cache2 directory is scanned — profile data (bookmarks,
 // passwords, extensions) is never touched.
-func scanFirefoxCaches(local string, wl *whitelist.Whitelist) []CleanItem {
+func scanFirefoxCaches(local string, wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
 	profilesDir := filepath.Join(local, "Mozilla", "Firefox", "Profiles")
 	if _, err := os.Stat(profilesDir); err != nil {
 		return nil
@@ -150,7 +185,7 @@ func scanFirefoxCaches(local string, wl *whitelist.Whitelist) []CleanItem {
 			continue
 		}
 
-		dirItems := scanDirectory(cacheDir, "browser", "Firefox cache", wl)
+		dirItems := walkDirectory(cacheDir, "browser", "Firefox cache", wl, opts)
 		items = append(items, dirItems...)
 	}
 
@@ -0,0 +1,55 @@
+package clean
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanBrowserCaches_ProbeLocked(t *testing.T) {
+	local := t.TempDir()
+	t.Setenv("LOCALAPPDATA", local)
+	t.Setenv("APPDATA", t.TempDir())
+
+	cacheDir := filepath.Join(local, "Google", "Chrome", "User Data", "Default", "Cache")
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	held := filepath.Join(cacheDir, "data_1")
+	free := filepath.Join(cacheDir, "data_2")
+	for _, p := range []string{held, free} {
+		if err := os.WriteFile(p, []byte("data"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// os.Open does not share delete access, so the file stays locked
+	// until it is closed, the way a running browser holds its cache.
+	f, err := os.Open(held)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	locked := func(items []CleanItem) map[string]bool {
+		got := make(map[string]bool)
+		for _, item := range items {
+			got[item.Path] = item.Locked
+		}
+		return got
+	}
+
+	got := locked(ScanBrowserCaches(nil, ScanOptions{ProbeLocked: true}))
+	if len(got) != 2 {
+		t.Fatalf("found %d cache files, want 2", len(got))
+	}
+	if !got[held] || got[free] {
+		t.Errorf("Locked = %v, want only %s locked", got, held)
+	}
+
+	for path, isLocked := range locked(ScanBrowserCaches(nil, ScanOptions{})) {
+		if isLocked {
+			t.Errorf("%s marked locked without ProbeLocked", path)
+		}
+	}
+}
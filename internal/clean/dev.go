@@ -26,8 +26,9 @@ type devCacheDef struct {
 // NuGet, VS Code, JetBrains) and returns discovered items.
 //
 // SAFETY: .cargo\bin is NEVER scanned — only registry\cache and
-// registry\src are included for Cargo.
-func ScanDevCaches(wl *whitelist.Whitelist) []CleanItem {
+// registry\src are included for Cargo. Only opts.ProbeLocked applies.
+func ScanDevCaches(wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
+	dirOpts := ScanOptions{ProbeLocked: opts.ProbeLocked}
 	home := os.Getenv("USERPROFILE")
 	local := os.Getenv("LOCALAPPDATA")
 	roaming := os.Getenv("APPDATA")
@@ -87,13 +88,13 @@ func ScanDevCaches(wl *whitelist.Whitelist) []CleanItem {
 			if wl != nil && wl.IsWhitelisted(p) {
 				continue
 			}
-			dirItems := scanDirectory(p, "dev", c.description, wl)
+			dirItems := walkDirectory(p, "dev", c.description, wl, dirOpts)
 			items = append(items, dirItems...)
 		}
 	}
 
 	// JetBrains: only scan caches subdirectories within each IDE.
-	jetbrainsItems := scanJetBrainsCaches(local, wl)
+	jetbrainsItems := scanJetBrainsCaches(local, wl, dirOpts)
 	items = append(items, jetbrainsItems...)
 
 	return items
@@ -103,7 +104,7 @@ func ScanDevCaches(wl *whitelist.Whitelist) []CleanItem {
 
 // scanJetBrainsCaches scans the "caches" directory within each JetBrains
 // IDE installation directory, avoiding settings and other IDE data.
-func scanJetBrainsCaches(local string, wl *whitelist.Whitelist) []CleanItem {
+func scanJetBrainsCaches(local string, wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
 	jetbrainsDir := filepath.Join(local, "JetBrains")
 	if _, err := os.Stat(jetbrainsDir); err != nil {
 		return nil
@@ -130,7 +131,7 @@ func scanJetBrainsCaches(local string, wl *whitelist.Whitelist) []CleanItem {
 		}
 
 		desc := "JetBrains " + e.Name() + " cache"
-		dirItems := scanDirectory(cachesDir, "dev", desc, wl)
+		dirItems := walkDirectory(cachesDir, "dev", desc, wl, opts)
 		items = append(items, dirItems...)
 	}
 
@@ -0,0 +1,111 @@
+package clean
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
+)
+
+// ─── Cache Discovery ─────────────────────────────────────────────────────────
+
+// discoverCacheDirs are the exact subdirectory names (lowercased) that apps
+// conventionally use for disposable data. Anything else is left alone.
+var discoverCacheDirs = map[string]bool{
+	"cache":      true,
+	"code cache": true,
+	"gpucache":   true,
+	"cacheddata": true,
+	"logs":       true,
+}
+
+// discoverSkipApps are top-level AppData folders that are either covered by
+// dedicated targets or too sensitive to touch.
+var discoverSkipApps = map[string]bool{
+	"temp":       true, // UserTemp
+	"crashdumps": true, // CrashDumps
+	"packages":   true, // UWP app containers
+	"programs":   true, // per-user installs
+}
+
+// discoverMaxDepth bounds how far below each app folder discovery looks.
+const discoverMaxDepth = 4
+
+// DiscoverAppCaches finds cache folders under %LOCALAPPDATA% and %APPDATA%
+// that the static target list doesn't know about. Only subdirectories with
+// an exact known cache name are reported; each item's Description is the
+// owning top-level app folder. Whitelisted paths are skipped. Only
+// opts.ProbeLocked applies.
+func DiscoverAppCaches(wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
+	dirOpts := ScanOptions{ProbeLocked: opts.ProbeLocked}
+	var items []CleanItem
+	seen := make(map[string]bool)
+
+	for _, root := range []string{os.Getenv("LOCALAPPDATA"), os.Getenv("APPDATA")} {
+		if root == "" || seen[strings.ToLower(root)] {
+			continue
+		}
+		seen[strings.ToLower(root)] = true
+
+		apps, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, app := range apps {
+			if !app.IsDir() || discoverSkipApps[strings.ToLower(app.Name())] {
+				continue
+			}
+			appDir := filepath.Join(root, app.Name())
+			items = append(items, discoverInApp(appDir, app.Name(), wl, dirOpts)...)
+		}
+	}
+
+	return items
+}
+
+// discoverInApp walks one app folder looking for known cache directories.
+func discoverInApp(appDir, appName string, wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
+	var items []CleanItem
+	baseDepth := strings.Count(appDir, string(os.PathSeparator))
+
+	_ = filepath.WalkDir(appDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// Junctions and symlinks are not reported as directories, so
+		// WalkDir never follows them out of the app folder.
+		if !d.IsDir() || path == appDir {
+			return nil
+		}
+		if strings.Count(path, string(os.PathSeparator))-baseDepth > discoverMaxDepth {
+			return filepath.SkipDir
+		}
+
+		name := strings.ToLower(d.Name())
+		if !discoverCacheDirs[name] {
+			return nil
+		}
+		if wl == nil || !wl.IsWhitelisted(path) {
+			// Logs can matter for troubleshooting, so they rank medium
+			// and are grouped apart from caches.
+			description, risk := appName+" cache (discovered)", "low"
+			if name == "logs" {
+				description, risk = appName+" logs (discovered)", "medium"
+			}
+			found := walkDirectory(path, "user", description, wl, opts)
+			for i := range found {
+				found[i].RiskLevel = risk
+			}
+			items = append(items, found...)
+		}
+		// Either way, don't look for caches nested inside a cache.
+		return filepath.SkipDir
+	})
+
+	return items
+}
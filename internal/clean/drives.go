@@ -60,7 +60,7 @@ var commonJunkPatterns = []string{
 
 // ScanNonSystemDrives discovers all non-system drives and scans them for
 // temp files, junk files, and common cache directories.
-func ScanNonSystemDrives(wl *whitelist.Whitelist) []CleanItem {
+func ScanNonSystemDrives(wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
 	drives := nonSystemDrives()
 	if len(drives) == 0 {
 		return nil
@@ -89,7 +89,7 @@ func ScanNonSystemDrives(wl *whitelist.Whitelist) []CleanItem {
 				continue
 			}
 
-			dirItems := scanDirectory(dir, "user", driveLetter+": Temp files", wl)
+			dirItems := walkDirectory(dir, "user", driveLetter+": Temp files", wl, opts)
 			items = append(items, dirItems...)
 		}
 
@@ -119,7 +119,10 @@ func ScanNonSystemDrives(wl *whitelist.Whitelist) []CleanItem {
 		// 3. Scan Windows.old on non-system drives (rare but possible).
 		winOld := filepath.Join(root, "Windows.old")
 		if info, err := os.Stat(winOld); err == nil && info.IsDir() {
-			dirItems := scanDirectory(winOld, "system", driveLetter+": Windows.old", wl)
+			dirItems := walkDirectory(winOld, "system", driveLetter+": Windows.old", wl, ScanOptions{ProbeLocked: opts.ProbeLocked})
+			for i := range dirItems {
+				dirItems[i].RiskLevel = "high"
+			}
 			items = append(items, dirItems...)
 		}
 
@@ -135,7 +138,7 @@ func ScanNonSystemDrives(wl *whitelist.Whitelist) []CleanItem {
 					if wl != nil && wl.IsWhitelisted(tempDir) {
 						continue
 					}
-					dirItems := scanDirectory(tempDir, "user", driveLetter+": User temp", wl)
+					dirItems := walkDirectory(tempDir, "user", driveLetter+": User temp", wl, opts)
 					items = append(items, dirItems...)
 				}
 			}
@@ -147,7 +150,7 @@ func ScanNonSystemDrives(wl *whitelist.Whitelist) []CleanItem {
 
 // ScanDriveJunkFiles scans a specific drive for common junk files
 // recursively in the top 2 directory levels (not deep — too slow).
-func ScanDriveJunkFiles(drive string, wl *whitelist.Whitelist) []CleanItem {
+func ScanDriveJunkFiles(drive string, wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
 	root := drive + `\`
 	driveLetter := drive[:1]
 
@@ -188,7 +191,12 @@ func ScanDriveJunkFiles(drive string, wl *whitelist.Whitelist) []CleanItem {
 				if wl != nil && wl.IsWhitelisted(subPath) {
 					continue
 				}
-				dirItems := scanDirectory(subPath, "user", driveLetter+": "+name+" temp", wl)
+				// Cache folders are not temp folders: clean every file.
+				subOpts := ScanOptions{ProbeLocked: opts.ProbeLocked}
+				if !strings.EqualFold(sub, "cache") {
+					subOpts.TempMinAge = opts.TempMinAge
+				}
+				dirItems := walkDirectory(subPath, "user", driveLetter+": "+name+" temp", wl, subOpts)
 				items = append(items, dirItems...)
 			}
 		}
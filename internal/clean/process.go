@@ -0,0 +1,70 @@
+package clean
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── Running Application Detection ───────────────────────────────────────────
+
+// appProcess links an application name, as it appears in clean target and
+// result names (e.g. "ChromeCache", "Chrome cache"), to its process image.
+type appProcess struct {
+	name  string
+	image string
+}
+
+// browserProcesses lists the browsers whose caches are locked while running.
+var browserProcesses = []appProcess{
+	{"Chrome", "chrome.exe"},
+	{"Edge", "msedge.exe"},
+	{"Brave", "brave.exe"},
+	{"Firefox", "firefox.exe"},
+	{"Opera", "opera.exe"},
+	{"Vivaldi", "vivaldi.exe"},
+}
+
+// IsProcessRunning reports whether any process with the given image name
+// (e.g. "chrome.exe") is running. The comparison is case-insensitive.
+func IsProcessRunning(name string) bool {
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return false
+	}
+	defer windows.CloseHandle(snap)
+
+	var entry windows.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	for err = windows.Process32First(snap, &entry); err == nil; err = windows.Process32Next(snap, &entry) {
+		if strings.EqualFold(windows.UTF16ToString(entry.ExeFile[:]), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// RunningBrowserFor returns the browser that owns the clean target or result
+// named resultName if that browser is currently running. Open browsers hold
+// their cache files locked, so the clean will be incomplete.
+func RunningBrowserFor(resultName string) (name, image string, running bool) {
+	lower := strings.ToLower(resultName)
+	for _, app := range browserProcesses {
+		if strings.HasPrefix(lower, strings.ToLower(app.name)) && IsProcessRunning(app.image) {
+			return app.name, app.image, true
+		}
+	}
+	return "", "", false
+}
+
+// CloseProcess asks every process with the given image name to close via
+// taskkill (without /F, so apps can shut down cleanly and save state).
+func CloseProcess(image string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return exec.CommandContext(ctx, "taskkill", "/IM", image, "/T").Run()
+}
@@ -4,9 +4,12 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
 	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
 )
 
@@ -25,6 +28,14 @@ type CleanItem struct {
 
 	// Description is a human-readable label for the parent target.
 	Description string
+
+	// RiskLevel is inherited from the parent target ("low", "medium", "high").
+	// Empty is treated as "low".
+	RiskLevel string
+
+	// Locked marks a file another process held open during the scan, so it
+	// cannot be deleted now. Only set when ScanOptions.ProbeLocked is on.
+	Locked bool
 }
 
 // ScanResult holds the aggregated scan output for a single clean target.
@@ -40,18 +51,51 @@ type ScanResult struct {
 
 	// ItemCount is the number of items discovered.
 	ItemCount int
+
+	// RiskLevel is the highest RiskLevel among Items.
+	RiskLevel string
+
+	// LockedSize and LockedCount cover the Items that are Locked. They are
+	// included in TotalSize and ItemCount but are not reclaimable now.
+	LockedSize  int64
+	LockedCount int
 }
 
 // ─── Parallel Scan Engine ────────────────────────────────────────────────────
 
+// scanConcurrency caps how many target paths are walked at once.
+const scanConcurrency = 8
+
+// DefaultTempMinAge is the clean --temp-min-age default: temp files
+// modified more recently may belong to an install still in progress.
+const DefaultTempMinAge = 10 * time.Minute
+
+// ScanOptions tunes how a scan treats the files it finds. It is passed
+// down to every scanner alongside the whitelist.
+type ScanOptions struct {
+	// TempMinAge is how long a file in a temp directory must go unmodified
+	// before it is cleaned. Zero cleans every file.
+	TempMinAge time.Duration
+
+	// ProbeLocked checks each file for a lock held by another process and
+	// marks it Locked, so the plan only promises space that can be freed
+	// now. The probe opens every file for delete access, so it is off
+	// unless clean --check-locked asks for it.
+	ProbeLocked bool
+}
+
 // ScanAll scans all provided targets in parallel, returning results for each
-// target that has cleanable items. Targets requiring admin privileges are
-// skipped when isAdmin is false. Whitelisted paths are excluded.
-func ScanAll(targets []config.CleanTarget, wl *whitelist.Whitelist, isAdmin bool) []ScanResult {
+// target that has cleanable items. Every resolved path of every target is
+// sized by a bounded worker pool, so targets with many paths (e.g. browser
+// profiles) don't serialize. Targets requiring admin privileges are skipped
+// when isAdmin is false. Whitelisted paths are excluded.
+func ScanAll(targets []config.CleanTarget, wl *whitelist.Whitelist, isAdmin bool, opts ScanOptions) []ScanResult {
 	var (
-		mu      sync.Mutex
-		wg      sync.WaitGroup
-		results []ScanResult
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, scanConcurrency)
+		byName = make(map[string][]CleanItem)
+		names  []string
 	)
 
 	for _, t := range targets {
@@ -65,25 +109,41 @@ func ScanAll(targets []config.CleanTarget, wl *whitelist.Whitelist, isAdmin bool
 			continue
 		}
 
-		wg.Add(1)
-		go func(target config.CleanTarget) {
-			defer wg.Done()
-
-			items := scanTarget(target, wl)
-			if len(items) == 0 {
-				return
-			}
-
-			result := ItemsToResult(target.Name, items)
+		// WindowsOld is removed as a whole via CleanWindowsOld, which has
+		// its own confirmation — never delete it file by file here.
+		if t.Name == "WindowsOld" {
+			continue
+		}
 
-			mu.Lock()
-			results = append(results, result)
-			mu.Unlock()
-		}(t)
+		names = append(names, t.Name)
+		for _, path := range resolveTargetPaths(t, wl) {
+			wg.Add(1)
+			go func(target config.CleanTarget, path string) {
+				defer wg.Done()
+
+				sem <- struct{}{}
+				items := scanTargetPath(target, path, wl, opts)
+				<-sem
+
+				if len(items) == 0 {
+					return
+				}
+				mu.Lock()
+				byName[target.Name] = append(byName[target.Name], items...)
+				mu.Unlock()
+			}(t, path)
+		}
 	}
 
 	wg.Wait()
 
+	var results []ScanResult
+	for _, name := range names {
+		if items := byName[name]; len(items) > 0 {
+			results = append(results, ItemsToResult(name, items))
+		}
+	}
+
 	// Sort results by category name for stable output.
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Category < results[j].Category
@@ -94,10 +154,12 @@ func ScanAll(targets []config.CleanTarget, wl *whitelist.Whitelist, isAdmin bool
 
 // ─── Single-Target Scanning ──────────────────────────────────────────────────
 
-// scanTarget scans a single CleanTarget by resolving environment variables
-// and glob patterns in its paths.
-func scanTarget(target config.CleanTarget, wl *whitelist.Whitelist) []CleanItem {
-	var items []CleanItem
+// resolveTargetPaths expands environment variables and glob patterns in a
+// target's paths, dropping whitelisted matches. A path reached by more than
+// one entry (e.g. "Default" via a literal and a "*" glob) is returned once.
+func resolveTargetPaths(target config.CleanTarget, wl *whitelist.Whitelist) []string {
+	var paths []string
+	seen := make(map[string]bool)
 
 	for _, rawPath := range target.Paths {
 		// Expand environment variables.
@@ -113,36 +175,60 @@ func scanTarget(target config.CleanTarget, wl *whitelist.Whitelist) []CleanItem
 		for _, path := range matches {
 			path = filepath.Clean(path)
 
+			key := strings.ToLower(path)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
 			// Skip whitelisted paths.
 			if wl != nil && wl.IsWhitelisted(path) {
 				continue
 			}
+			paths = append(paths, path)
+		}
+	}
 
-			info, statErr := os.Lstat(path)
-			if statErr != nil {
-				continue // Path doesn't exist or is inaccessible.
-			}
+	return paths
+}
 
-			if info.IsDir() {
-				dirItems := scanDirectory(path, target.Category, target.Description, wl)
-				items = append(items, dirItems...)
-			} else {
-				items = append(items, CleanItem{
-					Path:        path,
-					Size:        info.Size(),
-					Category:    target.Category,
-					Description: target.Description,
-				})
-			}
-		}
+// scanTargetPath collects the cleanable items at a single resolved path of
+// target, tagging each with the target's metadata.
+func scanTargetPath(target config.CleanTarget, path string, wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
+	info, statErr := os.Lstat(path)
+	if statErr != nil {
+		return nil // Path doesn't exist or is inaccessible.
+	}
+
+	var items []CleanItem
+	switch {
+	case info.IsDir() && target.Temp:
+		items = walkDirectory(path, target.Category, target.Description, wl, opts)
+	case info.IsDir():
+		items = walkDirectory(path, target.Category, target.Description, wl, ScanOptions{ProbeLocked: opts.ProbeLocked})
+	default:
+		items = []CleanItem{{
+			Path:        path,
+			Size:        info.Size(),
+			Category:    target.Category,
+			Description: target.Description,
+			Locked:      opts.ProbeLocked && core.IsFileLocked(path),
+		}}
 	}
 
+	for i := range items {
+		items[i].RiskLevel = target.RiskLevel
+	}
 	return items
 }
 
-// scanDirectory walks a directory tree collecting all files as CleanItems.
-// Whitelisted and inaccessible entries are silently skipped.
-func scanDirectory(dir, category, description string, wl *whitelist.Whitelist) []CleanItem {
+// walkDirectory collects the files under dir last modified more than
+// opts.TempMinAge ago; a zero TempMinAge collects every file, as wanted for
+// directories that are not temp directories. Whitelisted and inaccessible
+// entries are silently skipped.
+func walkDirectory(dir, category, description string, wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
+	minAge := opts.TempMinAge
+	cutoff := time.Now().Add(-minAge)
 	var items []CleanItem
 
 	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
@@ -161,12 +247,16 @@ func scanDirectory(dir, category, description string, wl *whitelist.Whitelist) [
 		if infoErr != nil {
 			return nil
 		}
+		if minAge > 0 && info.ModTime().After(cutoff) {
+			return nil
+		}
 
 		items = append(items, CleanItem{
 			Path:        path,
 			Size:        info.Size(),
 			Category:    category,
 			Description: description,
+			Locked:      opts.ProbeLocked && core.IsFileLocked(path),
 		})
 		return nil
 	})
@@ -179,15 +269,27 @@ func scanDirectory(dir, category, description string, wl *whitelist.Whitelist) [
 // ItemsToResult converts a slice of CleanItems into a ScanResult with
 // the given name and pre-calculated totals.
 func ItemsToResult(name string, items []CleanItem) ScanResult {
-	var totalSize int64
+	var totalSize, lockedSize int64
+	var lockedCount int
+	risk := "low"
 	for _, item := range items {
 		totalSize += item.Size
+		if item.Locked {
+			lockedSize += item.Size
+			lockedCount++
+		}
+		if config.RiskRank(item.RiskLevel) > config.RiskRank(risk) {
+			risk = item.RiskLevel
+		}
 	}
 	return ScanResult{
-		Category:  name,
-		Items:     items,
-		TotalSize: totalSize,
-		ItemCount: len(items),
+		Category:    name,
+		Items:       items,
+		TotalSize:   totalSize,
+		ItemCount:   len(items),
+		RiskLevel:   risk,
+		LockedSize:  lockedSize,
+		LockedCount: lockedCount,
 	}
 }
 
@@ -213,6 +315,18 @@ func TotalSizeAll(results []ScanResult) int64 {
 	return total
 }
 
+// LockedTotals returns the combined size and count of the Locked items
+// across all scan results.
+func LockedTotals(results []ScanResult) (int64, int) {
+	var size int64
+	var count int
+	for _, r := range results {
+		size += r.LockedSize
+		count += r.LockedCount
+	}
+	return size, count
+}
+
 // TotalItemCount returns the combined item count across all scan results.
 func TotalItemCount(results []ScanResult) int {
 	var total int
@@ -0,0 +1,60 @@
+package clean
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+)
+
+func TestScanTargetPath_TempSkipsRecentFiles(t *testing.T) {
+	dir := t.TempDir()
+	oldFile := filepath.Join(dir, "leftover.tmp")
+	newFile := filepath.Join(dir, "installing.tmp")
+	for _, p := range []string{oldFile, newFile} {
+		if err := os.WriteFile(p, []byte("data"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hourAgo := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(oldFile, hourAgo, hourAgo); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := ScanOptions{TempMinAge: 10 * time.Minute}
+	temp := config.CleanTarget{Name: "UserTemp", Category: "user", Temp: true}
+	items := scanTargetPath(temp, dir, nil, opts)
+	if len(items) != 1 || items[0].Path != oldFile {
+		t.Fatalf("temp target items = %+v, want only %s", items, oldFile)
+	}
+
+	// Non-temp targets and a zero minimum age take every file.
+	cache := config.CleanTarget{Name: "SomeCache", Category: "user"}
+	if items := scanTargetPath(cache, dir, nil, opts); len(items) != 2 {
+		t.Errorf("non-temp target found %d files, want 2", len(items))
+	}
+	if items := scanTargetPath(temp, dir, nil, ScanOptions{}); len(items) != 2 {
+		t.Errorf("temp target with a zero TempMinAge found %d files, want 2", len(items))
+	}
+}
+
+func TestItemsToResult_LockedTotals(t *testing.T) {
+	r := ItemsToResult("UserTemp", []CleanItem{
+		{Path: `C:\t\a`, Size: 100},
+		{Path: `C:\t\b`, Size: 40, Locked: true},
+		{Path: `C:\t\c`, Size: 10, Locked: true},
+	})
+	if r.TotalSize != 150 || r.ItemCount != 3 {
+		t.Errorf("totals = %d bytes in %d items, want 150 in 3", r.TotalSize, r.ItemCount)
+	}
+	if r.LockedSize != 50 || r.LockedCount != 2 {
+		t.Errorf("locked = %d bytes in %d items, want 50 in 2", r.LockedSize, r.LockedCount)
+	}
+
+	other := ItemsToResult("ChromeCache", []CleanItem{{Path: `C:\c\x`, Size: 7, Locked: true}})
+	if size, count := LockedTotals([]ScanResult{r, other}); size != 57 || count != 3 {
+		t.Errorf("LockedTotals = %d bytes in %d items, want 57 in 3", size, count)
+	}
+}
@@ -0,0 +1,101 @@
+package clean
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ─── Scheduled Clean ─────────────────────────────────────────────────────────
+
+// ScheduledTaskName is the Task Scheduler entry used for periodic cleans.
+const ScheduledTaskName = `PureWin\Scheduled Clean`
+
+// schtasksTimeout bounds every schtasks invocation.
+const schtasksTimeout = 30 * time.Second
+
+// ErrScheduleAccessDenied is returned when Task Scheduler refuses the
+// request, typically because the process is not elevated.
+var ErrScheduleAccessDenied = errors.New("access denied by Task Scheduler")
+
+// scheduleCadences maps accepted --schedule values to schtasks /sc values.
+var scheduleCadences = map[string]string{
+	"hourly": "HOURLY",
+	"daily":  "DAILY",
+	"weekly": "WEEKLY",
+}
+
+// ValidateCadence normalizes and checks a --schedule value.
+func ValidateCadence(cadence string) (string, error) {
+	c := strings.ToLower(strings.TrimSpace(cadence))
+	if _, ok := scheduleCadences[c]; !ok {
+		return "", fmt.Errorf("invalid schedule %q (use hourly, daily, or weekly)", cadence)
+	}
+	return c, nil
+}
+
+// CreateScheduledClean registers (or replaces) a scheduled task that runs
+// "pw clean --user --quiet" at the given cadence, and returns its name.
+func CreateScheduledClean(cadence string) (string, error) {
+	c, err := ValidateCadence(cadence)
+	if err != nil {
+		return "", err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine executable path: %w", err)
+	}
+
+	action := fmt.Sprintf(`"%s" clean --user --quiet`, exe)
+	if _, err := runSchtasks("/create", "/tn", ScheduledTaskName, "/tr", action,
+		"/sc", scheduleCadences[c], "/f"); err != nil {
+		return "", fmt.Errorf("cannot create scheduled task: %w", err)
+	}
+	return ScheduledTaskName, nil
+}
+
+// DeleteScheduledClean removes the scheduled clean task.
+func DeleteScheduledClean() error {
+	if _, err := runSchtasks("/delete", "/tn", ScheduledTaskName, "/f"); err != nil {
+		return fmt.Errorf("cannot delete scheduled task: %w", err)
+	}
+	return nil
+}
+
+// ScheduledCleanStatus reports whether the scheduled clean task exists and,
+// if so, returns schtasks' summary of it (next run time, status, …).
+func ScheduledCleanStatus() (bool, string, error) {
+	out, err := runSchtasks("/query", "/tn", ScheduledTaskName, "/fo", "LIST")
+	if errors.Is(err, ErrScheduleAccessDenied) {
+		return false, "", fmt.Errorf("cannot query scheduled task: %w", err)
+	}
+	if err != nil {
+		// schtasks exits non-zero when the task does not exist.
+		return false, "", nil
+	}
+	return true, out, nil
+}
+
+// runSchtasks runs schtasks.exe with a timeout and returns its trimmed output.
+func runSchtasks(args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), schtasksTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "schtasks", args...).CombinedOutput()
+	out := strings.TrimSpace(string(output))
+	if err != nil {
+		if strings.Contains(strings.ToLower(out), "access is denied") {
+			return out, ErrScheduleAccessDenied
+		}
+		if out != "" {
+			return out, fmt.Errorf("%s: %w", out, err)
+		}
+		return out, err
+	}
+	return out, nil
+}
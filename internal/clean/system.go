@@ -28,7 +28,7 @@ func getWindowsDir() string {
 
 // ScanSystemCaches scans system-level caches that require admin privileges.
 // Returns nil immediately if the process is not elevated.
-func ScanSystemCaches(wl *whitelist.Whitelist) []CleanItem {
+func ScanSystemCaches(wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
 	if !core.IsElevated() {
 		return nil
 	}
@@ -37,6 +37,7 @@ func ScanSystemCaches(wl *whitelist.Whitelist) []CleanItem {
 		name        string
 		paths       []string
 		description string
+		temp        bool // skip files newer than opts.TempMinAge
 	}
 
 	windir := getWindowsDir()
@@ -46,6 +47,7 @@ func ScanSystemCaches(wl *whitelist.Whitelist) []CleanItem {
 			name:        "WindowsTemp",
 			paths:       []string{filepath.Join(windir, "Temp")},
 			description: "System temporary files",
+			temp:        true,
 		},
 		{
 			name:        "WUCache",
@@ -92,7 +94,12 @@ func ScanSystemCaches(wl *whitelist.Whitelist) []CleanItem {
 			if wl != nil && wl.IsWhitelisted(p) {
 				continue
 			}
-			dirItems := scanDirectory(p, "system", t.description, wl)
+			// Only temp directories hold back recently written files.
+			dirOpts := ScanOptions{ProbeLocked: opts.ProbeLocked}
+			if t.temp {
+				dirOpts.TempMinAge = opts.TempMinAge
+			}
+			dirItems := walkDirectory(p, "system", t.description, wl, dirOpts)
 			items = append(items, dirItems...)
 		}
 	}
@@ -103,8 +110,8 @@ func ScanSystemCaches(wl *whitelist.Whitelist) []CleanItem {
 // ─── Memory Dumps ────────────────────────────────────────────────────────────
 
 // ScanMemoryDumps scans for kernel and minidump crash files.
-// Returns nil if not elevated.
-func ScanMemoryDumps() []CleanItem {
+// Returns nil if not elevated. Only opts.ProbeLocked applies.
+func ScanMemoryDumps(opts ScanOptions) []CleanItem {
 	if !core.IsElevated() {
 		return nil
 	}
@@ -120,13 +127,14 @@ func ScanMemoryDumps() []CleanItem {
 			Size:        info.Size(),
 			Category:    "system",
 			Description: "Kernel memory dump",
+			Locked:      opts.ProbeLocked && core.IsFileLocked(memDump),
 		})
 	}
 
 	// Minidumps.
 	minidumpDir := filepath.Join(windir, "Minidump")
 	if _, err := os.Stat(minidumpDir); err == nil {
-		dirItems := scanDirectory(minidumpDir, "system", "Minidump crash files", nil)
+		dirItems := walkDirectory(minidumpDir, "system", "Minidump crash files", nil, ScanOptions{ProbeLocked: opts.ProbeLocked})
 		items = append(items, dirItems...)
 	}
 
@@ -215,13 +223,15 @@ func runServiceCommand(action, service string) error {
 
 // ─── Windows.old ─────────────────────────────────────────────────────────────
 
-// windowsOldDir returns the path to the Windows.old directory.
-func windowsOldDir() string {
+// WindowsOldPath returns the path to the Windows.old directory on the
+// system drive (%SystemDrive%, C: when unset).
+func WindowsOldPath() string {
 	sysDrive := os.Getenv("SYSTEMDRIVE")
 	if sysDrive == "" {
 		sysDrive = "C:"
 	}
-	return filepath.Join(sysDrive, "Windows.old")
+	// Join would make "C:" + "Windows.old" drive-relative.
+	return filepath.Join(sysDrive+`\`, "Windows.old")
 }
 
 // WindowsOldSize returns the size of Windows.old if it exists.
@@ -231,7 +241,7 @@ func WindowsOldSize() int64 {
 		return 0
 	}
 
-	dir := windowsOldDir()
+	dir := WindowsOldPath()
 	if _, err := os.Stat(dir); err != nil {
 		return 0
 	}
@@ -243,14 +253,15 @@ func WindowsOldSize() int64 {
 	return size
 }
 
-// CleanWindowsOld removes Windows.old after requiring a DangerConfirm
-// from the user. This is irreversible. Requires admin privileges.
-func CleanWindowsOld(dryRun bool) (int64, error) {
+// CleanWindowsOld removes Windows.old after requiring the user to type its
+// path, unless preConfirmed (--yes with --include-high). This is
+// irreversible. Requires admin privileges.
+func CleanWindowsOld(dryRun, preConfirmed bool) (int64, error) {
 	if !core.IsElevated() {
 		return 0, fmt.Errorf("removing Windows.old requires administrator privileges")
 	}
 
-	dir := windowsOldDir()
+	dir := WindowsOldPath()
 	if _, err := os.Stat(dir); err != nil {
 		return 0, nil // Not present.
 	}
@@ -261,13 +272,17 @@ func CleanWindowsOld(dryRun bool) (int64, error) {
 		return size, nil
 	}
 
-	// Require explicit dangerous confirmation.
-	confirmed, err := ui.DangerConfirm(fmt.Sprintf(
-		"Delete Windows.old (%s)? This is IRREVERSIBLE and removes your ability to roll back.",
-		core.FormatSize(size),
-	))
-	if err != nil || !confirmed {
-		return 0, nil // User declined.
+	// Require the user to type the path — a stray "yes" is not enough.
+	fmt.Println()
+	fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf(
+		"  %s Delete Windows.old (%s)? This is IRREVERSIBLE and removes your ability to roll back.",
+		ui.IconWarning, core.FormatSize(size),
+	)))
+	if !preConfirmed {
+		confirmed, err := ui.TypedConfirm(dir)
+		if err != nil || !confirmed {
+			return 0, nil // User declined.
+		}
 	}
 
 	freed, delErr := core.SafeDelete(dir, false)
@@ -281,8 +296,9 @@ func CleanWindowsOld(dryRun bool) (int64, error) {
 // ─── WER User Reports ────────────────────────────────────────────────────────
 
 // ScanWERUserReports scans Windows Error Reporting directories that are
-// accessible without admin (user-level WER paths).
-func ScanWERUserReports(wl *whitelist.Whitelist) []CleanItem {
+// accessible without admin (user-level WER paths). Only opts.ProbeLocked
+// applies.
+func ScanWERUserReports(wl *whitelist.Whitelist, opts ScanOptions) []CleanItem {
 	local := os.Getenv("LOCALAPPDATA")
 	if local == "" {
 		return nil
@@ -301,7 +317,7 @@ func ScanWERUserReports(wl *whitelist.Whitelist) []CleanItem {
 		if wl != nil && wl.IsWhitelisted(p) {
 			continue
 		}
-		dirItems := scanDirectory(p, "system", "Windows Error Reports (user)", wl)
+		dirItems := walkDirectory(p, "system", "Windows Error Reports (user)", wl, ScanOptions{ProbeLocked: opts.ProbeLocked})
 		items = append(items, dirItems...)
 	}
 
@@ -7,14 +7,15 @@ import (
 	"strings"
 	"syscall"
 	"unsafe"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
 )
 
 // ─── Shell32 Syscalls ────────────────────────────────────────────────────────
 
 var (
-	modShell32          = syscall.NewLazyDLL("shell32.dll")
-	procEmptyRecycleBin = modShell32.NewProc("SHEmptyRecycleBinW")
-	procQueryRecycleBin = modShell32.NewProc("SHQueryRecycleBinW")
+	procEmptyRecycleBin = core.Shell32.NewProc("SHEmptyRecycleBinW")
+	procQueryRecycleBin = core.Shell32.NewProc("SHQueryRecycleBinW")
 )
 
 const (
@@ -36,7 +37,7 @@ type shQueryRBInfo struct {
 
 // ScanUserCaches scans user temporary file directories (%TEMP% and
 // %LOCALAPPDATA%\Temp), deduplicating if they resolve to the same path.
-func ScanUserCaches() []CleanItem {
+func ScanUserCaches(opts ScanOptions) []CleanItem {
 	dirs := []string{
 		os.ExpandEnv("$TEMP"),
 		filepath.Join(os.Getenv("LOCALAPPDATA"), "Temp"),
@@ -60,7 +61,7 @@ func ScanUserCaches() []CleanItem {
 		if err != nil || !info.IsDir() {
 			continue
 		}
-		dirItems := scanDirectory(dir, "user", "User temporary files", nil)
+		dirItems := walkDirectory(dir, "user", "User temporary files", nil, opts)
 		items = append(items, dirItems...)
 	}
 
@@ -98,21 +99,68 @@ func ScanThumbnailCache() []CleanItem {
 
 // ─── Recycle Bin ──────────────────────────────────────────────────────────────
 
-// ScanRecycleBin calculates the total size of items in the Windows Recycle
-// Bin across all drives using the SHQueryRecycleBinW Shell API.
-func ScanRecycleBin() (int64, error) {
-	var info shQueryRBInfo
-	info.cbSize = uint32(unsafe.Sizeof(info))
+// RecycleBinInfo summarizes the contents of the Recycle Bin.
+type RecycleBinInfo struct {
+	// Size is the total size of all items in bytes.
+	Size int64
 
-	ret, _, _ := procQueryRecycleBin.Call(
-		0, // NULL = query all drives
-		uintptr(unsafe.Pointer(&info)),
-	)
-	if ret != 0 {
-		return 0, fmt.Errorf("SHQueryRecycleBinW failed: HRESULT 0x%08x", uint32(ret))
+	// Items is the number of items in the bin.
+	Items int64
+
+	// Drive is the drive queried (e.g. "D:"), or empty for all drives.
+	Drive string
+}
+
+// Label returns a display name such as "Recycle Bin" or "Recycle Bin (D:)".
+func (r RecycleBinInfo) Label() string {
+	if r.Drive == "" {
+		return "Recycle Bin"
 	}
+	return "Recycle Bin (" + r.Drive + ")"
+}
 
-	return info.i64Size, nil
+// recycleBinQuery and recycleBinEmpty reach the Shell API. Tests swap in
+// fakes so the plan can be checked without touching the real bin.
+var (
+	recycleBinQuery = queryRecycleBin
+	recycleBinEmpty = emptyRecycleBin
+)
+
+// ScanRecycleBin reports the total size and item count of the Windows
+// Recycle Bin across all drives using the SHQueryRecycleBinW Shell API.
+func ScanRecycleBin() (RecycleBinInfo, error) {
+	return recycleBinQuery(nil)
+}
+
+// PlanRecycleBin sizes the RecycleBin target for the clean plan. The target
+// has no filesystem paths, so it is sized through the Shell API rather than
+// a directory walk. drive limits it to one drive; "" covers every drive.
+func PlanRecycleBin(drive string) (RecycleBinInfo, error) {
+	if drive == "" {
+		return ScanRecycleBin()
+	}
+	return ScanRecycleBinForDrive(drive)
+}
+
+// Empty empties the bin r was planned from: r.Drive's, or every drive's
+// when r.Drive is empty. In dryRun mode, no action is taken.
+func (r RecycleBinInfo) Empty(dryRun bool) error {
+	if r.Drive == "" {
+		return EmptyRecycleBin(dryRun)
+	}
+	return EmptyRecycleBinForDrive(r.Drive, dryRun)
+}
+
+// ScanRecycleBinForDrive reports the Recycle Bin contents of a single drive.
+// drive may be given as "D", "D:", or `D:\`.
+func ScanRecycleBinForDrive(drive string) (RecycleBinInfo, error) {
+	letter, root, err := recycleBinRoot(drive)
+	if err != nil {
+		return RecycleBinInfo{}, err
+	}
+	info, err := recycleBinQuery(root)
+	info.Drive = letter
+	return info, err
 }
 
 // EmptyRecycleBin empties the Windows Recycle Bin on all drives via the
@@ -121,9 +169,58 @@ func EmptyRecycleBin(dryRun bool) error {
 	if dryRun {
 		return nil
 	}
+	return recycleBinEmpty(nil)
+}
+
+// EmptyRecycleBinForDrive empties only the given drive's Recycle Bin.
+// In dryRun mode, no action is taken.
+func EmptyRecycleBinForDrive(drive string, dryRun bool) error {
+	_, root, err := recycleBinRoot(drive)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return nil
+	}
+	return recycleBinEmpty(root)
+}
+
+// recycleBinRoot normalizes a drive spec to its letter (e.g. "D:") and the
+// UTF-16 root path the Shell API expects (e.g. `D:\`).
+func recycleBinRoot(drive string) (string, *uint16, error) {
+	d := strings.TrimRight(strings.TrimSpace(drive), `\/`)
+	d = strings.ToUpper(strings.TrimSuffix(d, ":"))
+	if len(d) != 1 || d[0] < 'A' || d[0] > 'Z' {
+		return "", nil, fmt.Errorf("invalid drive %q (expected a letter such as D:)", drive)
+	}
+	letter := d + ":"
+	root, err := syscall.UTF16PtrFromString(letter + `\`)
+	if err != nil {
+		return "", nil, err
+	}
+	return letter, root, nil
+}
+
+// queryRecycleBin calls SHQueryRecycleBinW; a nil root queries all drives.
+func queryRecycleBin(root *uint16) (RecycleBinInfo, error) {
+	var info shQueryRBInfo
+	info.cbSize = uint32(unsafe.Sizeof(info))
+
+	ret, _, _ := procQueryRecycleBin.Call(
+		uintptr(unsafe.Pointer(root)), // NULL = query all drives
+		uintptr(unsafe.Pointer(&info)),
+	)
+	if ret != 0 {
+		return RecycleBinInfo{}, fmt.Errorf("SHQueryRecycleBinW failed: HRESULT 0x%08x", uint32(ret))
+	}
+
+	return RecycleBinInfo{Size: info.i64Size, Items: info.i64NumItems}, nil
+}
 
+// emptyRecycleBin calls SHEmptyRecycleBinW; a nil root empties all drives.
+func emptyRecycleBin(root *uint16) error {
 	flags := uintptr(sherbNoConfirmation | sherbNoProgressUI | sherbNoSound)
-	ret, _, _ := procEmptyRecycleBin.Call(0, 0, flags)
+	ret, _, _ := procEmptyRecycleBin.Call(0, uintptr(unsafe.Pointer(root)), flags)
 
 	hr := uint32(ret)
 	// S_OK (0) = success, E_UNEXPECTED (0x8000FFFF) = bin already empty.
@@ -0,0 +1,75 @@
+package clean
+
+import (
+	"syscall"
+	"testing"
+	"unsafe"
+)
+
+// fakeRecycleBin replaces the Shell API calls for the duration of a test
+// and records the roots they were called with ("" for all drives).
+func fakeRecycleBin(t *testing.T, info RecycleBinInfo) (queried, emptied *[]string) {
+	t.Helper()
+	oldQuery, oldEmpty := recycleBinQuery, recycleBinEmpty
+	t.Cleanup(func() { recycleBinQuery, recycleBinEmpty = oldQuery, oldEmpty })
+
+	queried, emptied = new([]string), new([]string)
+	root := func(p *uint16) string {
+		if p == nil {
+			return ""
+		}
+		return syscall.UTF16ToString((*[4]uint16)(unsafe.Pointer(p))[:])
+	}
+	recycleBinQuery = func(p *uint16) (RecycleBinInfo, error) {
+		*queried = append(*queried, root(p))
+		return info, nil
+	}
+	recycleBinEmpty = func(p *uint16) error {
+		*emptied = append(*emptied, root(p))
+		return nil
+	}
+	return queried, emptied
+}
+
+func TestPlanRecycleBin_SizesThroughProvider(t *testing.T) {
+	queried, _ := fakeRecycleBin(t, RecycleBinInfo{Size: 4096, Items: 3})
+
+	all, err := PlanRecycleBin("")
+	if err != nil || all.Size != 4096 || all.Items != 3 || all.Drive != "" {
+		t.Fatalf("PlanRecycleBin(\"\") = %+v, %v; want 4096 bytes, 3 items, all drives", all, err)
+	}
+
+	d, err := PlanRecycleBin("d")
+	if err != nil || d.Size != 4096 || d.Drive != "D:" {
+		t.Fatalf("PlanRecycleBin(\"d\") = %+v, %v; want 4096 bytes on D:", d, err)
+	}
+
+	if want := []string{"", `D:\`}; len(*queried) != 2 || (*queried)[0] != want[0] || (*queried)[1] != want[1] {
+		t.Errorf("queried roots = %q, want %q", *queried, want)
+	}
+
+	if _, err := PlanRecycleBin("not-a-drive"); err == nil {
+		t.Error("PlanRecycleBin accepted an invalid drive")
+	}
+}
+
+func TestRecycleBinInfo_EmptyUsesPlannedDrive(t *testing.T) {
+	_, emptied := fakeRecycleBin(t, RecycleBinInfo{})
+
+	if err := (RecycleBinInfo{Drive: "E:"}).Empty(true); err != nil {
+		t.Fatal(err)
+	}
+	if len(*emptied) != 0 {
+		t.Fatalf("dry run emptied %q", *emptied)
+	}
+
+	if err := (RecycleBinInfo{}).Empty(false); err != nil {
+		t.Fatal(err)
+	}
+	if err := (RecycleBinInfo{Drive: "E:"}).Empty(false); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"", `E:\`}; len(*emptied) != 2 || (*emptied)[0] != want[0] || (*emptied)[1] != want[1] {
+		t.Errorf("emptied roots = %q, want %q", *emptied, want)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 )
 
 const (
@@ -17,6 +18,9 @@ const (
 
 	// DefaultVersion is the config schema version.
 	DefaultVersion = "1"
+
+	// DefaultAnalyzeCacheTTL is how long a cached disk scan is offered for reuse.
+	DefaultAnalyzeCacheTTL = time.Hour
 )
 
 // Config holds the application configuration.
@@ -39,6 +43,13 @@ type Config struct {
 	// DryRunMode enables dry-run globally (no actual deletions).
 	DryRunMode bool `json:"dry_run_mode"`
 
+	// AnalyzeCacheTTL is the maximum age of a cached disk scan, as a Go
+	// duration string (e.g. "1h", "30m"). Empty means the default.
+	AnalyzeCacheTTL string `json:"analyze_cache_ttl,omitempty"`
+
+	// DisableUpdateCheck turns off the background new-version notice.
+	DisableUpdateCheck bool `json:"disable_update_check,omitempty"`
+
 	mu sync.RWMutex
 }
 
@@ -71,6 +82,8 @@ func newDefault() (*Config, error) {
 		LogFile:    filepath.Join(dir, "operations.log"),
 		DebugMode:  false,
 		DryRunMode: false,
+
+		AnalyzeCacheTTL: "1h",
 	}, nil
 }
 
@@ -162,6 +175,22 @@ func (c *Config) EnsureDirs() error {
 	return nil
 }
 
+// AnalyzeCacheDuration returns the parsed AnalyzeCacheTTL, falling back to
+// DefaultAnalyzeCacheTTL when unset or invalid.
+func (c *Config) AnalyzeCacheDuration() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.AnalyzeCacheTTL == "" {
+		return DefaultAnalyzeCacheTTL
+	}
+	d, err := time.ParseDuration(c.AnalyzeCacheTTL)
+	if err != nil || d <= 0 {
+		return DefaultAnalyzeCacheTTL
+	}
+	return d
+}
+
 // SetDebug updates the debug mode and persists the change.
 func (c *Config) SetDebug(enabled bool) error {
 	c.mu.Lock()
@@ -170,6 +199,15 @@ func (c *Config) SetDebug(enabled bool) error {
 	return c.Save()
 }
 
+// SetUpdateCheck enables or disables the new-version notice and persists
+// the change.
+func (c *Config) SetUpdateCheck(enabled bool) error {
+	c.mu.Lock()
+	c.DisableUpdateCheck = !enabled
+	c.mu.Unlock()
+	return c.Save()
+}
+
 // SetDryRun updates the dry-run mode and persists the change.
 func (c *Config) SetDryRun(enabled bool) error {
 	c.mu.Lock()
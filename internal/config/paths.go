@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/lakshaymaurya-felt/purewin/internal/envutil"
 )
@@ -26,6 +28,10 @@ type CleanTarget struct {
 
 	// RiskLevel is one of "low", "medium", "high".
 	RiskLevel string
+
+	// Temp marks directories that programs write in-flight files to, so
+	// recently modified files in them are left alone.
+	Temp bool
 }
 
 // expand resolves environment variables in a path, supporting both
@@ -34,19 +40,69 @@ func expand(path string) string {
 	return envutil.ExpandWindowsEnv(path)
 }
 
-// userProfile returns the user profile directory.
+// userProfile returns the user profile directory. Without %USERPROFILE% it
+// is derived from %HOMEDRIVE%%HOMEPATH%, then from the system drive and
+// %USERNAME%, then from the OS home directory. Returns "" if none resolve.
 func userProfile() string {
-	return os.Getenv("USERPROFILE")
+	if p := os.Getenv("USERPROFILE"); p != "" {
+		return p
+	}
+	if drive, path := os.Getenv("HOMEDRIVE"), os.Getenv("HOMEPATH"); drive != "" && path != "" {
+		return drive + path
+	}
+	if user := os.Getenv("USERNAME"); user != "" {
+		return filepath.Join(systemDrive(), "Users", user)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return home
+	}
+	return ""
 }
 
-// localAppData returns the local app data directory.
+// localAppData returns the local app data directory, falling back to
+// <profile>\AppData\Local if %LOCALAPPDATA% is not set.
 func localAppData() string {
-	return os.Getenv("LOCALAPPDATA")
+	if p := os.Getenv("LOCALAPPDATA"); p != "" {
+		return p
+	}
+	if home := userProfile(); home != "" {
+		return filepath.Join(home, "AppData", "Local")
+	}
+	return ""
 }
 
-// appData returns the roaming app data directory.
+// appData returns the roaming app data directory, falling back to
+// <profile>\AppData\Roaming if %APPDATA% is not set.
 func appData() string {
-	return os.Getenv("APPDATA")
+	if p := os.Getenv("APPDATA"); p != "" {
+		return p
+	}
+	if home := userProfile(); home != "" {
+		return filepath.Join(home, "AppData", "Roaming")
+	}
+	return ""
+}
+
+// isRooted reports whether path is absolute: a drive (C:\), UNC share, or
+// an absolute path on the host OS. Paths built from an unresolvable base
+// come out relative and must never be cleaned, since they would resolve
+// against the working directory.
+func isRooted(path string) bool {
+	if len(path) >= 3 && path[1] == ':' && (path[2] == '\\' || path[2] == '/') {
+		return true
+	}
+	return strings.HasPrefix(path, `\\`) || strings.HasPrefix(path, "//") || filepath.IsAbs(path)
+}
+
+// rootedPaths drops any path that isRooted rejects.
+func rootedPaths(paths []string) []string {
+	kept := paths[:0]
+	for _, p := range paths {
+		if isRooted(p) {
+			kept = append(kept, p)
+		}
+	}
+	return kept
 }
 
 // winDir returns the Windows directory (e.g., C:\Windows).
@@ -93,12 +149,13 @@ func programFilesX86() string {
 }
 
 // GetCleanTargets returns all available cleanup targets with paths expanded.
+// Paths whose base directory cannot be determined are omitted.
 func GetCleanTargets() []CleanTarget {
 	home := userProfile()
 	local := localAppData()
 	roaming := appData()
 
-	return []CleanTarget{
+	targets := []CleanTarget{
 		// ── User Temp ───────────────────────────────────────────
 		{
 			Name:          "UserTemp",
@@ -107,6 +164,7 @@ func GetCleanTargets() []CleanTarget {
 			RequiresAdmin: false,
 			Category:      "user",
 			RiskLevel:     "low",
+			Temp:          true,
 		},
 
 		// ── System Temp ─────────────────────────────────────────
@@ -117,16 +175,17 @@ func GetCleanTargets() []CleanTarget {
 			RequiresAdmin: true,
 			Category:      "system",
 			RiskLevel:     "low",
+			Temp:          true,
 		},
 
 		// ── Browser Caches ──────────────────────────────────────
 		{
 			Name: "ChromeCache",
 			Paths: []string{
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "Cache"),
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "Code Cache"),
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "GPUCache"),
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "Service Worker", "CacheStorage"),
+				filepath.Join(local, "Google", "Chrome", "User Data", "*", "Cache"),
+				filepath.Join(local, "Google", "Chrome", "User Data", "*", "Code Cache"),
+				filepath.Join(local, "Google", "Chrome", "User Data", "*", "GPUCache"),
+				filepath.Join(local, "Google", "Chrome", "User Data", "*", "Service Worker", "CacheStorage"),
 			},
 			Description:   "Google Chrome browser cache",
 			RequiresAdmin: false,
@@ -136,10 +195,10 @@ func GetCleanTargets() []CleanTarget {
 		{
 			Name: "EdgeCache",
 			Paths: []string{
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "Cache"),
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "Code Cache"),
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "GPUCache"),
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "Service Worker", "CacheStorage"),
+				filepath.Join(local, "Microsoft", "Edge", "User Data", "*", "Cache"),
+				filepath.Join(local, "Microsoft", "Edge", "User Data", "*", "Code Cache"),
+				filepath.Join(local, "Microsoft", "Edge", "User Data", "*", "GPUCache"),
+				filepath.Join(local, "Microsoft", "Edge", "User Data", "*", "Service Worker", "CacheStorage"),
 			},
 			Description:   "Microsoft Edge browser cache",
 			RequiresAdmin: false,
@@ -161,15 +220,44 @@ func GetCleanTargets() []CleanTarget {
 		{
 			Name: "BraveCache",
 			Paths: []string{
-				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "Default", "Cache"),
-				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "Default", "Code Cache"),
-				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "Default", "GPUCache"),
+				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "*", "Cache"),
+				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "*", "Code Cache"),
+				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "*", "GPUCache"),
 			},
 			Description:   "Brave browser cache",
 			RequiresAdmin: false,
 			Category:      "browser",
 			RiskLevel:     "low",
 		},
+		{
+			Name: "VivaldiCache",
+			Paths: []string{
+				filepath.Join(local, "Vivaldi", "User Data", "*", "Cache"),
+				filepath.Join(local, "Vivaldi", "User Data", "*", "Code Cache"),
+				filepath.Join(local, "Vivaldi", "User Data", "*", "GPUCache"),
+			},
+			Description:   "Vivaldi browser cache",
+			RequiresAdmin: false,
+			Category:      "browser",
+			RiskLevel:     "low",
+		},
+		{
+			// Opera keeps a single profile directly in "Opera Stable" (no
+			// "User Data"); the disk cache is local, the rest roaming.
+			Name: "OperaCache",
+			Paths: []string{
+				filepath.Join(local, "Opera Software", "Opera Stable", "Cache"),
+				filepath.Join(roaming, "Opera Software", "Opera Stable", "Code Cache"),
+				filepath.Join(roaming, "Opera Software", "Opera Stable", "GPUCache"),
+				filepath.Join(local, "Opera Software", "Opera GX Stable", "Cache"),
+				filepath.Join(roaming, "Opera Software", "Opera GX Stable", "Code Cache"),
+				filepath.Join(roaming, "Opera Software", "Opera GX Stable", "GPUCache"),
+			},
+			Description:   "Opera browser cache",
+			RequiresAdmin: false,
+			Category:      "browser",
+			RiskLevel:     "low",
+		},
 
 		// ── Developer Caches ────────────────────────────────────
 		{
@@ -362,6 +450,11 @@ func GetCleanTargets() []CleanTarget {
 			RiskLevel:     "medium",
 		},
 	}
+
+	for i := range targets {
+		targets[i].Paths = rootedPaths(targets[i].Paths)
+	}
+	return targets
 }
 
 // GetTargetsByCategory returns clean targets filtered by category.
@@ -375,6 +468,41 @@ func GetTargetsByCategory(category string) []CleanTarget {
 	return result
 }
 
+// RiskRank orders RiskLevel values for comparison. Unknown or empty levels
+// rank as "low".
+func RiskRank(level string) int {
+	switch level {
+	case "high":
+		return 2
+	case "medium":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// RiskAllowed reports whether a target at level may be cleaned under maxRisk.
+// High-risk targets are also allowed when includeHigh is set.
+func RiskAllowed(level, maxRisk string, includeHigh bool) bool {
+	if includeHigh && level == "high" {
+		return true
+	}
+	return RiskRank(level) <= RiskRank(maxRisk)
+}
+
+// FilterByRisk splits targets into those allowed under maxRisk and those
+// held back for being too risky.
+func FilterByRisk(targets []CleanTarget, maxRisk string, includeHigh bool) (kept, skipped []CleanTarget) {
+	for _, t := range targets {
+		if RiskAllowed(t.RiskLevel, maxRisk, includeHigh) {
+			kept = append(kept, t)
+		} else {
+			skipped = append(skipped, t)
+		}
+	}
+	return kept, skipped
+}
+
 // GetNeverDeletePaths returns paths that must NEVER be deleted under any
 // circumstances. This list uses environment variables to support Windows
 // installations on any drive letter (not just C:).
@@ -401,3 +529,62 @@ func GetNeverDeletePaths() []string {
 		filepath.Join(w, "Prefetch"),
 	}
 }
+
+// IsProtectedPath reports whether candidate is, or is inside, one of the
+// GetNeverDeletePaths entries. Both sides are normalized first: separators
+// unified, trailing separators dropped, 8.3 short names expanded, and case
+// folded, so "c:/windows/system32/" and "C:\PROGRA~1" are caught.
+func IsProtectedPath(candidate string) bool {
+	c := normalizeForCompare(candidate)
+	if c == "" {
+		return false
+	}
+	for _, p := range normalizedNeverDelete() {
+		if c == p || strings.HasPrefix(c+`\`, p+`\`) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeForCompare returns a lowercase, backslash-separated, cleaned form
+// of path with no trailing separator. Existing paths are resolved through
+// filepath.EvalSymlinks, which on Windows also expands 8.3 short names.
+func normalizeForCompare(path string) string {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return ""
+	}
+	path = strings.ReplaceAll(path, "/", `\`)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = strings.ReplaceAll(resolved, "/", `\`)
+	}
+	path = filepath.Clean(path)
+	return strings.ToLower(strings.TrimRight(path, `\`))
+}
+
+// neverDeleteCache memoizes the normalized NEVER_DELETE list, keyed by the
+// raw list so environment changes are still picked up.
+var neverDeleteCache struct {
+	sync.Mutex
+	key  string
+	norm []string
+}
+
+func normalizedNeverDelete() []string {
+	raw := GetNeverDeletePaths()
+	key := strings.Join(raw, "|")
+
+	neverDeleteCache.Lock()
+	defer neverDeleteCache.Unlock()
+	if neverDeleteCache.norm == nil || neverDeleteCache.key != key {
+		norm := make([]string, 0, len(raw))
+		for _, p := range raw {
+			if n := normalizeForCompare(p); n != "" {
+				norm = append(norm, n)
+			}
+		}
+		neverDeleteCache.key, neverDeleteCache.norm = key, norm
+	}
+	return neverDeleteCache.norm
+}
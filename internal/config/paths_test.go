@@ -119,3 +119,114 @@ func TestGetTargetsByCategory(t *testing.T) {
 		}
 	}
 }
+
+func TestFilterByRisk(t *testing.T) {
+	targets := []CleanTarget{
+		{Name: "Low", RiskLevel: "low"},
+		{Name: "Medium", RiskLevel: "medium"},
+		{Name: "High", RiskLevel: "high"},
+	}
+
+	tests := []struct {
+		maxRisk     string
+		includeHigh bool
+		want        []string
+	}{
+		{"low", false, []string{"Low"}},
+		{"medium", false, []string{"Low", "Medium"}},
+		{"low", true, []string{"Low", "High"}},
+		{"high", false, []string{"Low", "Medium", "High"}},
+	}
+
+	for _, tc := range tests {
+		kept, skipped := FilterByRisk(targets, tc.maxRisk, tc.includeHigh)
+		if len(kept)+len(skipped) != len(targets) {
+			t.Errorf("FilterByRisk(%q, %v) lost targets", tc.maxRisk, tc.includeHigh)
+		}
+		var got []string
+		for _, k := range kept {
+			got = append(got, k.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("FilterByRisk(%q, %v) kept %v, want %v", tc.maxRisk, tc.includeHigh, got, tc.want)
+		}
+	}
+}
+
+// clearProfileEnv empties every variable the path helpers read.
+func clearProfileEnv(t *testing.T) {
+	for _, v := range []string{
+		"USERPROFILE", "HOMEDRIVE", "HOMEPATH", "USERNAME",
+		"LOCALAPPDATA", "APPDATA", "TEMP", "TMP", "HOME",
+	} {
+		t.Setenv(v, "")
+	}
+}
+
+func TestGetCleanTargets_EmptyEnvironment(t *testing.T) {
+	clearProfileEnv(t)
+
+	for _, target := range GetCleanTargets() {
+		for _, p := range target.Paths {
+			if !isRooted(p) {
+				t.Errorf("target %q has unrooted path %q with an empty environment", target.Name, p)
+			}
+		}
+	}
+}
+
+func TestProfileDirs_Derived(t *testing.T) {
+	clearProfileEnv(t)
+	t.Setenv("USERPROFILE", `C:\Users\me`)
+
+	if got, want := localAppData(), filepath.Join(`C:\Users\me`, "AppData", "Local"); got != want {
+		t.Errorf("localAppData() = %q, want %q", got, want)
+	}
+	if got, want := appData(), filepath.Join(`C:\Users\me`, "AppData", "Roaming"); got != want {
+		t.Errorf("appData() = %q, want %q", got, want)
+	}
+
+	t.Setenv("USERPROFILE", "")
+	t.Setenv("HOMEDRIVE", "D:")
+	t.Setenv("HOMEPATH", `\Home\me`)
+	if got := userProfile(); got != `D:\Home\me` {
+		t.Errorf("userProfile() = %q, want %q", got, `D:\Home\me`)
+	}
+
+	t.Setenv("HOMEDRIVE", "")
+	t.Setenv("SYSTEMDRIVE", "C:")
+	t.Setenv("USERNAME", "me")
+	if got, want := userProfile(), filepath.Join(`C:\`, "Users", "me"); got != want {
+		t.Errorf("userProfile() = %q, want %q", got, want)
+	}
+
+	t.Setenv("USERNAME", "")
+	if got := localAppData(); got != "" {
+		t.Errorf("localAppData() = %q, want empty without any profile variables", got)
+	}
+}
+
+func TestIsProtectedPath(t *testing.T) {
+	t.Setenv("WINDIR", `C:\Windows`)
+
+	for _, p := range []string{
+		`c:\windows\system32`,
+		`C:/Windows/System32/`,
+		`C:\Windows\System32\drivers\etc`,
+		`C:\WINDOWS\`,
+	} {
+		if !IsProtectedPath(p) {
+			t.Errorf("IsProtectedPath(%q) = false, want true", p)
+		}
+	}
+
+	for _, p := range []string{
+		`C:\Windows2\cache`,
+		`D:\Projects\build`,
+		``,
+	} {
+		if IsProtectedPath(p) {
+			t.Errorf("IsProtectedPath(%q) = true, want false", p)
+		}
+	}
+}
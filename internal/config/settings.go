@@ -0,0 +1,190 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SettingsFileName is the user-editable file holding command defaults.
+const SettingsFileName = "settings.json"
+
+// Delete modes accepted by Settings.DeleteMode.
+const (
+	DeleteModeRecycle   = "recycle"
+	DeleteModePermanent = "permanent"
+)
+
+// cleanCategories lists the values accepted in Settings.CleanCategories.
+var cleanCategories = map[string]bool{
+	"all": true, "user": true, "system": true, "browser": true, "dev": true,
+}
+
+// Settings holds user defaults that commands fall back to when the
+// corresponding flag is not passed. Zero values mean "use the built-in
+// default".
+type Settings struct {
+	// CleanCategories selects what `pw clean` cleans without category flags.
+	CleanCategories []string `json:"clean_categories,omitempty"`
+
+	// AnalyzeExclude lists directory names skipped by `pw analyze`.
+	AnalyzeExclude []string `json:"analyze_exclude,omitempty"`
+
+	// PurgeArtifacts restricts `pw purge` to these artifact directory names.
+	PurgeArtifacts []string `json:"purge_artifacts,omitempty"`
+
+	// StatusRefreshSeconds is the `pw status` refresh interval.
+	StatusRefreshSeconds int `json:"status_refresh_seconds,omitempty"`
+
+	// DeleteMode is "recycle" or "permanent" for analyze and purge deletes.
+	DeleteMode string `json:"delete_mode,omitempty"`
+
+	// NoMouse turns off mouse capture in the analyze and status TUIs.
+	NoMouse bool `json:"no_mouse,omitempty"`
+
+	// Theme names the color theme; see `pw --theme`.
+	Theme string `json:"theme,omitempty"`
+}
+
+// defaultSettingsTemplate is written by `pw config init`. Lines starting
+// with "//" are comments and are stripped before parsing.
+const defaultSettingsTemplate = `// PureWin settings. Command-line flags always take precedence.
+// Lines starting with // are comments. Remove a key to use the built-in default.
+{
+  // Categories cleaned by "pw clean" when no category flag is given:
+  // any of "all", "user", "system", "browser", "dev".
+  "clean_categories": ["all"],
+
+  // Directory names skipped by "pw analyze" (same as --exclude).
+  "analyze_exclude": [],
+
+  // Artifact directories "pw purge" looks for. Empty means all supported
+  // types (node_modules, target, build, dist, .next, venv, ...).
+  "purge_artifacts": [],
+
+  // Refresh interval for "pw status", in seconds.
+  "status_refresh_seconds": 1,
+
+  // How analyze and purge delete: "recycle" (Recycle Bin) or "permanent".
+  // Leave empty to keep each command's default.
+  "delete_mode": "",
+
+  // Set to true if your terminal misbehaves with mouse capture in the
+  // analyze and status screens (same as --no-mouse).
+  "no_mouse": false,
+
+  // Color theme: "charmtone", "high-contrast", "monochrome", or "nord"
+  // (same as --theme). Leave empty for the default.
+  "theme": ""
+}
+`
+
+// SettingsPath returns the location of the settings file.
+func SettingsPath() (string, error) {
+	dir, err := defaultConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, SettingsFileName), nil
+}
+
+// LoadSettings reads the settings file. A missing file yields empty
+// Settings, so every command keeps its built-in defaults.
+func LoadSettings() (*Settings, error) {
+	path, err := SettingsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Settings{}, nil
+		}
+		return nil, fmt.Errorf("failed to read settings %s: %w", path, err)
+	}
+
+	s, err := parseSettings(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse settings %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// parseSettings decodes settings JSON after stripping "//" comment lines,
+// then validates the values.
+func parseSettings(data []byte) (*Settings, error) {
+	var b strings.Builder
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			continue
+		}
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+
+	s := &Settings{}
+	if err := json.Unmarshal([]byte(b.String()), s); err != nil {
+		return nil, err
+	}
+
+	for i, c := range s.CleanCategories {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if !cleanCategories[c] {
+			return nil, fmt.Errorf("unknown clean category %q", c)
+		}
+		s.CleanCategories[i] = c
+	}
+	switch s.DeleteMode {
+	case "", DeleteModeRecycle, DeleteModePermanent:
+	default:
+		return nil, fmt.Errorf("invalid delete_mode %q (use %q or %q)",
+			s.DeleteMode, DeleteModeRecycle, DeleteModePermanent)
+	}
+	if s.StatusRefreshSeconds < 0 {
+		return nil, fmt.Errorf("status_refresh_seconds must not be negative")
+	}
+	return s, nil
+}
+
+// WriteDefaultSettings writes the commented default settings file and
+// returns its path. An existing file is only replaced when force is set.
+func WriteDefaultSettings(force bool) (string, error) {
+	path, err := SettingsPath()
+	if err != nil {
+		return "", err
+	}
+
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			return path, fmt.Errorf("settings file already exists: %s", path)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(defaultSettingsTemplate), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write settings to %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// ChoosesCategories reports whether CleanCategories narrows `pw clean` to
+// particular categories. Nothing set, or "all" as the default template
+// writes, leaves the choice to the user.
+func (s *Settings) ChoosesCategories() bool {
+	return len(s.CleanCategories) > 0 && !s.HasCategory("all")
+}
+
+// HasCategory reports whether CleanCategories includes name.
+func (s *Settings) HasCategory(name string) bool {
+	for _, c := range s.CleanCategories {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
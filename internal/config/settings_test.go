@@ -0,0 +1,60 @@
+package config
+
+import "testing"
+
+func TestParseSettings_DefaultTemplate(t *testing.T) {
+	s, err := parseSettings([]byte(defaultSettingsTemplate))
+	if err != nil {
+		t.Fatalf("default template does not parse: %v", err)
+	}
+	if !s.HasCategory("all") {
+		t.Errorf("CleanCategories = %v, want [all]", s.CleanCategories)
+	}
+	if s.StatusRefreshSeconds != 1 {
+		t.Errorf("StatusRefreshSeconds = %d, want 1", s.StatusRefreshSeconds)
+	}
+	if s.NoMouse {
+		t.Error("NoMouse = true, want mouse enabled by default")
+	}
+}
+
+func TestSettings_ChoosesCategories(t *testing.T) {
+	tests := []struct {
+		categories []string
+		want       bool
+	}{
+		{nil, false},
+		{[]string{"all"}, false}, // the default template's value
+		{[]string{"all", "dev"}, false},
+		{[]string{"user"}, true},
+		{[]string{"browser", "dev"}, true},
+	}
+	for _, tc := range tests {
+		s := &Settings{CleanCategories: tc.categories}
+		if got := s.ChoosesCategories(); got != tc.want {
+			t.Errorf("ChoosesCategories(%v) = %v, want %v", tc.categories, got, tc.want)
+		}
+	}
+
+	s, err := parseSettings([]byte(defaultSettingsTemplate))
+	if err != nil {
+		t.Fatalf("default template does not parse: %v", err)
+	}
+	if s.ChoosesCategories() {
+		t.Error(`clean_categories ["all"] from config init must still open the clean picker`)
+	}
+}
+
+func TestParseSettings_Invalid(t *testing.T) {
+	tests := []string{
+		`{"clean_categories": ["everything"]}`,
+		`{"delete_mode": "shred"}`,
+		`{"status_refresh_seconds": -1}`,
+		`{not json}`,
+	}
+	for _, data := range tests {
+		if _, err := parseSettings([]byte(data)); err == nil {
+			t.Errorf("parseSettings(%s): expected error", data)
+		}
+	}
+}
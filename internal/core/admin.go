@@ -35,6 +35,18 @@ func RequireAdmin(operation string) error {
 // The args parameter should contain the command-line arguments to pass
 // (excluding the --admin flag itself to avoid an infinite re-launch loop).
 func RunElevated(args []string) error {
+	if err := LaunchElevated(args); err != nil {
+		return err
+	}
+
+	// Elevated process launched successfully — exit the current one.
+	os.Exit(0)
+	return nil // unreachable
+}
+
+// LaunchElevated starts the current executable with args and administrator
+// privileges, like RunElevated, but returns so the caller can finish.
+func LaunchElevated(args []string) error {
 	exe, err := os.Executable()
 	if err != nil {
 		return fmt.Errorf("cannot determine executable path: %w", err)
@@ -59,8 +71,5 @@ func RunElevated(args []string) error {
 	if err != nil {
 		return fmt.Errorf("UAC elevation failed: %w", err)
 	}
-
-	// Elevated process launched successfully — exit the current one.
-	os.Exit(0)
-	return nil // unreachable
+	return nil
 }
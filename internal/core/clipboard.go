@@ -0,0 +1,100 @@
+package core
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	modUser32            = windows.NewLazySystemDLL("user32.dll")
+	procOpenClipboard    = modUser32.NewProc("OpenClipboard")
+	procCloseClipboard   = modUser32.NewProc("CloseClipboard")
+	procEmptyClipboard   = modUser32.NewProc("EmptyClipboard")
+	procSetClipboardData = modUser32.NewProc("SetClipboardData")
+
+	modKernel32      = windows.NewLazySystemDLL("kernel32.dll")
+	procGlobalAlloc  = modKernel32.NewProc("GlobalAlloc")
+	procGlobalFree   = modKernel32.NewProc("GlobalFree")
+	procGlobalLock   = modKernel32.NewProc("GlobalLock")
+	procGlobalUnlock = modKernel32.NewProc("GlobalUnlock")
+	procMoveMemory   = modKernel32.NewProc("RtlMoveMemory")
+)
+
+const (
+	cfUnicodeText = 13
+	gmemMoveable  = 0x0002
+)
+
+// CopyToClipboard places s on the Windows clipboard as Unicode text.
+// If the clipboard API fails (e.g. another app holds it open), it falls
+// back to piping the text through clip.exe.
+func CopyToClipboard(s string) error {
+	if err := setClipboardText(s); err != nil {
+		if clipErr := copyViaClipExe(s); clipErr != nil {
+			return fmt.Errorf("cannot copy to clipboard: %w", err)
+		}
+	}
+	return nil
+}
+
+func setClipboardText(s string) error {
+	text, err := windows.UTF16FromString(s)
+	if err != nil {
+		return err
+	}
+
+	if ret, _, err := procOpenClipboard.Call(0); ret == 0 {
+		return fmt.Errorf("OpenClipboard: %w", err)
+	}
+	defer procCloseClipboard.Call()
+
+	if ret, _, err := procEmptyClipboard.Call(); ret == 0 {
+		return fmt.Errorf("EmptyClipboard: %w", err)
+	}
+
+	size := uintptr(len(text)) * unsafe.Sizeof(text[0])
+	hMem, _, err := procGlobalAlloc.Call(gmemMoveable, size)
+	if hMem == 0 {
+		return fmt.Errorf("GlobalAlloc: %w", err)
+	}
+
+	ptr, _, err := procGlobalLock.Call(hMem)
+	if ptr == 0 {
+		procGlobalFree.Call(hMem)
+		return fmt.Errorf("GlobalLock: %w", err)
+	}
+	procMoveMemory.Call(ptr, uintptr(unsafe.Pointer(&text[0])), size)
+	procGlobalUnlock.Call(hMem)
+
+	// On success the system owns hMem; only free it on failure.
+	if ret, _, err := procSetClipboardData.Call(cfUnicodeText, hMem); ret == 0 {
+		procGlobalFree.Call(hMem)
+		return fmt.Errorf("SetClipboardData: %w", err)
+	}
+	return nil
+}
+
+// copyViaClipExe pipes s to clip.exe as UTF-16LE with a BOM so that
+// non-ASCII paths survive the console code page.
+func copyViaClipExe(s string) error {
+	u, err := windows.UTF16FromString(s)
+	if err != nil {
+		return err
+	}
+	u = u[:len(u)-1] // drop the NUL terminator
+
+	var b strings.Builder
+	b.WriteString("\xff\xfe")
+	for _, c := range u {
+		b.WriteByte(byte(c))
+		b.WriteByte(byte(c >> 8))
+	}
+
+	cmd := exec.Command("clip")
+	cmd.Stdin = strings.NewReader(b.String())
+	return cmd.Run()
+}
@@ -29,6 +29,13 @@ func IsWindows11OrAbove() bool {
 	return major >= 10 && build >= 22000
 }
 
+// IsWindowsServer checks if running on a Server edition, i.e. any product
+// type other than VER_NT_WORKSTATION.
+func IsWindowsServer() bool {
+	const verNTWorkstation = 1
+	return windows.RtlGetVersion().ProductType != verNTWorkstation
+}
+
 // WindowsVersionString returns a human-readable Windows version string.
 // Examples: "Windows 10 (Build 19045)", "Windows 11 (Build 22621)"
 func WindowsVersionString() string {
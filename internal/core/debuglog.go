@@ -0,0 +1,172 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel orders the messages written to the debug log.
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the label a level is written with.
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// debugLog is the process-wide log opened by the --log-file flag, or the
+// stderr log of a bare --debug. Until one is started every Log* function
+// is a no-op.
+var debugLog struct {
+	mu       sync.Mutex
+	w        io.Writer
+	file     *os.File // w when it is a log file, closed by CloseDebugLog
+	minLevel LogLevel
+	paused   int      // PauseStderrLog depth; stderr output is held while > 0
+	held     []string // stderr entries written while paused
+}
+
+// OpenDebugLog starts appending log messages to path, beginning with a
+// session line carrying header so runs sharing a file can be told apart.
+// Only warnings and errors are written unless verbose is set, in which
+// case debug and info steps are too. A log that is already open is closed
+// first.
+func OpenDebugLog(path, header string, verbose bool) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("cannot create log directory %s: %w", dir, err)
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot open log file %s: %w", path, err)
+	}
+
+	CloseDebugLog()
+	debugLog.mu.Lock()
+	defer debugLog.mu.Unlock()
+	debugLog.w = file
+	debugLog.file = file
+	debugLog.minLevel = LevelWarn
+	if verbose {
+		debugLog.minLevel = LevelDebug
+	}
+	_, _ = fmt.Fprintf(file, "\n═══ [%s] SESSION START: %s ═══\n", time.Now().Format(logTimeFormat), header)
+	return nil
+}
+
+// LogToStderr writes every log message, debug steps included, to stderr.
+// It is how --debug shows what happened when no --log-file is given.
+func LogToStderr() {
+	CloseDebugLog()
+	debugLog.mu.Lock()
+	defer debugLog.mu.Unlock()
+	debugLog.w = os.Stderr
+	debugLog.minLevel = LevelDebug
+}
+
+// PauseStderrLog holds stderr log messages back while a full-screen TUI
+// owns the screen, and writes them out once it is gone. Calls nest: each
+// PauseStderrLog(true) needs its own PauseStderrLog(false), and the held
+// messages are written when the last one resumes. A log file is
+// unaffected.
+func PauseStderrLog(paused bool) {
+	debugLog.mu.Lock()
+	defer debugLog.mu.Unlock()
+	if paused {
+		debugLog.paused++
+		return
+	}
+	if debugLog.paused == 0 {
+		return
+	}
+	debugLog.paused--
+	if debugLog.paused == 0 {
+		if debugLog.w != nil {
+			_, _ = io.WriteString(debugLog.w, strings.Join(debugLog.held, ""))
+		}
+		debugLog.held = nil
+	}
+}
+
+// CloseDebugLog stops logging, closing the log file if one is open.
+// Stderr messages still held by PauseStderrLog are discarded.
+func CloseDebugLog() {
+	debugLog.mu.Lock()
+	defer debugLog.mu.Unlock()
+	if debugLog.file != nil {
+		_ = debugLog.file.Close()
+		debugLog.file = nil
+	}
+	debugLog.w = nil
+	debugLog.held = nil
+}
+
+// LogDebug records a verbose step, written only with --debug.
+func LogDebug(format string, args ...any) { logf(LevelDebug, format, args...) }
+
+// LogInfo records a notable step, written only with --debug.
+func LogInfo(format string, args ...any) { logf(LevelInfo, format, args...) }
+
+// LogWarn records a problem the operation carried on past.
+func LogWarn(format string, args ...any) { logf(LevelWarn, format, args...) }
+
+// LogError records a failed operation.
+func LogError(format string, args ...any) { logf(LevelError, format, args...) }
+
+// logf writes one timestamped line per message line, so a multi-line
+// message still reads as a single entry.
+func logf(level LogLevel, format string, args ...any) {
+	debugLog.mu.Lock()
+	defer debugLog.mu.Unlock()
+	if debugLog.w == nil || level < debugLog.minLevel {
+		return
+	}
+	msg := strings.TrimRight(fmt.Sprintf(format, args...), "\n")
+	prefix := fmt.Sprintf("%s %-5s ", time.Now().Format(logTimeFormat), level)
+	var b strings.Builder
+	for i, line := range strings.Split(msg, "\n") {
+		if i > 0 {
+			prefix = strings.Repeat(" ", len(prefix))
+		}
+		b.WriteString(prefix + line + "\n")
+	}
+	if debugLog.paused > 0 && debugLog.file == nil {
+		debugLog.held = append(debugLog.held, b.String())
+		return
+	}
+	_, _ = io.WriteString(debugLog.w, b.String())
+}
+
+// logDelete records the outcome of a delete: failures always, successes
+// and dry-run sizes only in verbose logs.
+func logDelete(op, path string, size int64, dryRun bool, err error) {
+	switch {
+	case err != nil:
+		LogError("%s failed: %v", op, err)
+	case dryRun:
+		LogDebug("%s (dry run) %s: %s", op, path, FormatSize(size))
+	default:
+		LogDebug("%s %s: %s", op, path, FormatSize(size))
+	}
+}
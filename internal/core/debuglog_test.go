@@ -0,0 +1,93 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDebugLog_Levels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "pw.log")
+	defer CloseDebugLog()
+
+	LogError("before open") // no log yet: dropped
+
+	if err := OpenDebugLog(path, "pw clean", false); err != nil {
+		t.Fatalf("OpenDebugLog() error: %v", err)
+	}
+	LogDebug("quiet step")
+	LogInfo("quiet info")
+	LogWarn("disk nearly full")
+	LogError("delete failed:\nsecond line")
+
+	if err := OpenDebugLog(path, "pw clean --debug", true); err != nil {
+		t.Fatalf("OpenDebugLog() verbose error: %v", err)
+	}
+	LogDebug("verbose step")
+	CloseDebugLog()
+	LogError("after close") // dropped
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	for _, want := range []string{"SESSION START: pw clean", "WARN  disk nearly full",
+		"ERROR delete failed:", "second line", "SESSION START: pw clean --debug", "DEBUG verbose step"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log lacks %q:\n%s", want, log)
+		}
+	}
+	for _, unwanted := range []string{"before open", "quiet step", "quiet info", "after close"} {
+		if strings.Contains(log, unwanted) {
+			t.Errorf("log contains %q:\n%s", unwanted, log)
+		}
+	}
+}
+
+func TestDebugLog_StderrPause(t *testing.T) {
+	stderr, err := os.Create(filepath.Join(t.TempDir(), "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stderr.Close()
+	orig := os.Stderr
+	os.Stderr = stderr
+	defer func() { os.Stderr = orig }()
+	defer CloseDebugLog()
+
+	LogToStderr()
+	LogDebug("shown")
+	PauseStderrLog(true)
+	LogError("held while paused")
+	PauseStderrLog(true) // nested, as when one TUI launches another
+	LogWarn("held while nested")
+	PauseStderrLog(false)
+	mid, err := os.ReadFile(stderr.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(mid), "held") {
+		t.Errorf("stderr log written before the outer pause ended:\n%s", mid)
+	}
+	PauseStderrLog(false)
+	PauseStderrLog(false) // unbalanced resume: ignored
+	LogInfo("shown again")
+	CloseDebugLog()
+
+	data, err := os.ReadFile(stderr.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	order := []string{"DEBUG shown", "ERROR held while paused", "WARN  held while nested", "INFO  shown again"}
+	last := -1
+	for _, want := range order {
+		i := strings.Index(log, want)
+		if i < 0 || i < last {
+			t.Errorf("stderr log lacks %q after the previous lines:\n%s", want, log)
+		}
+		last = i
+	}
+}
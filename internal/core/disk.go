@@ -0,0 +1,44 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// GetDiskFreeSpace returns the bytes available to the current user on the
+// volume containing path (e.g. "C:" or `D:\data`), via GetDiskFreeSpaceExW.
+func GetDiskFreeSpace(path string) (uint64, error) {
+	root := path
+	if !strings.HasSuffix(root, `\`) {
+		root += `\`
+	}
+
+	p, err := windows.UTF16PtrFromString(root)
+	if err != nil {
+		return 0, fmt.Errorf("invalid path %s: %w", path, err)
+	}
+
+	var free, total, totalFree uint64
+	if err := windows.GetDiskFreeSpaceEx(p, &free, &total, &totalFree); err != nil {
+		return 0, fmt.Errorf("cannot query free space on %s: %w", path, err)
+	}
+	return free, nil
+}
+
+// MountedDrives returns the drive letters (e.g. "C:") reported by
+// GetLogicalDrives, in alphabetical order.
+func MountedDrives() ([]string, error) {
+	mask, err := windows.GetLogicalDrives()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list drives: %w", err)
+	}
+	var drives []string
+	for i := 0; i < 26; i++ {
+		if mask&(1<<uint(i)) != 0 {
+			drives = append(drives, string(rune('A'+i))+":")
+		}
+	}
+	return drives, nil
+}
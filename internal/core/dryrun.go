@@ -60,66 +60,41 @@ func (d *DryRunContext) Count() int {
 	return len(d.Items)
 }
 
-// categorySummary groups items by category and calculates totals.
-func (d *DryRunContext) categorySummary() map[string]struct {
-	count int
-	size  int64
-} {
-	summary := make(map[string]struct {
-		count int
-		size  int64
-	})
-	for _, item := range d.Items {
-		entry := summary[item.Category]
-		entry.count++
-		entry.size += item.Size
-		summary[item.Category] = entry
-	}
-	return summary
+// CategoryTotal is the number and size of the items in one category.
+type CategoryTotal struct {
+	Category string
+	Count    int
+	Size     int64
 }
 
-// PrintSummary prints a categorized summary of what would be deleted.
-func (d *DryRunContext) PrintSummary() {
+// CategoryTotals groups items by category, largest first.
+func (d *DryRunContext) CategoryTotals() []CategoryTotal {
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	return d.categoryTotals()
+}
 
-	if len(d.Items) == 0 {
-		fmt.Println("  Nothing to clean.")
-		return
-	}
-
-	fmt.Println()
-	fmt.Println("  ╔══════════════════════════════════════════╗")
-	fmt.Println("  ║           DRY RUN — No files deleted     ║")
-	fmt.Println("  ╚══════════════════════════════════════════╝")
-	fmt.Println()
-
-	summary := d.categorySummary()
-
-	// Sort categories for stable output.
-	cats := make([]string, 0, len(summary))
-	for cat := range summary {
-		cats = append(cats, cat)
-	}
-	sort.Strings(cats)
-
-	for _, cat := range cats {
-		entry := summary[cat]
-		fmt.Printf("  %-20s  %5d items  %10s\n",
-			strings.ToUpper(cat),
-			entry.count,
-			FormatSize(entry.size),
-		)
+// categoryTotals is CategoryTotals for callers already holding the lock.
+func (d *DryRunContext) categoryTotals() []CategoryTotal {
+	index := make(map[string]int)
+	var totals []CategoryTotal
+	for _, item := range d.Items {
+		i, ok := index[item.Category]
+		if !ok {
+			i = len(totals)
+			index[item.Category] = i
+			totals = append(totals, CategoryTotal{Category: item.Category})
+		}
+		totals[i].Count++
+		totals[i].Size += item.Size
 	}
-
-	fmt.Println("  ──────────────────────────────────────────")
-	fmt.Printf("  %-20s  %5d items  %10s\n",
-		"TOTAL",
-		len(d.Items),
-		FormatSize(d.TotalSizeUnlocked()),
-	)
-	fmt.Println()
-	fmt.Println("  Run without --dry-run to execute cleanup.")
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Size != totals[j].Size {
+			return totals[i].Size > totals[j].Size
+		}
+		return totals[i].Category < totals[j].Category
+	})
+	return totals
 }
 
 // TotalSizeUnlocked calculates total size without acquiring the lock.
@@ -147,24 +122,16 @@ func (d *DryRunContext) ExportToFile(path string) error {
 	sb.WriteString(fmt.Sprintf("PureWin Dry Run Report — %s\n", time.Now().Format("2006-01-02 15:04:05")))
 	sb.WriteString(strings.Repeat("=", 60) + "\n\n")
 
-	summary := d.categorySummary()
-	cats := make([]string, 0, len(summary))
-	for cat := range summary {
-		cats = append(cats, cat)
-	}
-	sort.Strings(cats)
-
 	// Group items by category.
 	grouped := make(map[string][]DryRunItem)
 	for _, item := range d.Items {
 		grouped[item.Category] = append(grouped[item.Category], item)
 	}
 
-	for _, cat := range cats {
-		entry := summary[cat]
+	for _, t := range d.categoryTotals() {
 		sb.WriteString(fmt.Sprintf("[%s] — %d items, %s\n",
-			strings.ToUpper(cat), entry.count, FormatSize(entry.size)))
-		for _, item := range grouped[cat] {
+			strings.ToUpper(t.Category), t.Count, FormatSize(t.Size)))
+		for _, item := range grouped[t.Category] {
 			sb.WriteString(fmt.Sprintf("  %10s  %s\n", FormatSize(item.Size), item.Path))
 		}
 		sb.WriteString("\n")
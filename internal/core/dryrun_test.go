@@ -0,0 +1,32 @@
+package core
+
+import "testing"
+
+func TestDryRunContext_CategoryTotals(t *testing.T) {
+	drc := NewDryRunContext()
+	drc.Add(`C:\a`, 100, "user")
+	drc.Add(`C:\b`, 500, "dev")
+	drc.Add(`C:\c`, 50, "user")
+	drc.Add(`C:\d`, 150, "system")
+
+	got := drc.CategoryTotals()
+	want := []CategoryTotal{
+		{Category: "dev", Count: 1, Size: 500},
+		{Category: "system", Count: 1, Size: 150},
+		{Category: "user", Count: 2, Size: 150},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("CategoryTotals() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("CategoryTotals()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDryRunContext_CategoryTotalsEmpty(t *testing.T) {
+	if got := NewDryRunContext().CategoryTotals(); len(got) != 0 {
+		t.Errorf("CategoryTotals() on empty context = %+v, want none", got)
+	}
+}
@@ -3,8 +3,11 @@ package core
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/sys/windows"
@@ -41,29 +44,104 @@ func isAccessDenied(err error) bool {
 	return os.IsPermission(err)
 }
 
+// longPathPrefix makes Win32 file APIs accept paths past MAX_PATH.
+const longPathPrefix = `\\?\`
+
+// longPathThreshold is where LongPath starts prefixing: MAX_PATH (260) less
+// room for an 8.3 name, the limit for creating directories.
+const longPathThreshold = 248
+
+// LongPath returns path in the \\?\ form that Windows file APIs accept
+// beyond MAX_PATH. Short paths and paths already in that form are returned
+// unchanged; UNC shares become \\?\UNC\server\share.
+func LongPath(path string) string {
+	if len(path) < longPathThreshold || strings.HasPrefix(path, longPathPrefix) {
+		return path
+	}
+	// The prefix turns off path normalization, so the path must already be
+	// absolute and clean.
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if strings.HasPrefix(path, `\\`) {
+		return longPathPrefix + `UNC\` + path[2:]
+	}
+	return longPathPrefix + path
+}
+
+// StripLongPath undoes LongPath so paths are shown to users as they know
+// them.
+func StripLongPath(path string) string {
+	switch {
+	case strings.HasPrefix(path, longPathPrefix+`UNC\`):
+		return `\\` + path[len(longPathPrefix)+4:]
+	case strings.HasPrefix(path, longPathPrefix):
+		return path[len(longPathPrefix):]
+	}
+	return path
+}
+
+// stripPathError rewrites the path in a *fs.PathError with StripLongPath,
+// keeping the underlying error for errors.Is/As.
+func stripPathError(err error) error {
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		return &fs.PathError{Op: pe.Op, Path: StripLongPath(pe.Path), Err: pe.Err}
+	}
+	return err
+}
+
 // SafeDelete removes a file or directory after safety validation.
 // In dryRun mode, it calculates and returns the size without deleting.
 // It retries up to 3 times with exponential backoff for locked files.
+// Paths past MAX_PATH are handled via LongPath; errors show the plain path.
 // Returns the number of bytes freed (or that would be freed).
 func SafeDelete(path string, dryRun bool) (int64, error) {
-	// Validate path through safety checks.
+	return SafeDeleteWithProgress(path, dryRun, nil)
+}
+
+// DeleteProgress is a snapshot of a running SafeDeleteWithProgress.
+type DeleteProgress struct {
+	BytesFreed   int64 // bytes removed so far
+	BytesTotal   int64 // size measured before deletion started
+	FilesRemoved int64 // files removed so far
+}
+
+// progressInterval throttles DeleteProgress callbacks.
+const progressInterval = 100 * time.Millisecond
+
+// SafeDeleteWithProgress is SafeDelete that removes a directory file by
+// file, calling progress (when non-nil) at most every progressInterval and
+// once more at the end. Files that fail during the walk are left for the
+// final retrying removal, so errors are reported exactly as SafeDelete does.
+func SafeDeleteWithProgress(path string, dryRun bool, progress func(DeleteProgress)) (int64, error) {
+	freed, err := safeDelete(path, dryRun, progress)
+	logDelete("delete", StripLongPath(path), freed, dryRun, err)
+	return freed, err
+}
+
+func safeDelete(path string, dryRun bool, progress func(DeleteProgress)) (int64, error) {
+	// Validate the plain form, so a \\?\ prefix can't dodge the checks,
+	// then operate on the long form.
+	path = StripLongPath(path)
 	if err := ValidatePath(path); err != nil {
 		return 0, fmt.Errorf("safety check failed for %s: %w", path, err)
 	}
+	fsPath := LongPath(path)
 
 	// Check if path exists.
-	info, err := os.Lstat(path)
+	info, err := os.Lstat(fsPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return 0, nil // Nothing to delete.
 		}
-		return 0, fmt.Errorf("cannot stat %s: %w", path, err)
+		return 0, fmt.Errorf("cannot stat %s: %w", path, stripPathError(err))
 	}
 
 	// Calculate size.
 	var size int64
 	if info.IsDir() {
-		size, err = GetDirSize(path)
+		size, err = GetDirSize(fsPath)
 		if err != nil {
 			// Non-fatal: we can still attempt deletion.
 			size = 0
@@ -76,6 +154,10 @@ func SafeDelete(path string, dryRun bool) (int64, error) {
 		return size, nil
 	}
 
+	if info.IsDir() && progress != nil {
+		deleteTreeFiles(fsPath, size, progress)
+	}
+
 	// Attempt deletion with retry.
 	var lastErr error
 	for attempt := 0; attempt < maxRetries; attempt++ {
@@ -85,9 +167,9 @@ func SafeDelete(path string, dryRun bool) (int64, error) {
 		}
 
 		if info.IsDir() {
-			lastErr = os.RemoveAll(path)
+			lastErr = os.RemoveAll(fsPath)
 		} else {
-			lastErr = os.Remove(path)
+			lastErr = os.Remove(fsPath)
 		}
 
 		if lastErr == nil {
@@ -101,7 +183,7 @@ func SafeDelete(path string, dryRun bool) (int64, error) {
 
 		// For access denied, try removing read-only attribute and retry.
 		if isAccessDenied(lastErr) && !info.IsDir() {
-			_ = os.Chmod(path, 0o666)
+			_ = os.Chmod(fsPath, 0o666)
 			continue
 		}
 
@@ -109,7 +191,117 @@ func SafeDelete(path string, dryRun bool) (int64, error) {
 		break
 	}
 
-	return 0, fmt.Errorf("failed to delete %s after %d attempts: %w", path, maxRetries, lastErr)
+	return 0, fmt.Errorf("failed to delete %s after %d attempts: %w", path, maxRetries, stripPathError(lastErr))
+}
+
+// deleteTreeFiles removes the files under dir, reporting progress as it
+// goes. Directories are left for the caller's RemoveAll. WalkDir never
+// follows symlinks or junctions; those are removed as links.
+func deleteTreeFiles(dir string, total int64, progress func(DeleteProgress)) {
+	p := DeleteProgress{BytesTotal: total}
+	last := time.Now()
+
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		var fileSize int64
+		if info, infoErr := d.Info(); infoErr == nil {
+			fileSize = info.Size()
+		}
+		if os.Remove(path) != nil {
+			return nil
+		}
+		p.BytesFreed += fileSize
+		p.FilesRemoved++
+		if time.Since(last) >= progressInterval {
+			progress(p)
+			last = time.Now()
+		}
+		return nil
+	})
+	progress(p)
+}
+
+// IsLockedError reports whether err (possibly wrapped) is a sharing or lock
+// violation — i.e. the file is held open by another process.
+func IsLockedError(err error) bool {
+	return isRetryableError(err)
+}
+
+// IsFileLocked reports whether another process holds path open in a way
+// that stops it being deleted right now. It opens the file for DELETE
+// access while sharing everything, which fails with a sharing violation
+// only when some other handle refused delete sharing. Nothing is modified.
+func IsFileLocked(path string) bool {
+	p, err := windows.UTF16PtrFromString(LongPath(path))
+	if err != nil {
+		return false
+	}
+	h, err := windows.CreateFile(p, windows.DELETE,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, windows.FILE_FLAG_OPEN_REPARSE_POINT, 0)
+	if err != nil {
+		return IsLockedError(err)
+	}
+	windows.CloseHandle(h)
+	return false
+}
+
+// ScheduleDeleteOnReboot registers path for deletion the next time Windows
+// starts, via MoveFileExW with MOVEFILE_DELAY_UNTIL_REBOOT. Used as a fallback
+// for files that stay locked. Requires administrator privileges.
+//
+// Windows only removes empty directories at boot and processes the queue in
+// order, so for a directory every file is queued first, deepest first, then
+// each directory after its contents.
+func ScheduleDeleteOnReboot(path string) error {
+	path = StripLongPath(path)
+	if err := ValidatePath(path); err != nil {
+		return fmt.Errorf("safety check failed for %s: %w", path, err)
+	}
+
+	fsPath := LongPath(path)
+
+	info, err := os.Lstat(fsPath)
+	if err != nil {
+		return fmt.Errorf("cannot schedule %s for deletion on reboot: %w", path, stripPathError(err))
+	}
+	if !info.IsDir() {
+		return scheduleOneOnReboot(fsPath)
+	}
+
+	// WalkDir lists each directory before its contents, so walking the
+	// list backwards queues children ahead of their parents.
+	var paths []string
+	walkErr := filepath.WalkDir(fsPath, func(p string, _ os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		paths = append(paths, p)
+		return nil
+	})
+	if walkErr != nil {
+		return fmt.Errorf("cannot schedule %s for deletion on reboot: %w", path, stripPathError(walkErr))
+	}
+	for i := len(paths) - 1; i >= 0; i-- {
+		if err := scheduleOneOnReboot(paths[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scheduleOneOnReboot queues a single file or empty-at-boot directory.
+func scheduleOneOnReboot(path string) error {
+	from, err := windows.UTF16PtrFromString(LongPath(path))
+	if err != nil {
+		return fmt.Errorf("invalid path %s: %w", StripLongPath(path), err)
+	}
+	if err := windows.MoveFileEx(from, nil, windows.MOVEFILE_DELAY_UNTIL_REBOOT); err != nil {
+		return fmt.Errorf("cannot schedule %s for deletion on reboot: %w", StripLongPath(path), err)
+	}
+	return nil
 }
 
 // SafeDeleteWithWhitelist removes a file or directory after checking
@@ -204,25 +396,123 @@ func GetFileSize(path string) (int64, error) {
 	return info.Size(), nil
 }
 
-// FormatSize returns a human-readable representation of a byte count.
+// SizeFormat controls how FormatSizeOpts renders a byte count.
+type SizeFormat struct {
+	// Decimal uses SI units (1 KB = 1000 B), as Windows Explorer reports
+	// drive capacity. Otherwise units are binary (1 KB = 1024 B).
+	Decimal bool
+
+	// Precision is the number of digits after the decimal point.
+	Precision int
+
+	// Pad right-aligns the number and unit to a fixed width so columns line up.
+	Pad bool
+}
+
+// FormatSize returns a human-readable representation of a byte count
+// using binary units and two decimal places (e.g. "1.50 MB").
 func FormatSize(bytes int64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-		TB = GB * 1024
-	)
+	return FormatSizeOpts(bytes, SizeFormat{Precision: 2})
+}
 
-	switch {
-	case bytes >= TB:
-		return fmt.Sprintf("%.2f TB", float64(bytes)/float64(TB))
-	case bytes >= GB:
-		return fmt.Sprintf("%.2f GB", float64(bytes)/float64(GB))
-	case bytes >= MB:
-		return fmt.Sprintf("%.2f MB", float64(bytes)/float64(MB))
-	case bytes >= KB:
-		return fmt.Sprintf("%.2f KB", float64(bytes)/float64(KB))
-	default:
-		return fmt.Sprintf("%d B", bytes)
+// FormatSizeOpts returns a human-readable representation of a byte count
+// formatted according to opts.
+func FormatSizeOpts(bytes int64, opts SizeFormat) string {
+	var base float64 = 1024
+	if opts.Decimal {
+		base = 1000
+	}
+
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	value := float64(bytes)
+	unit := 0
+	for unit < len(units)-1 && (value >= base || value <= -base) {
+		value /= base
+		unit++
+	}
+
+	if unit == 0 {
+		if opts.Pad {
+			return fmt.Sprintf("%*d %-2s", opts.Precision+5, bytes, units[0])
+		}
+		return fmt.Sprintf("%d %s", bytes, units[0])
+	}
+	if opts.Pad {
+		return fmt.Sprintf("%*.*f %-2s", opts.Precision+5, opts.Precision, value, units[unit])
 	}
+	return fmt.Sprintf("%.*f %s", opts.Precision, value, units[unit])
+}
+
+// ParseSize parses a human-readable size such as "100MB", "1.5 GiB", or
+// "512" (bytes) into a byte count. Units are case-insensitive and binary
+// (powers of 1024), matching FormatSize: "100 MB" as printed parses back
+// to the same size. KB/MB/GB/TB, their IEC forms KiB/MiB/GiB/TiB, and the
+// single letters K/M/G/T are all accepted.
+func ParseSize(s string) (int64, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	// Split into numeric prefix and unit suffix.
+	i := 0
+	for i < len(trimmed) && (trimmed[i] >= '0' && trimmed[i] <= '9' || trimmed[i] == '.') {
+		i++
+	}
+	numStr := trimmed[:i]
+	unit := strings.ToUpper(strings.TrimSpace(trimmed[i:]))
+
+	if numStr == "" {
+		return 0, fmt.Errorf("invalid size %q: no number found", s)
+	}
+	value, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+
+	const kibi = 1024
+	multipliers := map[string]float64{
+		"": 1, "B": 1,
+		"KB": kibi, "MB": kibi * kibi, "GB": kibi * kibi * kibi, "TB": kibi * kibi * kibi * kibi,
+		"KIB": kibi, "MIB": kibi * kibi, "GIB": kibi * kibi * kibi, "TIB": kibi * kibi * kibi * kibi,
+		"K": kibi, "M": kibi * kibi, "G": kibi * kibi * kibi, "T": kibi * kibi * kibi * kibi,
+	}
+	multiplier, ok := multipliers[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q (use B, KB, MB, GB, TB or KiB, MiB, GiB, TiB)", s, trimmed[i:])
+	}
+
+	return int64(value * multiplier), nil
+}
+
+// ParseAge parses a human-readable age such as "90d", "8w", "12mo", or "1y"
+// into a duration. Months count as 30 days and years as 365 days.
+func ParseAge(s string) (time.Duration, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(s))
+	if trimmed == "" {
+		return 0, fmt.Errorf("empty age")
+	}
+
+	i := 0
+	for i < len(trimmed) && trimmed[i] >= '0' && trimmed[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return 0, fmt.Errorf("invalid age %q: no number found", s)
+	}
+	n, err := strconv.Atoi(trimmed[:i])
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q: %w", s, err)
+	}
+
+	const day = 24 * time.Hour
+	units := map[string]time.Duration{
+		"d": day, "w": 7 * day, "mo": 30 * day, "y": 365 * day,
+	}
+	unit, ok := units[strings.TrimSpace(trimmed[i:])]
+	if !ok {
+		return 0, fmt.Errorf("invalid age %q: unknown unit %q (use d, w, mo, or y)", s, trimmed[i:])
+	}
+
+	return time.Duration(n) * unit, nil
 }
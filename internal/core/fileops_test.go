@@ -3,8 +3,10 @@ package core
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
 // unprotectedTempDir creates a temporary directory that passes IsSafePath.
@@ -126,6 +128,202 @@ func TestSafeDelete_NonExistentPath(t *testing.T) {
 	}
 }
 
+func TestSafeDelete_LongPath(t *testing.T) {
+	// Elsewhere the \\?\ prefix is just part of a relative name, so the
+	// fixture would land in the package directory.
+	if runtime.GOOS != "windows" {
+		t.Skip("long-path prefixes only apply on Windows")
+	}
+	dir := unprotectedTempDir(t)
+	top := filepath.Join(dir, "deep")
+	deep := top
+	for len(deep) <= 300 {
+		deep = filepath.Join(deep, strings.Repeat("d", 40))
+	}
+	if err := os.MkdirAll(LongPath(deep), 0o755); err != nil {
+		t.Fatalf("cannot create deep tree: %v", err)
+	}
+	fpath := filepath.Join(deep, "deleteme.tmp")
+	if err := os.WriteFile(LongPath(fpath), []byte("too deep"), 0o644); err != nil {
+		t.Fatalf("cannot create deep file: %v", err)
+	}
+
+	size, err := SafeDelete(fpath, false)
+	if err != nil {
+		t.Fatalf("SafeDelete should delete a %d-char path, got: %v", len(fpath), err)
+	}
+	if size == 0 {
+		t.Error("SafeDelete should return non-zero bytes freed")
+	}
+	if _, statErr := os.Stat(LongPath(fpath)); !os.IsNotExist(statErr) {
+		t.Fatal("deep file still exists after SafeDelete")
+	}
+
+	if _, err := SafeDelete(top, false); err != nil {
+		t.Fatalf("SafeDelete should remove a tree with deep paths, got: %v", err)
+	}
+	if _, statErr := os.Stat(top); !os.IsNotExist(statErr) {
+		t.Fatal("deep tree still exists after SafeDelete")
+	}
+}
+
+func TestSafeDelete_ErrorsShowPlainPath(t *testing.T) {
+	_, err := SafeDelete(`\\?\C:\Windows\System32`, false)
+	if err == nil {
+		t.Fatal(`a \\?\ prefix must not get past the safety check`)
+	}
+	if strings.Contains(err.Error(), `\\?\`) {
+		t.Errorf("error should show the plain path, got: %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// SafeDeleteWithProgress tests
+// ---------------------------------------------------------------------------
+
+// makeTree writes a small nested tree under dir and returns its root and
+// total size.
+func makeTree(t *testing.T, dir string) (string, int64) {
+	t.Helper()
+	root := filepath.Join(dir, "tree")
+	files := map[string]int{
+		"a.tmp":                                 100,
+		filepath.Join("sub", "b.tmp"):           200,
+		filepath.Join("sub", "deeper", "c.tmp"): 300,
+	}
+	var total int64
+	for name, size := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("cannot create %s: %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte(strings.Repeat("x", size)), 0o644); err != nil {
+			t.Fatalf("cannot create %s: %v", p, err)
+		}
+		total += int64(size)
+	}
+	return root, total
+}
+
+func TestSafeDeleteWithProgress_ReportsFinalTotals(t *testing.T) {
+	root, total := makeTree(t, unprotectedTempDir(t))
+
+	var calls int
+	var last DeleteProgress
+	freed, err := SafeDeleteWithProgress(root, false, func(p DeleteProgress) {
+		calls++
+		last = p
+	})
+	if err != nil {
+		t.Fatalf("SafeDeleteWithProgress: %v", err)
+	}
+	if freed != total {
+		t.Errorf("freed = %d, want %d", freed, total)
+	}
+	if calls == 0 {
+		t.Fatal("progress was never called")
+	}
+	if last.BytesTotal != total || last.BytesFreed != last.BytesTotal {
+		t.Errorf("final progress = %+v, want BytesFreed == BytesTotal == %d", last, total)
+	}
+	if last.FilesRemoved != 3 {
+		t.Errorf("final FilesRemoved = %d, want 3", last.FilesRemoved)
+	}
+	if _, statErr := os.Stat(root); !os.IsNotExist(statErr) {
+		t.Fatal("tree still exists after SafeDeleteWithProgress")
+	}
+}
+
+func TestSafeDeleteWithProgress_NilCallback(t *testing.T) {
+	root, total := makeTree(t, unprotectedTempDir(t))
+
+	freed, err := SafeDeleteWithProgress(root, false, nil)
+	if err != nil {
+		t.Fatalf("SafeDeleteWithProgress(nil): %v", err)
+	}
+	if freed != total {
+		t.Errorf("freed = %d, want %d", freed, total)
+	}
+	if _, statErr := os.Stat(root); !os.IsNotExist(statErr) {
+		t.Fatal("tree still exists after SafeDeleteWithProgress")
+	}
+}
+
+func TestSafeDeleteWithProgress_DryRunDoesNotDelete(t *testing.T) {
+	root, total := makeTree(t, unprotectedTempDir(t))
+
+	var calls int
+	size, err := SafeDeleteWithProgress(root, true, func(DeleteProgress) { calls++ })
+	if err != nil {
+		t.Fatalf("SafeDeleteWithProgress(dryRun=true): %v", err)
+	}
+	if size != total {
+		t.Errorf("size = %d, want %d", size, total)
+	}
+	if calls != 0 {
+		t.Errorf("dry run reported progress %d times, want none", calls)
+	}
+	if _, statErr := os.Stat(filepath.Join(root, "sub", "deeper", "c.tmp")); statErr != nil {
+		t.Fatal("file was deleted during dry run — SAFETY VIOLATION")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// LongPath tests
+// ---------------------------------------------------------------------------
+
+func TestLongPath(t *testing.T) {
+	long := `C:\` + strings.Repeat(`abcdefghij\`, 30) + "file.txt"
+	share := `\\server\share\` + strings.Repeat(`abcdefghij\`, 30) + "file.txt"
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{`C:\Temp\file.txt`, `C:\Temp\file.txt`},
+		{long, `\\?\` + long},
+		{`\\?\` + long, `\\?\` + long},
+		{share, `\\?\UNC\` + share[2:]},
+	} {
+		got := LongPath(tc.in)
+		if got != tc.want {
+			t.Errorf("LongPath(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+		if back := StripLongPath(got); back != StripLongPath(tc.in) {
+			t.Errorf("StripLongPath(%q) = %q, want the plain path", got, back)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// IsFileLocked tests
+// ---------------------------------------------------------------------------
+
+func TestIsFileLocked(t *testing.T) {
+	fpath := filepath.Join(t.TempDir(), "in-use.tmp")
+	if err := os.WriteFile(fpath, []byte("data"), 0o644); err != nil {
+		t.Fatalf("cannot create test file: %v", err)
+	}
+	if IsFileLocked(fpath) {
+		t.Fatal("closed file reported as locked")
+	}
+
+	// Go opens files without delete sharing, which blocks deletion.
+	f, err := os.Open(fpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsFileLocked(fpath) {
+		t.Error("file held open without delete sharing not reported as locked")
+	}
+	f.Close()
+
+	if IsFileLocked(fpath) {
+		t.Error("file still reported as locked after it was closed")
+	}
+	if IsFileLocked(filepath.Join(t.TempDir(), "missing.tmp")) {
+		t.Error("missing file reported as locked")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // SafeDeleteWithWhitelist tests
 // ---------------------------------------------------------------------------
@@ -178,3 +376,107 @@ func TestFormatSize(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatSizeOpts(t *testing.T) {
+	tests := []struct {
+		bytes    int64
+		opts     SizeFormat
+		expected string
+	}{
+		// Binary boundaries.
+		{1023, SizeFormat{Precision: 2}, "1023 B"},
+		{1024, SizeFormat{Precision: 2}, "1.00 KB"},
+		{1099511627776, SizeFormat{Precision: 2}, "1.00 TB"},
+		// Decimal boundaries.
+		{999, SizeFormat{Decimal: true, Precision: 1}, "999 B"},
+		{1000, SizeFormat{Decimal: true, Precision: 1}, "1.0 KB"},
+		{1024, SizeFormat{Decimal: true, Precision: 2}, "1.02 KB"},
+		{1099511627776, SizeFormat{Decimal: true, Precision: 2}, "1.10 TB"},
+		// Precision and padding.
+		{1536, SizeFormat{Precision: 0}, "2 KB"},
+		{1536, SizeFormat{Precision: 1, Pad: true}, "   1.5 KB"},
+		{512, SizeFormat{Precision: 1, Pad: true}, "   512 B "},
+	}
+	for _, tc := range tests {
+		got := FormatSizeOpts(tc.bytes, tc.opts)
+		if got != tc.expected {
+			t.Errorf("FormatSizeOpts(%d, %+v) = %q, want %q", tc.bytes, tc.opts, got, tc.expected)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ParseSize tests
+// ---------------------------------------------------------------------------
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"512", 512},
+		{"512B", 512},
+		{"1KB", 1024},
+		{"1kib", 1024},
+		{"100MB", 100 * 1024 * 1024},
+		{"100MiB", 100 * 1024 * 1024},
+		{"1.5 GiB", 1536 * 1024 * 1024},
+		{"2gb", 2 * 1024 * 1024 * 1024},
+		{"1TiB", 1 << 40},
+		{"10M", 10 * 1024 * 1024},
+	}
+	for _, tc := range tests {
+		got, err := ParseSize(tc.input)
+		if err != nil {
+			t.Errorf("ParseSize(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if got != tc.expected {
+			t.Errorf("ParseSize(%q) = %d, want %d", tc.input, got, tc.expected)
+		}
+	}
+
+	// What FormatSize prints must parse back to the same size.
+	for _, size := range []int64{100 * 1024 * 1024, 3 * 1024 * 1024 * 1024} {
+		if got, err := ParseSize(FormatSize(size)); err != nil || got != size {
+			t.Errorf("ParseSize(FormatSize(%d)) = %d, %v", size, got, err)
+		}
+	}
+
+	for _, bad := range []string{"", "MB", "10XB", "1.2.3GB", "abc"} {
+		if _, err := ParseSize(bad); err == nil {
+			t.Errorf("ParseSize(%q) expected error, got nil", bad)
+		}
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"90d", 90 * day},
+		{"8w", 56 * day},
+		{"12mo", 360 * day},
+		{"6MO", 180 * day},
+		{"1y", 365 * day},
+		{" 2 y", 730 * day},
+	}
+	for _, tc := range tests {
+		got, err := ParseAge(tc.input)
+		if err != nil {
+			t.Errorf("ParseAge(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if got != tc.expected {
+			t.Errorf("ParseAge(%q) = %v, want %v", tc.input, got, tc.expected)
+		}
+	}
+
+	for _, bad := range []string{"", "mo", "12", "12m", "1.5y", "-3d"} {
+		if _, err := ParseAge(bad); err == nil {
+			t.Errorf("ParseAge(%q) expected error, got nil", bad)
+		}
+	}
+}
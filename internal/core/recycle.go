@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	// Shell32 is shell32.dll, shared by every package that calls into the
+	// shell so it is loaded through one lazy handle.
+	Shell32              = windows.NewLazySystemDLL("shell32.dll")
+	procSHFileOperationW = Shell32.NewProc("SHFileOperationW")
+)
+
+// SHFileOperationW constants.
+const (
+	foDelete          = 0x0003
+	fofSilent         = 0x0004
+	fofNoConfirmation = 0x0010
+	fofAllowUndo      = 0x0040
+	fofNoErrorUI      = 0x0400
+)
+
+// SafeDeleteToRecycleBin moves a file or directory to the Recycle Bin after
+// safety validation, so the deletion can be undone from Explorer.
+// In dryRun mode, it calculates and returns the size without moving.
+// Returns the number of bytes moved (or that would be moved).
+func SafeDeleteToRecycleBin(path string, dryRun bool) (int64, error) {
+	moved, err := recycle(path, dryRun)
+	logDelete("recycle", path, moved, dryRun, err)
+	return moved, err
+}
+
+func recycle(path string, dryRun bool) (int64, error) {
+	if err := ValidatePath(path); err != nil {
+		return 0, fmt.Errorf("safety check failed for %s: %w", path, err)
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil // Nothing to delete.
+		}
+		return 0, fmt.Errorf("cannot stat %s: %w", path, err)
+	}
+
+	var size int64
+	if info.IsDir() {
+		size, _ = GetDirSize(path)
+	} else {
+		size = info.Size()
+	}
+
+	if dryRun {
+		return size, nil
+	}
+
+	// The shell only offers undo for fully qualified paths.
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return 0, fmt.Errorf("cannot resolve %s: %w", path, err)
+	}
+
+	// pFrom is a double-NUL-terminated list of paths.
+	from, err := windows.UTF16FromString(abs)
+	if err != nil {
+		return 0, fmt.Errorf("invalid path %s: %w", path, err)
+	}
+	from = append(from, 0)
+
+	op := newShFileOp(foDelete, &from[0], fofAllowUndo|fofNoConfirmation|fofSilent|fofNoErrorUI)
+
+	ret, _, _ := procSHFileOperationW.Call(uintptr(unsafe.Pointer(&op)))
+	if ret != 0 {
+		return 0, fmt.Errorf("cannot move %s to Recycle Bin: SHFileOperationW error 0x%x", path, ret)
+	}
+	if op.aborted() {
+		return 0, fmt.Errorf("cannot move %s to Recycle Bin: operation aborted", path)
+	}
+
+	return size, nil
+}
@@ -0,0 +1,36 @@
+//go:build 386 || arm
+
+package core
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// shFileOpStruct mirrors SHFILEOPSTRUCTW on 32-bit Windows, where
+// shellapi.h packs it to 1-byte alignment. fAnyOperationsAborted then sits
+// straight after the 2-byte fFlags, which no Go struct can express, so the
+// fields from fFlags on are kept as raw bytes:
+// fFlags, fAnyOperationsAborted, hNameMappings, lpszProgressTitle.
+type shFileOpStruct struct {
+	hwnd   uintptr
+	wFunc  uint32
+	pFrom  *uint16
+	pTo    *uint16
+	packed [14]byte
+}
+
+// Fails to compile unless the fields end at byte 30, where the packed C
+// struct does. Go pads the struct to 32 bytes; Windows never reads the
+// padding.
+var _ = [1]struct{}{}[unsafe.Offsetof(shFileOpStruct{}.packed)+unsafe.Sizeof(shFileOpStruct{}.packed)-30]
+
+func newShFileOp(fn uint32, from *uint16, flags uint16) shFileOpStruct {
+	op := shFileOpStruct{wFunc: fn, pFrom: from}
+	binary.LittleEndian.PutUint16(op.packed[0:], flags)
+	return op
+}
+
+func (op *shFileOpStruct) aborted() bool {
+	return binary.LittleEndian.Uint32(op.packed[2:]) != 0
+}
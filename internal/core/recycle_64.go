@@ -0,0 +1,29 @@
+//go:build amd64 || arm64
+
+package core
+
+import "unsafe"
+
+// shFileOpStruct mirrors SHFILEOPSTRUCTW on 64-bit Windows, which uses
+// natural alignment.
+type shFileOpStruct struct {
+	hwnd                  uintptr
+	wFunc                 uint32
+	pFrom                 *uint16
+	pTo                   *uint16
+	fFlags                uint16
+	fAnyOperationsAborted int32
+	hNameMappings         uintptr
+	lpszProgressTitle     *uint16
+}
+
+// Fails to compile unless shFileOpStruct is the 56 bytes Windows expects.
+var _ = [1]struct{}{}[unsafe.Sizeof(shFileOpStruct{})-56]
+
+func newShFileOp(fn uint32, from *uint16, flags uint16) shFileOpStruct {
+	return shFileOpStruct{wFunc: fn, pFrom: from, fFlags: flags}
+}
+
+func (op *shFileOpStruct) aborted() bool {
+	return op.fAnyOperationsAborted != 0
+}
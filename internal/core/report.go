@@ -0,0 +1,103 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/jsonutil"
+)
+
+// ReportEntry records the outcome of deleting a single path.
+type ReportEntry struct {
+	Path       string `json:"path"`
+	BytesFreed int64  `json:"bytes_freed"`
+	Category   string `json:"category"`
+	Error      string `json:"error,omitempty"`
+}
+
+// CleanReport is an audit record of one clean run. Reports are appended to
+// a JSON array on disk so earlier runs are never overwritten; since one file
+// spans many runs, each record carries its own schema version rather than
+// sitting in a jsonutil.Envelope.
+type CleanReport struct {
+	SchemaVersion int           `json:"schema_version"`
+	Timestamp     time.Time     `json:"timestamp"`
+	DryRun        bool          `json:"dry_run"`
+	TotalFreed    int64         `json:"total_freed"`
+	Errors        int           `json:"errors"`
+	Entries       []ReportEntry `json:"entries"`
+
+	mu sync.Mutex
+}
+
+// NewCleanReport creates an empty report stamped with the current time.
+func NewCleanReport(dryRun bool) *CleanReport {
+	return &CleanReport{
+		SchemaVersion: jsonutil.SchemaVersion,
+		Timestamp:     time.Now(),
+		DryRun:        dryRun,
+		Entries:       make([]ReportEntry, 0),
+	}
+}
+
+// Add records the result for path. A non-nil err marks the entry as failed.
+func (r *CleanReport) Add(path, category string, freed int64, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := ReportEntry{Path: path, BytesFreed: freed, Category: category}
+	if err != nil {
+		entry.Error = err.Error()
+		entry.BytesFreed = 0
+		r.Errors++
+	}
+	r.TotalFreed += entry.BytesFreed
+	r.Entries = append(r.Entries, entry)
+}
+
+// AppendToFile appends the report to the JSON array stored at path,
+// creating the file if needed.
+func (r *CleanReport) AppendToFile(path string) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var reports []json.RawMessage
+	if data, err := os.ReadFile(path); err == nil && len(data) > 0 {
+		if err := json.Unmarshal(data, &reports); err != nil {
+			return fmt.Errorf("cannot append to report %s: existing file is not a JSON array: %w", path, err)
+		}
+	} else if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("cannot read report %s: %w", path, err)
+	}
+
+	current, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("cannot encode report: %w", err)
+	}
+	reports = append(reports, current)
+
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode report: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("cannot create report directory %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("cannot write report %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/jsonutil"
+)
+
+func TestCleanReport_AppendToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	first := NewCleanReport(false)
+	first.Add(`D:\tmp\a.log`, "user", 100, nil)
+	first.Add(`D:\tmp\b.log`, "user", 50, errors.New("locked"))
+	if err := first.AppendToFile(path); err != nil {
+		t.Fatalf("AppendToFile() error: %v", err)
+	}
+
+	second := NewCleanReport(true)
+	second.Add(`D:\tmp\c.log`, "dev", 10, nil)
+	if err := second.AppendToFile(path); err != nil {
+		t.Fatalf("AppendToFile() second run error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var runs []CleanReport
+	if err := json.Unmarshal(data, &runs); err != nil {
+		t.Fatalf("report is not a JSON array: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(runs))
+	}
+	if runs[0].TotalFreed != 100 || runs[0].Errors != 1 {
+		t.Errorf("first run: TotalFreed=%d Errors=%d, want 100 and 1", runs[0].TotalFreed, runs[0].Errors)
+	}
+	if runs[0].Entries[1].Error != "locked" || runs[0].Entries[1].BytesFreed != 0 {
+		t.Errorf("failed entry not recorded correctly: %+v", runs[0].Entries[1])
+	}
+	if !runs[1].DryRun {
+		t.Error("second run should be marked dry_run")
+	}
+	if runs[0].SchemaVersion != jsonutil.SchemaVersion {
+		t.Errorf("schema_version = %d, want %d", runs[0].SchemaVersion, jsonutil.SchemaVersion)
+	}
+}
@@ -11,22 +11,11 @@ import (
 	"github.com/lakshaymaurya-felt/purewin/internal/envutil"
 )
 
-// IsSafePath returns true if the given path is NOT in the NEVER_DELETE list.
-// Paths are compared case-insensitively after cleaning.
+// IsSafePath returns true if the given path is NOT in the NEVER_DELETE list
+// and not inside one of its entries. See config.IsProtectedPath for how
+// paths are normalized before comparison.
 func IsSafePath(path string) bool {
-	cleaned := filepath.Clean(path)
-	for _, protected := range config.GetNeverDeletePaths() {
-		if strings.EqualFold(cleaned, filepath.Clean(protected)) {
-			return false
-		}
-		// Also block anything directly under a never-delete path.
-		// e.g. C:\Windows\System32\drivers is still under System32.
-		protectedClean := filepath.Clean(protected) + string(os.PathSeparator)
-		if strings.HasPrefix(strings.ToLower(cleaned)+string(os.PathSeparator), strings.ToLower(protectedClean)) {
-			return false
-		}
-	}
-	return true
+	return !config.IsProtectedPath(path)
 }
 
 // ValidatePath performs comprehensive validation on a path before any
@@ -0,0 +1,82 @@
+// Package jsonutil wraps the JSON that commands print or export in a
+// versioned envelope, so scripts can detect breaking changes.
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SchemaVersion is the version of every JSON output. Bump it whenever one
+// changes incompatibly: a field is renamed, removed, or changes type.
+// Adding fields is compatible.
+//
+// Version history:
+//
+//	1  initial envelope
+const SchemaVersion = 1
+
+// Envelope is the top-level shape of all JSON output:
+//
+//	{
+//	  "schema_version": 1,
+//	  "data": { ... }
+//	}
+//
+// Data holds the command's payload:
+//
+//	pw status --json            status.SystemMetrics
+//	pw analyze --output json    analyze.DirEntry (the scan tree)
+//	pw analyze --export FILE    analyze.DirEntry (the scan tree)
+//
+// --export-ncdu is not wrapped; it keeps ncdu's own format. pw clean
+// --report appends to an array that spans runs, so each record carries
+// schema_version itself (see core.CleanReport).
+type Envelope struct {
+	SchemaVersion int `json:"schema_version"`
+	Data          any `json:"data"`
+}
+
+// Wrap puts data in an envelope at the current SchemaVersion.
+func Wrap(data any) Envelope {
+	return Envelope{SchemaVersion: SchemaVersion, Data: data}
+}
+
+// Marshal encodes data in an envelope as indented JSON.
+func Marshal(data any) ([]byte, error) {
+	return json.MarshalIndent(Wrap(data), "", "  ")
+}
+
+// Write encodes data in an envelope to w as indented JSON, followed by a
+// newline.
+func Write(w io.Writer, data any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Wrap(data))
+}
+
+// Unmarshal decodes an envelope's payload into v and returns its schema
+// version. Input without an envelope, written before versioning, decodes
+// as-is with version 0. Input from a newer schema is rejected.
+func Unmarshal(raw []byte, v any) (int, error) {
+	var env struct {
+		SchemaVersion *int            `json:"schema_version"`
+		Data          json.RawMessage `json:"data"`
+	}
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &env); err != nil {
+			return 0, err
+		}
+	}
+	if env.SchemaVersion == nil {
+		return 0, json.Unmarshal(raw, v)
+	}
+	version := *env.SchemaVersion
+	if version > SchemaVersion {
+		return version, fmt.Errorf("schema version %d is newer than the supported %d; update pw", version, SchemaVersion)
+	}
+	return version, json.Unmarshal(env.Data, v)
+}
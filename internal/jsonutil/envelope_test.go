@@ -0,0 +1,64 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type payload struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+func TestWrite_Envelope(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, payload{Name: "C:\\", Size: 42}); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a JSON object: %v", err)
+	}
+	if string(got["schema_version"]) != "1" {
+		t.Errorf("schema_version = %s, want 1", got["schema_version"])
+	}
+	if !strings.Contains(string(got["data"]), `"size": 42`) {
+		t.Errorf("data = %s, want the payload", got["data"])
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	wrapped, err := Marshal(payload{Name: "wrapped", Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name        string
+		in          string
+		wantVersion int
+		wantName    string
+		wantErr     bool
+	}{
+		{name: "current", in: string(wrapped), wantVersion: SchemaVersion, wantName: "wrapped"},
+		{name: "legacy", in: `{"name": "bare", "size": 2}`, wantVersion: 0, wantName: "bare"},
+		{name: "newer", in: `{"schema_version": 99, "data": {"name": "future"}}`, wantErr: true},
+		{name: "garbage", in: `{"name":`, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var p payload
+			version, err := Unmarshal([]byte(tc.in), &p)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if version != tc.wantVersion || p.Name != tc.wantName {
+				t.Errorf("got version %d name %q, want %d %q", version, p.Name, tc.wantVersion, tc.wantName)
+			}
+		})
+	}
+}
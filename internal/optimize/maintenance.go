@@ -15,10 +15,105 @@ import (
 const (
 	// maintenanceTimeout is the maximum time for long-running maintenance tasks.
 	maintenanceTimeout = 10 * time.Minute
+
+	// refreshTimeout is the maximum time for quick cache-refresh tasks.
+	refreshTimeout = 2 * time.Minute
 )
 
+// Requirement describes the Windows versions an optimize action supports.
+// The zero value supports every version.
+type Requirement struct {
+	MinBuild   uint32 // minimum Windows 10+ build number; 0 means any
+	ClientOnly bool   // unavailable on Windows Server
+}
+
+// Unmet returns why the running Windows does not satisfy r, or "" if it does.
+func (r Requirement) Unmet() string {
+	if r.MinBuild > 0 {
+		_, _, build := core.GetWindowsVersion()
+		if !core.IsWindows10OrAbove() || build < r.MinBuild {
+			return fmt.Sprintf("requires Windows 10 build %d+", r.MinBuild)
+		}
+	}
+	if r.ClientOnly && core.IsWindowsServer() {
+		return "not available on Windows Server"
+	}
+	return ""
+}
+
+// MaintenanceTask is a refresh-type maintenance operation offered by
+// "optimize --maintenance". None of them delete user data.
+type MaintenanceTask struct {
+	Name        string
+	Description string
+	NeedsAdmin  bool
+	Deep        bool // long-running; only offered with --deep
+	Requires    Requirement
+	Run         func() error
+}
+
+// GetMaintenanceTasks returns the curated maintenance tasks, in run order.
+func GetMaintenanceTasks() []MaintenanceTask {
+	return []MaintenanceTask{
+		{
+			Name:        "Flush DNS cache",
+			Description: "Clears cached DNS lookups (ipconfig /flushdns)",
+			NeedsAdmin:  true,
+			Run:         FlushDNS,
+		},
+		{
+			Name:        "Reset Microsoft Store cache",
+			Description: "Clears the Store cache (wsreset); the Store may open afterwards",
+			Requires:    Requirement{ClientOnly: true},
+			Run:         ResetStoreCache,
+		},
+		{
+			Name:        "Rebuild icon and thumbnail cache",
+			Description: "Restarts Explorer and lets Windows regenerate icons and thumbnails",
+			Run:         RebuildIconCache,
+		},
+		{
+			Name:        "DISM component cleanup",
+			Description: "Removes superseded Windows components (DISM /StartComponentCleanup)",
+			NeedsAdmin:  true,
+			Deep:        true,
+			Run:         RunDISMCleanup,
+		},
+		{
+			Name:        "System file integrity check",
+			Description: "Verifies protected system files without repairing them (sfc /verifyonly)",
+			NeedsAdmin:  true,
+			Deep:        true,
+			Run:         RunSFCCheck,
+		},
+		{
+			Name:        "Rebuild search index",
+			Description: "Restarts Windows Search so it re-indexes",
+			NeedsAdmin:  true,
+			Deep:        true,
+			Run:         RebuildSearchIndex,
+		},
+	}
+}
+
 // ─── Public API ──────────────────────────────────────────────────────────────
 
+// ResetStoreCache clears the Microsoft Store cache with wsreset.exe.
+func ResetStoreCache() error {
+	ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "wsreset.exe")
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("wsreset timed out after %s", refreshTimeout)
+	}
+	if err != nil {
+		return fmt.Errorf("wsreset failed: %s: %w", truncateOutput(output, 300), err)
+	}
+	return nil
+}
+
 // RunDISMCleanup runs the DISM component cleanup to free disk space.
 func RunDISMCleanup() error {
 	if err := core.RequireAdmin("DISM cleanup"); err != nil {
@@ -58,13 +153,10 @@ func RunSFCCheck() error {
 	return nil
 }
 
-// RebuildIconCache kills Explorer, deletes the icon cache files, and
-// restarts Explorer. This forces Windows to rebuild the icon cache.
+// RebuildIconCache kills Explorer, deletes the icon and thumbnail cache
+// files, and restarts Explorer. This forces Windows to rebuild both caches.
+// The caches live in the user profile, so no elevation is needed.
 func RebuildIconCache() error {
-	if err := core.RequireAdmin("rebuild icon cache"); err != nil {
-		return err
-	}
-
 	// Kill explorer.exe to release icon cache file handles.
 	killCtx, killCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer killCancel()
@@ -77,20 +169,28 @@ func RebuildIconCache() error {
 	if localAppData != "" {
 		// Modern icon cache: Explorer\iconcache_*.db
 		cacheDir := filepath.Join(localAppData, "Microsoft", "Windows", "Explorer")
-		pattern := filepath.Join(cacheDir, "iconcache*")
-		matches, _ := filepath.Glob(pattern)
-		for _, m := range matches {
-			_ = os.Remove(m) // Best effort — some may still be locked.
+		for _, pattern := range []string{"iconcache*", "thumbcache*"} {
+			matches, _ := filepath.Glob(filepath.Join(cacheDir, pattern))
+			for _, m := range matches {
+				// Best effort — some may still be locked.
+				if err := os.Remove(m); err != nil {
+					core.LogDebug("icon cache: %v", err)
+				}
+			}
 		}
 
 		// Legacy icon cache: IconCache.db
 		legacyCache := filepath.Join(localAppData, "IconCache.db")
-		_ = os.Remove(legacyCache)
+		if err := os.Remove(legacyCache); err != nil && !os.IsNotExist(err) {
+			core.LogDebug("icon cache: %v", err)
+		}
 	}
 
-	// Restart explorer.exe.
+	// Restart explorer.exe. Fire and forget.
 	startCmd := exec.Command("cmd.exe", "/C", "start", "explorer.exe")
-	_ = startCmd.Start() // Fire and forget.
+	if err := startCmd.Start(); err != nil {
+		core.LogWarn("restart explorer.exe: %v", err)
+	}
 
 	return nil
 }
@@ -101,30 +201,6 @@ func RebuildSearchIndex() error {
 	return RestartService("WSearch")
 }
 
-// ClearEventLogs clears the Application, System, and Security event logs.
-func ClearEventLogs() error {
-	if err := core.RequireAdmin("clear event logs"); err != nil {
-		return err
-	}
-
-	logs := []string{"Application", "System", "Security"}
-	var errs []string
-
-	for _, logName := range logs {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		cmd := exec.CommandContext(ctx, "wevtutil", "cl", logName)
-		if _, err := cmd.CombinedOutput(); err != nil {
-			errs = append(errs, fmt.Sprintf("%s: %v", logName, err))
-		}
-		cancel()
-	}
-
-	if len(errs) > 0 {
-		return fmt.Errorf("failed to clear some logs: %s", strings.Join(errs, "; "))
-	}
-	return nil
-}
-
 // ─── Helpers ─────────────────────────────────────────────────────────────────
 
 // truncateOutput trims and truncates command output for error messages.
@@ -20,6 +20,7 @@ const (
 type ManagedService struct {
 	Name        string
 	DisplayName string
+	Requires    Requirement
 }
 
 // GetManagedServices returns the list of services that PureWin can restart.
@@ -27,7 +28,7 @@ func GetManagedServices() []ManagedService {
 	return []ManagedService{
 		{Name: "Dnscache", DisplayName: "DNS Client"},
 		{Name: "Dhcp", DisplayName: "DHCP Client"},
-		{Name: "WSearch", DisplayName: "Windows Search"},
+		{Name: "WSearch", DisplayName: "Windows Search", Requires: Requirement{ClientOnly: true}},
 		{Name: "wuauserv", DisplayName: "Windows Update"},
 	}
 }
@@ -129,10 +129,11 @@ func ListStartupItems() {
 			status = ui.MutedStyle().Render(ui.IconUnselected + " Disabled")
 		}
 
-		name := ui.BoldStyle().Render(item.Name)
+		// Pad before styling: escape codes would count toward %-30s.
+		name := ui.BoldStyle().Render(ui.PadRight(item.Name, 30))
 		loc := ui.MutedStyle().Render(item.Location)
 
-		fmt.Printf("  %s  %-30s  %s\n", status, name, loc)
+		fmt.Printf("  %s  %s  %s\n", status, name, loc)
 
 		// Show command on the next line, truncated for readability.
 		cmd := item.Command
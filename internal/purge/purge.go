@@ -59,6 +59,28 @@ var artifactDirNames = func() map[string]bool {
 	return m
 }()
 
+// enabledArtifacts limits which artifact types are reported; nil means all.
+// Disabled types are still never recursed into.
+var enabledArtifacts map[string]bool
+
+// SetArtifactTypes restricts scanning to the named artifact directories
+// (e.g. "node_modules", "target"). An empty list enables every type.
+func SetArtifactTypes(names []string) error {
+	if len(names) == 0 {
+		enabledArtifacts = nil
+		return nil
+	}
+	m := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !artifactDirNames[name] {
+			return fmt.Errorf("unknown artifact type %q", name)
+		}
+		m[name] = true
+	}
+	enabledArtifacts = m
+	return nil
+}
+
 // ScanProjects walks the given paths and identifies project artifacts.
 // It will scan up to 3 levels deep and NOT recurse into artifact directories.
 func ScanProjects(paths []string) ([]ProjectArtifact, error) {
@@ -132,8 +154,8 @@ func scanDirectory(basePath, currentPath string, depth, maxDepth int, seenProjec
 			continue
 		}
 
-		// Check if this is an artifact directory
-		if !artifactDirNames[name] {
+		// Check if this is an enabled artifact directory
+		if !artifactDirNames[name] || (enabledArtifacts != nil && !enabledArtifacts[name]) {
 			continue
 		}
 
@@ -244,13 +266,20 @@ func hasAnyIndicator(dir string, indicators []string) bool {
 }
 
 // PurgeArtifacts deletes the specified artifacts and returns total bytes freed and count.
-func PurgeArtifacts(artifacts []ProjectArtifact, dryRun bool) (int64, int, error) {
+// With toRecycleBin set, artifacts are moved to the Recycle Bin instead.
+func PurgeArtifacts(artifacts []ProjectArtifact, dryRun, toRecycleBin bool) (int64, int, error) {
 	var totalBytes int64
 	var totalCount int
 	var lastErr error
 
 	for _, artifact := range artifacts {
-		freed, err := core.SafeDelete(artifact.ArtifactPath, dryRun)
+		var freed int64
+		var err error
+		if toRecycleBin {
+			freed, err = core.SafeDeleteToRecycleBin(artifact.ArtifactPath, dryRun)
+		} else {
+			freed, err = core.SafeDelete(artifact.ArtifactPath, dryRun)
+		}
 		if err != nil {
 			lastErr = err
 			continue
@@ -23,7 +23,7 @@ const (
 type CmdDef struct {
 	Name        string   // e.g., "clean" (without leading /)
 	Description string   // shown in completions popup
-	Usage       string   // e.g., "/clean [--dry-run] [--all|--user|--browser|--dev|--system]"
+	Usage       string   // e.g., "/clean [--dry-run] [--yes] [--all|--user|--browser|--dev|--system]"
 	Mode        ExecMode // how to execute
 	AdminHint   bool     // true if the command may need admin privileges
 }
@@ -34,52 +34,58 @@ func AllCommands() []CmdDef {
 		{
 			Name:        "clean",
 			Description: "Deep clean system caches and temp files",
-			Usage:       "/clean [--dry-run] [--all|--user|--browser|--dev|--system]",
+			Usage:       "/clean [--dry-run] [--yes] [--all|--user|--browser|--dev|--system] [--max-risk low|medium|high] [--include-high] [--only names|--skip names] [--discover] [--drive D:] [--report file] [--on-reboot] [--quiet] [--watch 30m] [--stale-report [--older-than 12mo] [--min-size 1GB]] [--schedule hourly|daily|weekly|--unschedule|--schedule-status] [--whitelist [add|remove|defaults|export|import] [arg]]",
 			Mode:        ExecCobra,
 			AdminHint:   true,
 		},
 		{
 			Name:        "uninstall",
 			Description: "Remove installed applications",
-			Usage:       "/uninstall [--search name] [--quiet]",
+			Usage:       "/uninstall [name] [--search name] [--quiet] [--yes]",
 			Mode:        ExecCobra,
 			AdminHint:   true,
 		},
 		{
 			Name:        "optimize",
 			Description: "Speed up Windows with service tuning",
-			Usage:       "/optimize [--dry-run] [--services|--maintenance|--startup]",
+			Usage:       "/optimize [--dry-run] [--services|--maintenance|--startup] [--deep]",
 			Mode:        ExecCobra,
 			AdminHint:   true,
 		},
 		{
 			Name:        "analyze",
 			Description: "Explore disk space usage",
-			Usage:       "/analyze [path]",
+			Usage:       "/analyze [path] [--fresh] [--show-links] [--no-mouse] [--old-after 6mo] [--output tree|plain|json] [--export file|--export-ncdu file|--import file]",
 			Mode:        ExecCobra,
 		},
 		{
 			Name:        "status",
 			Description: "Live system health monitor",
-			Usage:       "/status [--json]",
+			Usage:       "/status [--json|--oneline|--once] [--tab cpu] [--log file] [--alert-cpu 90] [--alert-mem 90] [--alert-disk 90] [--alert-temp 85] [--alert-bell] [--procs 50] [--no-mouse]",
 			Mode:        ExecCobra,
 		},
 		{
 			Name:        "purge",
 			Description: "Clean project build artifacts",
-			Usage:       "/purge [--dry-run] [--min-age days] [--min-size bytes]",
+			Usage:       "/purge [--dry-run] [--yes] [--min-age days] [--min-size size]",
 			Mode:        ExecCobra,
 		},
 		{
 			Name:        "installer",
 			Description: "Find and remove old installer files",
-			Usage:       "/installer [--dry-run] [--min-age days]",
+			Usage:       "/installer [--dry-run] [--yes] [--min-age days]",
+			Mode:        ExecCobra,
+		},
+		{
+			Name:        "doctor",
+			Description: "Check environment readiness",
+			Usage:       "/doctor",
 			Mode:        ExecCobra,
 		},
 		{
 			Name:        "update",
 			Description: "Check for PureWin updates",
-			Usage:       "/update [--force]",
+			Usage:       "/update [--force] [--skip-verify] [--disable-check|--enable-check]",
 			Mode:        ExecCobra,
 		},
 		{
@@ -11,19 +11,53 @@ import (
 
 // ─── Charmtone Shell Palette ─────────────────────────────────────────────────
 // Extends the global palette with shell-specific violet accent styles.
-// Each screen gets its own accent color for visual variety.
+// Each screen gets its own accent color for visual variety. The styles are
+// rebuilt whenever a theme is applied.
 
 // Shell-specific accent colors — violet for shell's unique visual identity.
 var (
 	// Accent: Violet purple — primary interactive elements.
-	accent = lipgloss.AdaptiveColor{Light: "#9A48CC", Dark: "#C259FF"}
+	accent lipgloss.AdaptiveColor
 
 	// Dim: Oyster gray — chrome, borders, secondary text.
-	dim = lipgloss.AdaptiveColor{Light: "#858392", Dark: "#605F6B"}
+	dim lipgloss.AdaptiveColor
+
+	// ── Prompt ──
+	promptSymbol, promptLabel lipgloss.Style
+
+	// ── Banner ──
+	bannerName, bannerDesc lipgloss.Style
+
+	// ── Welcome Screen ──
+	welcomeCardBorderCleanup, welcomeCardBorderSystem, welcomeCardBorderTools lipgloss.Style
+	welcomeTipsBox, welcomeCardTitle, welcomeCmdName, welcomeCmdIcon          lipgloss.Style
+	welcomeTipLabel, welcomeTipCmd, welcomeTipDesc                            lipgloss.Style
+	welcomeHostname, welcomeAdminBadge, welcomeVersionBadge                   lipgloss.Style
+
+	// ── Completions Popup ──
+	compBorder, compActiveRow, compActiveName, compActiveDesc lipgloss.Style
+	compInactiveName, compInactiveDesc, compAdminBadge        lipgloss.Style
+
+	// ── Output ──
+	outputText, outputEcho, outputDimEcho, outputCmd lipgloss.Style
+
+	// ── Scroll & Status ──
+	scrollHint, statusText, statusKey, statusSep, statusAdmin lipgloss.Style
+)
+
+func init() {
+	setStyles()
+	ui.OnThemeChange(setStyles)
+}
+
+// setStyles builds the shell styles from the active palette.
+func setStyles() {
+	accent = ui.ColorViolet
+	dim = ui.ColorMuted
 
 	// ── Prompt ──
 	promptSymbol = lipgloss.NewStyle().Foreground(accent).Bold(true)
-	promptLabel  = lipgloss.NewStyle().Foreground(ui.ColorText).Bold(true)
+	promptLabel = lipgloss.NewStyle().Foreground(ui.ColorText).Bold(true)
 
 	// ── Banner ──
 	bannerName = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true)
@@ -31,53 +65,53 @@ var (
 
 	// ── Welcome Screen ──
 	welcomeCardBorderCleanup = lipgloss.NewStyle().
-					Border(lipgloss.RoundedBorder()).
-					BorderForeground(ui.ColorSuccess).
-					Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorSuccess).
+		Padding(1, 2)
 	welcomeCardBorderSystem = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(ui.ColorInfo).
-				Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorInfo).
+		Padding(1, 2)
 	welcomeCardBorderTools = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(ui.ColorViolet).
-				Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorViolet).
+		Padding(1, 2)
 	welcomeTipsBox = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ui.ColorBorder).
-			Padding(0, 2)
-	welcomeCardTitle    = lipgloss.NewStyle().Bold(true)
-	welcomeCmdName      = lipgloss.NewStyle().Foreground(ui.ColorText)
-	welcomeCmdIcon      = lipgloss.NewStyle().Foreground(ui.ColorMuted)
-	welcomeTipLabel     = lipgloss.NewStyle().Foreground(accent).Bold(true)
-	welcomeTipCmd       = lipgloss.NewStyle().Foreground(ui.ColorPrimary)
-	welcomeTipDesc      = lipgloss.NewStyle().Foreground(ui.ColorTextDim)
-	welcomeHostname     = lipgloss.NewStyle().Foreground(ui.ColorText).Bold(true)
-	welcomeAdminBadge   = lipgloss.NewStyle().Foreground(ui.ColorWarning).Bold(true)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorBorder).
+		Padding(0, 2)
+	welcomeCardTitle = lipgloss.NewStyle().Bold(true)
+	welcomeCmdName = lipgloss.NewStyle().Foreground(ui.ColorText)
+	welcomeCmdIcon = lipgloss.NewStyle().Foreground(ui.ColorMuted)
+	welcomeTipLabel = lipgloss.NewStyle().Foreground(accent).Bold(true)
+	welcomeTipCmd = lipgloss.NewStyle().Foreground(ui.ColorPrimary)
+	welcomeTipDesc = lipgloss.NewStyle().Foreground(ui.ColorTextDim)
+	welcomeHostname = lipgloss.NewStyle().Foreground(ui.ColorText).Bold(true)
+	welcomeAdminBadge = lipgloss.NewStyle().Foreground(ui.ColorWarning).Bold(true)
 	welcomeVersionBadge = lipgloss.NewStyle().Foreground(ui.ColorMuted)
 
 	// ── Completions Popup ──
-	compBorder       = lipgloss.NewStyle().Foreground(ui.ColorBorder)
-	compActiveRow    = lipgloss.NewStyle().Background(ui.ColorOverlay).Foreground(ui.ColorText).Bold(true)
-	compActiveName   = lipgloss.NewStyle().Background(ui.ColorOverlay).Foreground(ui.ColorText).Bold(true)
-	compActiveDesc   = lipgloss.NewStyle().Background(ui.ColorOverlay).Foreground(ui.ColorTextDim).Italic(true)
+	compBorder = lipgloss.NewStyle().Foreground(ui.ColorBorder)
+	compActiveRow = lipgloss.NewStyle().Background(ui.ColorOverlay).Foreground(ui.ColorText).Bold(true)
+	compActiveName = lipgloss.NewStyle().Background(ui.ColorOverlay).Foreground(ui.ColorText).Bold(true)
+	compActiveDesc = lipgloss.NewStyle().Background(ui.ColorOverlay).Foreground(ui.ColorTextDim).Italic(true)
 	compInactiveName = lipgloss.NewStyle().Foreground(ui.ColorText)
 	compInactiveDesc = lipgloss.NewStyle().Foreground(dim).Italic(true)
-	compAdminBadge   = lipgloss.NewStyle().Foreground(ui.ColorWarning)
+	compAdminBadge = lipgloss.NewStyle().Foreground(ui.ColorWarning)
 
 	// ── Output ──
-	outputText    = lipgloss.NewStyle().Foreground(ui.ColorText)
-	outputEcho    = lipgloss.NewStyle().Foreground(accent).Bold(true)
+	outputText = lipgloss.NewStyle().Foreground(ui.ColorText)
+	outputEcho = lipgloss.NewStyle().Foreground(accent).Bold(true)
 	outputDimEcho = lipgloss.NewStyle().Foreground(dim)
-	outputCmd     = lipgloss.NewStyle().Foreground(ui.ColorText).Bold(true)
+	outputCmd = lipgloss.NewStyle().Foreground(ui.ColorText).Bold(true)
 
 	// ── Scroll & Status ──
-	scrollHint  = lipgloss.NewStyle().Foreground(dim).Italic(true)
-	statusText  = lipgloss.NewStyle().Foreground(dim).Italic(true)
-	statusKey   = lipgloss.NewStyle().Foreground(ui.ColorMuted)
-	statusSep   = lipgloss.NewStyle().Foreground(ui.ColorBorder)
+	scrollHint = lipgloss.NewStyle().Foreground(dim).Italic(true)
+	statusText = lipgloss.NewStyle().Foreground(dim).Italic(true)
+	statusKey = lipgloss.NewStyle().Foreground(ui.ColorMuted)
+	statusSep = lipgloss.NewStyle().Foreground(ui.ColorBorder)
 	statusAdmin = lipgloss.NewStyle().Foreground(ui.ColorWarning).Bold(true)
-)
+}
 
 // ─── Welcome Mascot & Brand Art ──────────────────────────────────────────────
 // ASCII mascot matching the SVG logo (assets/logo.svg) and the large wordmark.
@@ -109,6 +143,7 @@ var cmdIcons = map[string]string{
 	"status":    ui.IconDot,
 	"purge":     ui.IconTrash,
 	"installer": ui.IconFolder,
+	"doctor":    ui.IconCheck,
 	"update":    ui.IconReload,
 	"version":   ui.IconDiamond,
 	"help":      ui.IconHelp,
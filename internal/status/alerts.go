@@ -0,0 +1,60 @@
+package status
+
+// ─── Alert Thresholds ────────────────────────────────────────────────────────
+
+// Thresholds are the levels at which a dashboard metric counts as breached.
+// Breached metrics render in the alert color; a zero value disables the
+// check for that metric.
+type Thresholds struct {
+	CPU    float64 // total CPU, percent
+	Memory float64 // physical memory used, percent
+	Disk   float64 // any partition used, percent
+	Temp   float64 // hottest thermal zone, °C
+}
+
+// DefaultThresholds match the dashboard's long-standing 90% alert color.
+var DefaultThresholds = Thresholds{CPU: 90, Memory: 90, Disk: 90, Temp: 85}
+
+// Breach keys; disks are keyed per partition as alertDisk + path.
+const (
+	alertCPU    = "cpu"
+	alertMemory = "mem"
+	alertTemp   = "temp"
+	alertDisk   = "disk:"
+)
+
+// Breaches returns the metrics at or above their thresholds.
+func (t Thresholds) Breaches(met *SystemMetrics) map[string]bool {
+	breaches := make(map[string]bool)
+	if met == nil {
+		return breaches
+	}
+	if t.CPU > 0 && met.CPU.TotalPercent >= t.CPU {
+		breaches[alertCPU] = true
+	}
+	if t.Memory > 0 && met.Memory.UsedPercent >= t.Memory {
+		breaches[alertMemory] = true
+	}
+	if t.Temp > 0 && met.Temperature >= t.Temp {
+		breaches[alertTemp] = true
+	}
+	if t.Disk > 0 {
+		for _, p := range met.Disk.Partitions {
+			if p.UsedPercent >= t.Disk {
+				breaches[alertDisk+p.Path] = true
+			}
+		}
+	}
+	return breaches
+}
+
+// hasNewBreach reports whether cur contains a breach that prev did not, so
+// a metric that stays over its threshold alerts only once.
+func hasNewBreach(prev, cur map[string]bool) bool {
+	for key := range cur {
+		if !prev[key] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package status
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ─── CSV metrics log ─────────────────────────────────────────────────────────
+
+// MetricsLogger appends one CSV row per collection cycle to a file.
+// Partition columns are fixed by the first reading so every row lines up
+// with the header even if drives are mounted or removed mid-session.
+type MetricsLogger struct {
+	file       *os.File
+	w          *csv.Writer
+	mu         sync.Mutex
+	needHeader bool
+	partitions []string // column order, captured from the first reading
+}
+
+// NewMetricsLogger opens (or creates) path for appending. The header row is
+// written with the first reading, and only if the file was empty on open.
+func NewMetricsLogger(path string) (*MetricsLogger, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("cannot create log directory %s: %w", dir, err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open metrics log %s: %w", path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("cannot stat metrics log %s: %w", path, err)
+	}
+
+	return &MetricsLogger{
+		file:       file,
+		w:          csv.NewWriter(file),
+		needHeader: info.Size() == 0,
+	}, nil
+}
+
+// Write appends a row for m and flushes it to disk immediately so a crash
+// never loses more than the current reading.
+func (l *MetricsLogger) Write(m *SystemMetrics) error {
+	if l == nil || m == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	if l.partitions == nil {
+		l.partitions = make([]string, 0, len(m.Disk.Partitions))
+		for _, p := range m.Disk.Partitions {
+			l.partitions = append(l.partitions, p.Path)
+		}
+	}
+
+	if l.needHeader {
+		header := []string{"timestamp", "cpu_pct", "mem_pct", "net_up_bps", "net_down_bps"}
+		for _, p := range l.partitions {
+			header = append(header, "disk_"+p+"_pct")
+		}
+		if err := l.w.Write(header); err != nil {
+			return err
+		}
+		l.needHeader = false
+	}
+
+	usage := make(map[string]float64, len(m.Disk.Partitions))
+	for _, p := range m.Disk.Partitions {
+		usage[p.Path] = p.UsedPercent
+	}
+
+	row := []string{
+		m.CollectedAt.Format(time.RFC3339),
+		strconv.FormatFloat(m.CPU.TotalPercent, 'f', 1, 64),
+		strconv.FormatFloat(m.Memory.UsedPercent, 'f', 1, 64),
+		strconv.FormatUint(m.Network.SendSpeed, 10),
+		strconv.FormatUint(m.Network.RecvSpeed, 10),
+	}
+	for _, p := range l.partitions {
+		if pct, ok := usage[p]; ok {
+			row = append(row, strconv.FormatFloat(pct, 'f', 1, 64))
+		} else {
+			row = append(row, "") // partition disappeared since the first reading
+		}
+	}
+
+	if err := l.w.Write(row); err != nil {
+		return err
+	}
+	l.w.Flush()
+	if err := l.w.Error(); err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+// Close flushes any buffered data and closes the file. Safe to call twice.
+func (l *MetricsLogger) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	l.w.Flush()
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
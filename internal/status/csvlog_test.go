@@ -0,0 +1,124 @@
+package status
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// csvReading returns metrics with the given disk usage per partition path.
+func csvReading(at time.Time, disks map[string]float64, order ...string) *SystemMetrics {
+	m := &SystemMetrics{CollectedAt: at}
+	m.CPU.TotalPercent = 12.34
+	m.Memory.UsedPercent = 56.78
+	m.Network.SendSpeed = 1000
+	m.Network.RecvSpeed = 2000
+	for _, path := range order {
+		m.Disk.Partitions = append(m.Disk.Partitions, DiskPartition{Path: path, UsedPercent: disks[path]})
+	}
+	return m
+}
+
+// readCSV returns every row of the CSV file at path.
+func readCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestMetricsLogger_HeaderAndRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "metrics.csv")
+	l, err := NewMetricsLogger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	if err := l.Write(csvReading(at, map[string]float64{"C:": 40, "D:": 75.3}, "C:", "D:")); err != nil {
+		t.Fatal(err)
+	}
+	// D: was removed; E: appeared. Columns stay as the first reading set them.
+	if err := l.Write(csvReading(at.Add(time.Second), map[string]float64{"C:": 41, "E:": 10}, "C:", "E:")); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := readCSV(t, path)
+	want := [][]string{
+		{"timestamp", "cpu_pct", "mem_pct", "net_up_bps", "net_down_bps", "disk_C:_pct", "disk_D:_pct"},
+		{"2026-03-01T09:30:00Z", "12.3", "56.8", "1000", "2000", "40.0", "75.3"},
+		{"2026-03-01T09:30:01Z", "12.3", "56.8", "1000", "2000", "41.0", ""},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %q", len(rows), len(want), rows)
+	}
+	for i := range want {
+		if !slices.Equal(rows[i], want[i]) {
+			t.Errorf("row %d = %q, want %q", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestMetricsLogger_AppendsWithoutSecondHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.csv")
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	for i := range 2 {
+		l, err := NewMetricsLogger(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := l.Write(csvReading(at.Add(time.Duration(i)*time.Minute), nil)); err != nil {
+			t.Fatal(err)
+		}
+		if err := l.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rows := readCSV(t, path)
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want a header and two readings: %q", len(rows), rows)
+	}
+	if rows[0][0] != "timestamp" || rows[1][0] == "timestamp" || rows[2][0] == "timestamp" {
+		t.Errorf("want exactly one header row, got %q", rows)
+	}
+	if rows[2][0] != "2026-03-01T09:31:00Z" {
+		t.Errorf("second session row = %q, want it appended after the first", rows[2])
+	}
+}
+
+func TestMetricsLogger_NilSafe(t *testing.T) {
+	var l *MetricsLogger
+	if err := l.Write(&SystemMetrics{}); err != nil {
+		t.Errorf("nil logger Write = %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("nil logger Close = %v", err)
+	}
+
+	l, err := NewMetricsLogger(filepath.Join(t.TempDir(), "metrics.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("second Close = %v", err)
+	}
+	if err := l.Write(&SystemMetrics{}); err != nil {
+		t.Errorf("Write after Close = %v", err)
+	}
+}
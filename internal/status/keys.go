@@ -0,0 +1,36 @@
+package status
+
+import "github.com/lakshaymaurya-felt/purewin/internal/ui"
+
+// ─── Key Bindings ────────────────────────────────────────────────────────────
+// The footer hints and the ? overlay are both built from these.
+
+var (
+	keySwitch     = ui.KeyBinding{Keys: "Tab/Shift-Tab", Help: "switch"}
+	keyJump       = ui.KeyBinding{Keys: "1-6", Help: "jump"}
+	keyQuit       = ui.KeyBinding{Keys: "q", Help: "quit"}
+	keyScore      = ui.KeyBinding{Keys: "h", Help: "score"}
+	keyAdapter    = ui.KeyBinding{Keys: "i", Help: "adapter"}
+	keyScroll     = ui.KeyBinding{Keys: "↑↓", Help: "scroll"}
+	keyFlush      = ui.KeyBinding{Keys: "f", Help: "flush standby"}
+	keyFlushOK    = ui.KeyBinding{Keys: "Enter", Help: "confirm flush"}
+	keyFlushEsc   = ui.KeyBinding{Keys: "any key", Help: "cancel"}
+	keyGraph      = ui.KeyBinding{Keys: "g", Help: "graph"}
+	keySort       = ui.KeyBinding{Keys: "c/m/n", Help: "sort"}
+	keyGroup      = ui.KeyBinding{Keys: "g", Help: "group"}
+	keyFilter     = ui.KeyBinding{Keys: "/", Help: "filter"}
+	keyFilterType = ui.KeyBinding{Keys: "Type", Help: "to filter"}
+	keyFilterKeep = ui.KeyBinding{Keys: "Enter", Help: "keep"}
+	keyFilterEsc  = ui.KeyBinding{Keys: "Esc", Help: "clear"}
+	keyClickTab   = ui.KeyBinding{Keys: "click", Help: "switch to a tab"}
+)
+
+// helpGroups lists every dashboard binding for the help overlay.
+var helpGroups = []ui.KeyGroup{
+	{Title: "Tabs", Bindings: []ui.KeyBinding{keySwitch, keyJump, keyClickTab}},
+	{Title: "Overview", Bindings: []ui.KeyBinding{keyScore}},
+	{Title: "Memory", Bindings: []ui.KeyBinding{keyFlush, keyFlushOK}},
+	{Title: "Network", Bindings: []ui.KeyBinding{keyAdapter, keyGraph, keyScroll}},
+	{Title: "Processes", Bindings: []ui.KeyBinding{keySort, keyGroup, keyFilter, keyFilterKeep, keyFilterEsc}},
+	{Title: "General", Bindings: []ui.KeyBinding{ui.KeyHelp, keyQuit}},
+}
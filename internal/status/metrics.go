@@ -1,6 +1,8 @@
 package status
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"runtime"
 	"sort"
@@ -14,7 +16,9 @@ import (
 	"github.com/shirou/gopsutil/v4/mem"
 	"github.com/shirou/gopsutil/v4/net"
 	"github.com/shirou/gopsutil/v4/process"
+	"github.com/shirou/gopsutil/v4/sensors"
 	"github.com/yusufpapurcu/wmi"
+	"golang.org/x/sys/windows"
 )
 
 // ─── Metric structs ──────────────────────────────────────────────────────────
@@ -37,6 +41,17 @@ type MemoryMetrics struct {
 	SwapTotal   uint64
 	SwapUsed    uint64
 	SwapPercent float64
+
+	// Cached is the standby list and system file cache: memory that holds
+	// recently used data but counts as available, since Windows hands it
+	// out again on demand.
+	Cached uint64
+	// Committed is the commit charge, the virtual memory the system has
+	// promised to back with RAM or the page file, and CommitLimit is the
+	// most it can promise before allocations fail.
+	Committed     uint64
+	CommitLimit   uint64
+	CommitPercent float64
 }
 
 // DiskMetrics holds partition usage and I/O counters.
@@ -44,6 +59,8 @@ type DiskMetrics struct {
 	Partitions []DiskPartition
 	ReadBytes  uint64
 	WriteBytes uint64
+	ReadSpeed  uint64 // bytes/sec, filled in by the dashboard from successive samples
+	WriteSpeed uint64 // bytes/sec
 }
 
 // DiskPartition is a single mount point.
@@ -55,14 +72,35 @@ type DiskPartition struct {
 	UsedPercent float64
 }
 
-// NetworkMetrics holds aggregate network I/O.
+// NetworkMetrics holds aggregate network I/O, plus a per-adapter breakdown.
 type NetworkMetrics struct {
+	BytesSent  uint64
+	BytesRecv  uint64
+	SendSpeed  uint64 // bytes/sec
+	RecvSpeed  uint64 // bytes/sec
+	Interfaces map[string]InterfaceMetrics
+}
+
+// InterfaceMetrics holds I/O counters for a single network adapter.
+type InterfaceMetrics struct {
 	BytesSent uint64
 	BytesRecv uint64
 	SendSpeed uint64 // bytes/sec
 	RecvSpeed uint64 // bytes/sec
 }
 
+// InterfaceNames returns the adapters that have carried traffic, sorted.
+func (n NetworkMetrics) InterfaceNames() []string {
+	var names []string
+	for name, im := range n.Interfaces {
+		if im.BytesSent > 0 || im.BytesRecv > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // ProcessInfo describes a single process for the top-N list.
 type ProcessInfo struct {
 	PID    int32
@@ -71,6 +109,157 @@ type ProcessInfo struct {
 	MemPct float32
 }
 
+// ProcessGroup aggregates every process sharing one image name.
+type ProcessGroup struct {
+	Name   string
+	Count  int
+	CPUPct float64
+	MemPct float32
+}
+
+// DefaultTopProcs is how many of the busiest processes, by CPU and by
+// memory, the JSON and one-line output keep in TopProcs and ProcGroups.
+const DefaultTopProcs = 5
+
+// DashboardTopProcs is how many the dashboard collects: the Processes tab
+// sorts and filters from this larger set.
+const DashboardTopProcs = 50
+
+// GroupProcesses sums CPU% and Mem% per image name (case-insensitive) and
+// returns the groups in the top n by CPU or by memory, ordered by CPU.
+// n <= 0 returns every group.
+func GroupProcesses(procs []ProcessInfo, n int) []ProcessGroup {
+	idx := make(map[string]int)
+	var groups []ProcessGroup
+	for _, p := range procs {
+		key := strings.ToLower(p.Name)
+		i, ok := idx[key]
+		if !ok {
+			i = len(groups)
+			idx[key] = i
+			groups = append(groups, ProcessGroup{Name: p.Name})
+		}
+		groups[i].Count++
+		groups[i].CPUPct += p.CPUPct
+		groups[i].MemPct += p.MemPct
+	}
+	if n <= 0 || len(groups) <= n {
+		SortProcessGroups(groups, ProcSortCPU)
+		return groups
+	}
+
+	// Keep the n largest by memory as well, so re-sorting the list by
+	// memory on the Processes tab is still meaningful.
+	SortProcessGroups(groups, ProcSortMemory)
+	keep := make(map[string]bool, 2*n)
+	for _, g := range groups[:n] {
+		keep[strings.ToLower(g.Name)] = true
+	}
+	SortProcessGroups(groups, ProcSortCPU)
+	out := groups[:0:0]
+	for i, g := range groups {
+		if i < n || keep[strings.ToLower(g.Name)] {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// TopProcesses returns the processes in the top n by CPU or by memory,
+// ordered by CPU. n <= 0 returns every process.
+func TopProcesses(procs []ProcessInfo, n int) []ProcessInfo {
+	if n <= 0 || len(procs) <= n {
+		SortProcesses(procs, ProcSortCPU)
+		return procs
+	}
+
+	SortProcesses(procs, ProcSortMemory)
+	keep := make(map[int32]bool, n)
+	for _, p := range procs[:n] {
+		keep[p.PID] = true
+	}
+	SortProcesses(procs, ProcSortCPU)
+	out := procs[:0:0]
+	for i, p := range procs {
+		if i < n || keep[p.PID] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// ProcSort selects the ordering of the Processes tab.
+type ProcSort int
+
+const (
+	ProcSortCPU ProcSort = iota
+	ProcSortMemory
+	ProcSortName
+)
+
+// ProcSortNames labels each ProcSort for the Processes tab header.
+var ProcSortNames = []string{"CPU", "Memory", "Name"}
+
+// SortProcesses orders procs in place: CPU and memory descending, names
+// ascending (case-insensitive). Ties keep their current order.
+func SortProcesses(procs []ProcessInfo, by ProcSort) {
+	sort.SliceStable(procs, func(i, j int) bool {
+		switch by {
+		case ProcSortMemory:
+			return procs[i].MemPct > procs[j].MemPct
+		case ProcSortName:
+			return strings.ToLower(procs[i].Name) < strings.ToLower(procs[j].Name)
+		default:
+			return procs[i].CPUPct > procs[j].CPUPct
+		}
+	})
+}
+
+// SortProcessGroups is SortProcesses for grouped rows.
+func SortProcessGroups(groups []ProcessGroup, by ProcSort) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		switch by {
+		case ProcSortMemory:
+			return groups[i].MemPct > groups[j].MemPct
+		case ProcSortName:
+			return strings.ToLower(groups[i].Name) < strings.ToLower(groups[j].Name)
+		default:
+			return groups[i].CPUPct > groups[j].CPUPct
+		}
+	})
+}
+
+// FilterProcesses returns the processes whose name contains query,
+// case-insensitively. An empty query returns procs unchanged.
+func FilterProcesses(procs []ProcessInfo, query string) []ProcessInfo {
+	if query == "" {
+		return procs
+	}
+	q := strings.ToLower(query)
+	var out []ProcessInfo
+	for _, p := range procs {
+		if strings.Contains(strings.ToLower(p.Name), q) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// FilterProcessGroups is FilterProcesses for grouped rows.
+func FilterProcessGroups(groups []ProcessGroup, query string) []ProcessGroup {
+	if query == "" {
+		return groups
+	}
+	q := strings.ToLower(query)
+	var out []ProcessGroup
+	for _, g := range groups {
+		if strings.Contains(strings.ToLower(g.Name), q) {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
 // GPUInfo holds basic GPU information from WMI.
 type GPUInfo struct {
 	Name       string
@@ -93,19 +282,30 @@ type HardwareInfo struct {
 	CPUCores     int
 	RAMTotal     uint64
 	Architecture string
+	Uptime       time.Duration
+	BootTime     time.Time
 }
 
 // SystemMetrics is the aggregate result of a single collection cycle.
 type SystemMetrics struct {
-	CPU         CPUMetrics     `json:"cpu"`
-	Memory      MemoryMetrics  `json:"memory"`
-	Disk        DiskMetrics    `json:"disk"`
-	Network     NetworkMetrics `json:"network"`
-	TopProcs    []ProcessInfo  `json:"top_processes"`
-	GPU         GPUInfo        `json:"gpu"`
-	Battery     BatteryInfo    `json:"battery"`
-	Hardware    HardwareInfo   `json:"hardware"`
-	CollectedAt time.Time      `json:"collected_at"`
+	CPU         CPUMetrics       `json:"cpu"`
+	Memory      MemoryMetrics    `json:"memory"`
+	Disk        DiskMetrics      `json:"disk"`
+	Network     NetworkMetrics   `json:"network"`
+	TopProcs    []ProcessInfo    `json:"top_processes"`
+	ProcGroups  []ProcessGroup   `json:"process_groups,omitempty"`
+	Counts      SystemCounts     `json:"counts"`
+	Sessions    []SessionInfo    `json:"sessions,omitempty"`
+	PageFiles   []PageFile       `json:"page_files,omitempty"`
+	GPU         GPUInfo          `json:"gpu"`
+	Battery     BatteryInfo      `json:"battery"`
+	Hardware    HardwareInfo     `json:"hardware"`
+	Connections []ConnectionInfo `json:"connections,omitempty"` // only while the Network tab is shown
+	CollectedAt time.Time        `json:"collected_at"`
+
+	// Temperature is the hottest ACPI thermal zone in °C, or 0 when the
+	// firmware doesn't expose one (reading it usually needs admin).
+	Temperature float64 `json:"temperature_c,omitempty"`
 }
 
 // ─── WMI helper structs ──────────────────────────────────────────────────────
@@ -120,12 +320,63 @@ type win32Battery struct {
 	BatteryStatus            uint16
 }
 
+// byteRate converts two byte-counter samples into bytes/sec. A counter
+// that went backwards (device removed and re-added, or a counter reset)
+// yields 0 rather than an unsigned-underflow delta.
+func byteRate(cur, prev uint64, interval time.Duration) uint64 {
+	secs := interval.Seconds()
+	if secs <= 0 || cur < prev {
+		return 0
+	}
+	return uint64(float64(cur-prev) / secs)
+}
+
+// netSpeed is byteRate for network counters, which also discards
+// implausible jumps.
+func netSpeed(cur, prev uint64, interval time.Duration) uint64 {
+	// Cap at 10 Gbps (1.25 GB/s) to filter counter resets.
+	const maxBytesPerSec uint64 = 10 * 1024 * 1024 * 1024 / 8 // ~1.25 GB/s
+	speed := byteRate(cur, prev, interval)
+	if speed > maxBytesPerSec {
+		return 0
+	}
+	return speed
+}
+
 // ─── Collection ──────────────────────────────────────────────────────────────
 
+// MetricsCollector gathers one round of metrics. prev is the previous
+// network reading used to derive speeds (nil on the first round) and
+// interval the time elapsed since it was taken. Connections lists at most
+// limit TCP connections; it is comparatively costly, so the dashboard only
+// calls it while the Network tab is visible. The dashboard collects through
+// this so tests can script the readings.
+type MetricsCollector interface {
+	Collect(prev *NetworkMetrics, interval time.Duration) (*SystemMetrics, error)
+	Connections(limit int) ([]ConnectionInfo, error)
+}
+
+// SystemCollector reads the live machine via CollectMetrics, keeping the
+// TopProcs busiest processes.
+type SystemCollector struct {
+	TopProcs int
+}
+
+// Collect implements MetricsCollector.
+func (c SystemCollector) Collect(prev *NetworkMetrics, interval time.Duration) (*SystemMetrics, error) {
+	return CollectMetrics(prev, interval, c.TopProcs)
+}
+
+// Connections implements MetricsCollector.
+func (SystemCollector) Connections(limit int) ([]ConnectionInfo, error) {
+	return CollectConnections(limit)
+}
+
 // CollectMetrics gathers all system metrics in parallel.
 // prevNet provides the previous network counters for speed calculation;
-// interval is the time elapsed since prevNet was recorded.
-func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMetrics, error) {
+// interval is the time elapsed since prevNet was recorded. topProcs is how
+// many of the busiest processes to keep; 0 keeps every one.
+func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration, topProcs int) (*SystemMetrics, error) {
 	m := &SystemMetrics{
 		CollectedAt: time.Now(),
 	}
@@ -164,9 +415,7 @@ func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMet
 			return
 		}
 		swap, _ := mem.SwapMemory()
-
-		mu.Lock()
-		m.Memory = MemoryMetrics{
+		mm := MemoryMetrics{
 			Total:       vm.Total,
 			Used:        vm.Used,
 			Available:   vm.Available,
@@ -174,10 +423,14 @@ func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMet
 			UsedPercent: vm.UsedPercent,
 		}
 		if swap != nil {
-			m.Memory.SwapTotal = swap.Total
-			m.Memory.SwapUsed = swap.Used
-			m.Memory.SwapPercent = swap.UsedPercent
+			mm.SwapTotal = swap.Total
+			mm.SwapUsed = swap.Used
+			mm.SwapPercent = swap.UsedPercent
 		}
+		collectMemoryPressure(&mm)
+
+		mu.Lock()
+		m.Memory = mm
 		mu.Unlock()
 	}()
 
@@ -223,34 +476,30 @@ func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMet
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		counters, err := net.IOCounters(false)
+		counters, err := net.IOCounters(true)
 		if err != nil || len(counters) == 0 {
 			return
 		}
 
-		nm := NetworkMetrics{
-			BytesSent: counters[0].BytesSent,
-			BytesRecv: counters[0].BytesRecv,
-		}
-		if prevNet != nil && interval > 0 {
-			secs := interval.Seconds()
-			if secs > 0 {
-				// Calculate speed only if counters didn't wrap/reset.
-				// Cap at 10 Gbps (1.25 GB/s) to filter counter resets.
-				const maxBytesPerSec uint64 = 10 * 1024 * 1024 * 1024 / 8 // ~1.25 GB/s
-				if nm.BytesSent >= prevNet.BytesSent {
-					speed := uint64(float64(nm.BytesSent-prevNet.BytesSent) / secs)
-					if speed <= maxBytesPerSec {
-						nm.SendSpeed = speed
-					}
-				}
-				if nm.BytesRecv >= prevNet.BytesRecv {
-					speed := uint64(float64(nm.BytesRecv-prevNet.BytesRecv) / secs)
-					if speed <= maxBytesPerSec {
-						nm.RecvSpeed = speed
-					}
+		nm := NetworkMetrics{Interfaces: make(map[string]InterfaceMetrics, len(counters))}
+		for _, c := range counters {
+			im := InterfaceMetrics{BytesSent: c.BytesSent, BytesRecv: c.BytesRecv}
+			// Adapters that just appeared (e.g. a VPN connecting) have no
+			// previous sample, so their speed stays 0 until the next tick.
+			if prevNet != nil {
+				if prev, ok := prevNet.Interfaces[c.Name]; ok {
+					im.SendSpeed = netSpeed(im.BytesSent, prev.BytesSent, interval)
+					im.RecvSpeed = netSpeed(im.BytesRecv, prev.BytesRecv, interval)
 				}
 			}
+			nm.Interfaces[c.Name] = im
+
+			// Summing per-adapter speeds (rather than diffing the totals)
+			// keeps the aggregate steady when an adapter comes or goes.
+			nm.BytesSent += c.BytesSent
+			nm.BytesRecv += c.BytesRecv
+			nm.SendSpeed += im.SendSpeed
+			nm.RecvSpeed += im.RecvSpeed
 		}
 
 		mu.Lock()
@@ -281,15 +530,14 @@ func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMet
 				MemPct: memPct,
 			})
 		}
-		sort.Slice(infos, func(i, j int) bool {
-			return infos[i].CPUPct > infos[j].CPUPct
-		})
-		if len(infos) > 5 {
-			infos = infos[:5]
-		}
+		// Group before truncating so many small instances of one app
+		// still add up to a single busy row.
+		groups := GroupProcesses(infos, topProcs)
+		infos = TopProcesses(infos, topProcs)
 
 		mu.Lock()
 		m.TopProcs = infos
+		m.ProcGroups = groups
 		mu.Unlock()
 	}()
 
@@ -348,6 +596,60 @@ func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMet
 		mu.Unlock()
 	}()
 
+	// ── Temperature via WMI thermal zones ────────────────────
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		temps, _ := sensors.TemperaturesWithContext(ctx)
+		var hottest float64
+		for _, t := range temps {
+			if t.Temperature > hottest {
+				hottest = t.Temperature
+			}
+		}
+		mu.Lock()
+		m.Temperature = hottest
+		mu.Unlock()
+	}()
+
+	// ── Process / thread / handle counts ─────────────────────
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		counts := collectSystemCounts()
+		mu.Lock()
+		m.Counts = counts
+		mu.Unlock()
+	}()
+
+	// ── Logged-in sessions (best effort) ─────────────────────
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		sessions, err := CollectSessions()
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		m.Sessions = sessions
+		mu.Unlock()
+	}()
+
+	// ── Page files via WMI (best effort) ─────────────────────
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		files, err := CollectPageFiles()
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		m.PageFiles = files
+		mu.Unlock()
+	}()
+
 	// ── Hardware info ────────────────────────────────────────
 	wg.Add(1)
 	go func() {
@@ -399,57 +701,95 @@ func GetHardwareInfo() HardwareInfo {
 		info.RAMTotal = vm.Total
 	}
 
+	// DurationSinceBoot wraps GetTickCount64, which cannot fail.
+	info.Uptime = windows.DurationSinceBoot()
+	info.BootTime = time.Now().Add(-info.Uptime).Truncate(time.Second)
+
 	return info
 }
 
 // ─── Health score ────────────────────────────────────────────────────────────
 
-// HealthScore computes a 0–100 composite health score.
+// ScoreComponent is one line of the health-score breakdown: the points
+// deducted for a single resource and the most it could ever deduct.
+type ScoreComponent struct {
+	Name   string
+	Points int // points deducted (0 = healthy)
+	Max    int // maximum possible deduction for this component
+	Detail string
+}
+
+// HealthScore computes a 0–100 composite health score and returns the
+// per-resource breakdown that produced it.
 //
 // Deductions:
 //
 //	CPU  >80 → -30, >60 → -20, >40 → -10
 //	Mem  >90 → -25, >75 → -15, >60 → -10
 //	Disk >95 → -20, >85 → -15, >75 → -10  (worst partition)
-func HealthScore(m *SystemMetrics) int {
-	score := 100
-
+//	Swap >80 → -10, >50 → -5              (only if a page file exists)
+func HealthScore(m *SystemMetrics) (int, []ScoreComponent) {
+	cpuC := ScoreComponent{Name: "CPU", Max: 30, Detail: fmt.Sprintf("%.1f%% busy", m.CPU.TotalPercent)}
 	switch {
 	case m.CPU.TotalPercent > 80:
-		score -= 30
+		cpuC.Points = 30
 	case m.CPU.TotalPercent > 60:
-		score -= 20
+		cpuC.Points = 20
 	case m.CPU.TotalPercent > 40:
-		score -= 10
+		cpuC.Points = 10
 	}
 
+	memC := ScoreComponent{Name: "Memory", Max: 25, Detail: fmt.Sprintf("%.1f%% used", m.Memory.UsedPercent)}
 	switch {
 	case m.Memory.UsedPercent > 90:
-		score -= 25
+		memC.Points = 25
 	case m.Memory.UsedPercent > 75:
-		score -= 15
+		memC.Points = 15
 	case m.Memory.UsedPercent > 60:
-		score -= 10
+		memC.Points = 10
 	}
 
 	// Use the worst (highest usage) partition.
 	var worstDisk float64
+	worstPath := ""
 	for _, p := range m.Disk.Partitions {
 		if p.UsedPercent > worstDisk {
 			worstDisk = p.UsedPercent
+			worstPath = p.Path
 		}
 	}
+	diskC := ScoreComponent{Name: "Disk", Max: 20, Detail: fmt.Sprintf("%.1f%% full", worstDisk)}
+	if worstPath != "" {
+		diskC.Detail += " (" + worstPath + ")"
+	}
 	switch {
 	case worstDisk > 95:
-		score -= 20
+		diskC.Points = 20
 	case worstDisk > 85:
-		score -= 15
+		diskC.Points = 15
 	case worstDisk > 75:
-		score -= 10
+		diskC.Points = 10
+	}
+
+	swapC := ScoreComponent{Name: "Swap", Max: 10, Detail: "no page file"}
+	if m.Memory.SwapTotal > 0 {
+		swapC.Detail = fmt.Sprintf("%.1f%% used", m.Memory.SwapPercent)
+		switch {
+		case m.Memory.SwapPercent > 80:
+			swapC.Points = 10
+		case m.Memory.SwapPercent > 50:
+			swapC.Points = 5
+		}
 	}
 
+	components := []ScoreComponent{cpuC, memC, diskC, swapC}
+
+	score := 100
+	for _, c := range components {
+		score -= c.Points
+	}
 	if score < 0 {
 		score = 0
 	}
-	return score
+	return score, components
 }
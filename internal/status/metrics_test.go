@@ -0,0 +1,374 @@
+package status
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+)
+
+// ---------------------------------------------------------------------------
+// netSpeed tests
+// ---------------------------------------------------------------------------
+
+func TestNetSpeed_CounterReset(t *testing.T) {
+	// A driver reload resets the counter: each sample is lower than the last.
+	samples := []uint64{5_000_000, 4_000_000, 1_000, 0}
+	for i := 1; i < len(samples); i++ {
+		if got := netSpeed(samples[i], samples[i-1], time.Second); got != 0 {
+			t.Errorf("netSpeed(%d, %d) = %d, want 0 after counter reset",
+				samples[i], samples[i-1], got)
+		}
+	}
+}
+
+func TestNetSpeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		cur      uint64
+		prev     uint64
+		interval time.Duration
+		want     uint64
+	}{
+		{"steady", 3_000, 1_000, time.Second, 2_000},
+		{"two seconds", 3_000, 1_000, 2 * time.Second, 1_000},
+		{"idle", 1_000, 1_000, time.Second, 0},
+		{"zero interval", 3_000, 1_000, 0, 0},
+		{"implausible jump", 1 << 40, 0, time.Second, 0},
+	}
+	for _, tt := range tests {
+		if got := netSpeed(tt.cur, tt.prev, tt.interval); got != tt.want {
+			t.Errorf("%s: netSpeed(%d, %d, %v) = %d, want %d",
+				tt.name, tt.cur, tt.prev, tt.interval, got, tt.want)
+		}
+	}
+}
+
+func TestByteRate_NoCap(t *testing.T) {
+	// Fast NVMe drives exceed the network cap; disk rates must not be clipped.
+	const sevenGB = 7 * 1024 * 1024 * 1024
+	if got := byteRate(sevenGB, 0, time.Second); got != sevenGB {
+		t.Errorf("byteRate = %d, want %d", got, uint64(sevenGB))
+	}
+	if got := byteRate(0, sevenGB, time.Second); got != 0 {
+		t.Errorf("byteRate after reset = %d, want 0", got)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Alert threshold tests
+// ---------------------------------------------------------------------------
+
+func TestThresholds_Breaches(t *testing.T) {
+	met := &SystemMetrics{
+		CPU:         CPUMetrics{TotalPercent: 95},
+		Memory:      MemoryMetrics{UsedPercent: 50},
+		Temperature: 70,
+		Disk: DiskMetrics{Partitions: []DiskPartition{
+			{Path: `C:\`, UsedPercent: 91},
+			{Path: `D:\`, UsedPercent: 40},
+		}},
+	}
+
+	got := Thresholds{CPU: 90, Memory: 90, Disk: 90, Temp: 85}.Breaches(met)
+	if !got[alertCPU] || got[alertMemory] || got[alertTemp] || !got[alertDisk+`C:\`] || got[alertDisk+`D:\`] {
+		t.Errorf("Breaches = %v, want cpu and C: only", got)
+	}
+
+	// Zero thresholds disable their checks; lower ones catch more.
+	got = Thresholds{Memory: 50, Temp: 60}.Breaches(met)
+	if got[alertCPU] || !got[alertMemory] || !got[alertTemp] || len(got) != 2 {
+		t.Errorf("Breaches = %v, want mem and temp only", got)
+	}
+}
+
+func TestHasNewBreach(t *testing.T) {
+	if !hasNewBreach(nil, map[string]bool{alertCPU: true}) {
+		t.Error("first breach should be new")
+	}
+	if hasNewBreach(map[string]bool{alertCPU: true}, map[string]bool{alertCPU: true}) {
+		t.Error("sustained breach should not be new")
+	}
+	if hasNewBreach(map[string]bool{alertCPU: true, alertMemory: true}, map[string]bool{alertCPU: true}) {
+		t.Error("a breach clearing should not be new")
+	}
+	if !hasNewBreach(map[string]bool{alertCPU: true}, map[string]bool{alertCPU: true, alertTemp: true}) {
+		t.Error("an additional metric breaching should be new")
+	}
+}
+
+func TestGroupProcesses(t *testing.T) {
+	procs := []ProcessInfo{
+		{PID: 1, Name: "chrome.exe", CPUPct: 10, MemPct: 2},
+		{PID: 2, Name: "Chrome.exe", CPUPct: 15, MemPct: 3},
+		{PID: 3, Name: "chrome.exe", CPUPct: 20, MemPct: 1},
+		{PID: 4, Name: "code.exe", CPUPct: 30, MemPct: 5},
+		{PID: 5, Name: "idle.exe", CPUPct: 0, MemPct: 0},
+	}
+
+	got := GroupProcesses(procs, 2)
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	// Three small chrome instances outrank one busier code.exe.
+	if got[0].Name != "chrome.exe" || got[0].Count != 3 || got[0].CPUPct != 45 || got[0].MemPct != 6 {
+		t.Errorf("group[0] = %+v, want chrome.exe ×3 45%% 6%%", got[0])
+	}
+	if got[1].Name != "code.exe" || got[1].Count != 1 {
+		t.Errorf("group[1] = %+v, want code.exe ×1", got[1])
+	}
+
+	if all := GroupProcesses(procs, 0); len(all) != 3 {
+		t.Errorf("n=0 returned %d groups, want 3", len(all))
+	}
+}
+
+func TestMergePageFiles(t *testing.T) {
+	usage := []win32PageFileUsage{
+		{Name: `C:\pagefile.sys`, AllocatedBaseSize: 4096, CurrentUsage: 512, PeakUsage: 1024},
+		{Name: `D:\pagefile.sys`, AllocatedBaseSize: 2048, CurrentUsage: 10, PeakUsage: 20},
+		{Name: `E:\pagefile.sys`, AllocatedBaseSize: 1024},
+	}
+	settings := []win32PageFileSetting{
+		{Name: `c:\PAGEFILE.SYS`, InitialSize: 0, MaximumSize: 0},
+		{Name: `D:\pagefile.sys`, InitialSize: 2048, MaximumSize: 8192},
+	}
+
+	got := mergePageFiles(usage, settings)
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3", len(got))
+	}
+	if got[0].Allocated != 4<<30 || got[0].Used != 512<<20 || got[0].Peak != 1<<30 {
+		t.Errorf("C: sizes = %+v, want 4 GB / 512 MB / 1 GB", got[0])
+	}
+	// 0/0 and a missing setting are both system-managed.
+	for i, want := range []bool{true, false, true} {
+		if got[i].SystemManaged != want {
+			t.Errorf("%s SystemManaged = %v, want %v", got[i].Path, got[i].SystemManaged, want)
+		}
+	}
+}
+
+func TestFilterProcesses(t *testing.T) {
+	procs := []ProcessInfo{
+		{PID: 1, Name: "chrome.exe"},
+		{PID: 2, Name: "ChromeDriver.exe"},
+		{PID: 3, Name: "code.exe"},
+	}
+
+	if got := FilterProcesses(procs, ""); len(got) != 3 {
+		t.Errorf("empty query returned %d, want 3", len(got))
+	}
+	got := FilterProcesses(procs, "CHROME")
+	if len(got) != 2 || got[0].PID != 1 || got[1].PID != 2 {
+		t.Errorf("FilterProcesses(CHROME) = %+v, want PIDs 1 and 2", got)
+	}
+	if got := FilterProcesses(procs, "firefox"); len(got) != 0 {
+		t.Errorf("FilterProcesses(firefox) = %+v, want none", got)
+	}
+
+	groups := GroupProcesses(procs, 0)
+	if got := FilterProcessGroups(groups, "code"); len(got) != 1 || got[0].Name != "code.exe" {
+		t.Errorf("FilterProcessGroups(code) = %+v, want code.exe", got)
+	}
+}
+
+func TestTopProcesses_KeepsBusiestByMemory(t *testing.T) {
+	procs := []ProcessInfo{
+		{PID: 1, Name: "a.exe", CPUPct: 40, MemPct: 1},
+		{PID: 2, Name: "b.exe", CPUPct: 30, MemPct: 2},
+		{PID: 3, Name: "c.exe", CPUPct: 20, MemPct: 3},
+		{PID: 4, Name: "d.exe", CPUPct: 0, MemPct: 50},
+	}
+
+	got := TopProcesses(procs, 2)
+	var pids []int32
+	for _, p := range got {
+		pids = append(pids, p.PID)
+	}
+	// Top two by CPU (1, 2) plus top two by memory (4, 3), ordered by CPU.
+	if len(pids) != 4 || pids[0] != 1 || pids[1] != 2 || pids[2] != 3 || pids[3] != 4 {
+		t.Errorf("TopProcesses PIDs = %v, want [1 2 3 4]", pids)
+	}
+
+	got = TopProcesses(procs, 1)
+	if len(got) != 2 || got[0].PID != 1 || got[1].PID != 4 {
+		t.Errorf("TopProcesses(1) = %+v, want PIDs 1 and 4", got)
+	}
+}
+
+func TestSortProcesses(t *testing.T) {
+	procs := []ProcessInfo{
+		{PID: 1, Name: "zoom.exe", CPUPct: 5, MemPct: 1},
+		{PID: 2, Name: "Code.exe", CPUPct: 1, MemPct: 9},
+		{PID: 3, Name: "audiodg.exe", CPUPct: 3, MemPct: 4},
+	}
+
+	for _, tt := range []struct {
+		by   ProcSort
+		want []int32
+	}{
+		{ProcSortCPU, []int32{1, 3, 2}},
+		{ProcSortMemory, []int32{2, 3, 1}},
+		{ProcSortName, []int32{3, 2, 1}},
+	} {
+		SortProcesses(procs, tt.by)
+		for i, p := range procs {
+			if p.PID != tt.want[i] {
+				t.Errorf("sort %s: got PID %d at %d, want %v", ProcSortNames[tt.by], p.PID, i, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestFormatThousands(t *testing.T) {
+	for n, want := range map[uint64]string{
+		0:       "0",
+		312:     "312",
+		4102:    "4,102",
+		98441:   "98,441",
+		1234567: "1,234,567",
+	} {
+		if got := formatThousands(n); got != want {
+			t.Errorf("formatThousands(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestFormatUptime(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		30 * time.Second:              "0m",
+		12 * time.Minute:              "12m",
+		2*time.Hour + 5*time.Minute:   "2h 5m",
+		86*time.Hour + 40*time.Minute: "3d 14h",
+		24 * time.Hour:                "1d 0h",
+	} {
+		if got := formatUptime(d); got != want {
+			t.Errorf("formatUptime(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestRenderBlockChart(t *testing.T) {
+	// Two rows of eight eighths each: 4 fills the bottom row, 8 both rows,
+	// 2 half the bottom row. The missing fourth column pads on the left.
+	got := renderBlockChart([]uint64{4, 8, 2}, 4, 2)
+	want := []string{"  █ ", " ██▄"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// Only the newest readings fit.
+	if got := renderBlockChart([]uint64{8, 0, 8}, 2, 1); got[0] != " █" {
+		t.Errorf("truncated chart = %q, want %q", got[0], " █")
+	}
+}
+
+func TestRenderSparkline_Peak(t *testing.T) {
+	label := " peak " + formatSpeed(5000)
+	got := renderSparklineU64([]uint64{1000, 5000, 2500}, 5, ui.ColorTeal, sparkSpeed)
+	if !strings.HasSuffix(got, label) {
+		t.Errorf("sparkline = %q, want a %q suffix", got, label)
+	}
+	if w := lipgloss.Width(got); w != 5+lipgloss.Width(label) {
+		t.Errorf("width = %d, want %d", w, 5+lipgloss.Width(label))
+	}
+
+	// The peak is taken from the visible window only.
+	got = renderSparklineU64([]uint64{4096, 1024, 2048}, 2, ui.ColorTeal, sparkSpeed)
+	if !strings.Contains(got, "peak "+formatSpeed(2048)) {
+		t.Errorf("sparkline = %q, want peak %s", got, formatSpeed(2048))
+	}
+
+	if got := renderSparkline([]float64{1, 2}, 4, ui.ColorPrimary, sparkOpts{}); strings.Contains(got, "peak") {
+		t.Errorf("bare sparkline = %q, want no label", got)
+	}
+}
+
+func TestFormatWindow(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		60 * time.Second: "60s",
+		90 * time.Second: "90s",
+		5 * time.Minute:  "5m",
+	} {
+		if got := formatWindow(d); got != want {
+			t.Errorf("formatWindow(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestParseTab(t *testing.T) {
+	for name, want := range map[string]Tab{
+		"overview":  TabOverview,
+		"CPU":       TabCPU,
+		"mem":       TabMemory,
+		"Disk":      TabDisk,
+		"net":       TabNetwork,
+		"processes": TabProcesses,
+		" proc ":    TabProcesses,
+	} {
+		got, err := ParseTab(name)
+		if err != nil || got != want {
+			t.Errorf("ParseTab(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "gpu"} {
+		if _, err := ParseTab(bad); err == nil {
+			t.Errorf("ParseTab(%q) should fail", bad)
+		}
+	}
+}
+
+func TestTabAt(t *testing.T) {
+	m := NewStatusModel(0)
+	labels := m.renderTabLabels()
+
+	x := 0
+	for i, label := range labels {
+		if got, ok := m.tabAt(x+1, 1); !ok || got != Tab(i) {
+			t.Errorf("tabAt(%d, 1) = %v, %v; want %v", x+1, got, ok, Tab(i))
+		}
+		x += lipgloss.Width(label)
+	}
+	if _, ok := m.tabAt(x+5, 1); ok {
+		t.Error("click right of the last tab should miss")
+	}
+	if _, ok := m.tabAt(1, 5); ok {
+		t.Error("click below the tab bar should miss")
+	}
+
+	// A click while the process filter has focus closes and clears it,
+	// as Esc does before a key can switch tabs.
+	m = m.WithTab(TabProcesses)
+	m.filtering = true
+	m.procFilter = "chr"
+	updated, _ := m.Update(tea.MouseMsg{Button: tea.MouseButtonLeft, Action: tea.MouseActionPress, X: 1, Y: 1})
+	m = updated.(StatusModel)
+	if m.Tab != TabOverview || m.filtering || m.procFilter != "" {
+		t.Errorf("after click: tab %v, filtering %v, filter %q; want overview with the filter cleared",
+			m.Tab, m.filtering, m.procFilter)
+	}
+}
+
+func TestApplyTheme_RebuildsStyles(t *testing.T) {
+	defer ui.ApplyTheme(ui.DefaultTheme)
+
+	if err := ui.ApplyTheme("Monochrome"); err != nil {
+		t.Fatalf("ApplyTheme: %v", err)
+	}
+	if got := alertStyle.GetForeground(); got != ui.ColorError {
+		t.Errorf("alertStyle foreground = %v, want the monochrome error color %v", got, ui.ColorError)
+	}
+	if err := ui.ApplyTheme("solarized"); err == nil {
+		t.Error("unknown theme should fail")
+	}
+}
@@ -1,7 +1,11 @@
 package status
 
 import (
+	"fmt"
+	"os"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -23,6 +27,44 @@ const (
 // TabNames is the display label for each tab.
 var TabNames = []string{"Overview", "CPU", "Memory", "Disk", "Network", "Processes"}
 
+// ParseTab resolves a tab by name or unambiguous prefix, case-insensitively
+// ("cpu", "mem", "proc").
+func ParseTab(name string) (Tab, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	found := -1
+	for i, t := range TabNames {
+		t = strings.ToLower(t)
+		if t == name {
+			return Tab(i), nil
+		}
+		if name != "" && strings.HasPrefix(t, name) {
+			if found >= 0 {
+				return 0, fmt.Errorf("ambiguous tab %q", name)
+			}
+			found = i
+		}
+	}
+	if found < 0 {
+		return 0, fmt.Errorf("unknown tab %q (want one of %s)", name, strings.ToLower(strings.Join(TabNames, ", ")))
+	}
+	return Tab(found), nil
+}
+
+// ─── Refresh Ticks ───────────────────────────────────────────────────────────
+
+// Ticker schedules the dashboard's refresh ticks. It has tea.Tick's shape.
+type Ticker interface {
+	Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd
+}
+
+// WallTicker ticks on the wall clock via tea.Tick.
+type WallTicker struct{}
+
+// Tick implements Ticker.
+func (WallTicker) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return tea.Tick(d, fn)
+}
+
 // ─── Messages ────────────────────────────────────────────────────────────────
 
 type tickMsg time.Time
@@ -32,24 +74,84 @@ type metricsMsg struct {
 	err     error
 }
 
+// flushResultMsg reports how a standby list flush went.
+type flushResultMsg struct{ err error }
+
 // ─── Model ───────────────────────────────────────────────────────────────────
 
+// HistoryLen is how many readings each sparkline history keeps.
+const HistoryLen = 60
+
 // StatusModel is the bubbletea Model for the system health dashboard.
 type StatusModel struct {
 	Metrics         *SystemMetrics
 	prevNet         *NetworkMetrics
+	prevDisk        *DiskMetrics
+	prevDiskAt      time.Time
 	Tab             Tab
 	Width           int
 	Height          int
 	refreshInterval time.Duration
+	collector       MetricsCollector
+	ticker          Ticker
 	quitting        bool
 	Err             error
 
-	// Sparkline ring buffers (last 60 readings).
+	// ShowScoreBreakdown expands the health-score panel on the overview.
+	ShowScoreBreakdown bool
+
+	// connOffset is the first visible row of the Network tab's connection list.
+	connOffset int
+
+	// NetIface is the adapter shown on the Network tab; "" means all adapters.
+	NetIface string
+
+	// NetGraph swaps the Network tab's sparklines for taller labeled charts.
+	NetGraph bool
+
+	// GroupProcs shows the Processes tab aggregated by image name.
+	GroupProcs bool
+
+	// SortProcs orders the Processes tab; CPU is the default.
+	SortProcs ProcSort
+
+	// procFilter narrows the Processes tab to names containing it;
+	// filtering is true while the filter input has focus.
+	procFilter string
+	filtering  bool
+
+	// thresholds decide which metrics render as alerts; breached holds the
+	// current breaches so alertBell rings once per new breach.
+	thresholds Thresholds
+	breached   map[string]bool
+	alertBell  bool
+
+	// confirmFlush arms the standby list flush; only Enter confirms it.
+	// flushNote reports the last flush, and flushStandby performs it.
+	confirmFlush bool
+	flushNote    string
+	flushStandby func() error
+
+	// snapshot renders a single frame without the key-hint footer.
+	snapshot bool
+
+	// showHelp covers the view with the key binding overlay.
+	showHelp bool
+
+	// metricsLog, when set, receives one CSV row per collection cycle.
+	metricsLog *MetricsLogger
+
+	// Sparkline ring buffers (last HistoryLen readings).
 	NetSendHistory []uint64
 	NetRecvHistory []uint64
+	DiskReadHist   []uint64
+	DiskWriteHist  []uint64
 	CPUHistory     []float64
 	MemHistory     []float64
+
+	// Per-adapter sparkline buffers, keyed by adapter name.
+	ifaceSendHistory map[string][]uint64
+	ifaceRecvHistory map[string][]uint64
 }
 
 // NewStatusModel creates a StatusModel with the given refresh cadence.
@@ -61,11 +163,50 @@ func NewStatusModel(refreshInterval time.Duration) StatusModel {
 		Width:           80,
 		Height:          24,
 		refreshInterval: refreshInterval,
+		collector:       SystemCollector{TopProcs: DashboardTopProcs},
+		ticker:          WallTicker{},
+		thresholds:      DefaultThresholds,
+		flushStandby:    FlushStandbyList,
 	}
 }
 
+// WithCollector replaces the live system collector, e.g. with a fake that
+// returns scripted readings in tests.
+func (m StatusModel) WithCollector(c MetricsCollector) StatusModel {
+	m.collector = c
+	return m
+}
+
+// WithAlerts sets the alert thresholds and whether a new breach rings the
+// terminal bell.
+func (m StatusModel) WithAlerts(t Thresholds, bell bool) StatusModel {
+	m.thresholds = t
+	m.alertBell = bell
+	return m
+}
+
+// WithTab sets the tab shown first.
+func (m StatusModel) WithTab(t Tab) StatusModel {
+	m.Tab = t
+	return m
+}
+
+// WithTicker replaces the wall-clock refresh ticker, e.g. with one that
+// fires immediately so tests can drive the update loop synchronously.
+func (m StatusModel) WithTicker(t Ticker) StatusModel {
+	m.ticker = t
+	return m
+}
+
+// WithMetricsLog attaches a CSV logger that records every collection cycle.
+// The logger is closed when the user quits the dashboard.
+func (m StatusModel) WithMetricsLog(l *MetricsLogger) StatusModel {
+	m.metricsLog = l
+	return m
+}
+
 func (m StatusModel) doTick() tea.Cmd {
-	return tea.Tick(m.refreshInterval, func(t time.Time) tea.Msg {
+	return m.ticker.Tick(m.refreshInterval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -73,12 +214,46 @@ func (m StatusModel) doTick() tea.Cmd {
 func (m StatusModel) collectMetrics() tea.Cmd {
 	prevNet := m.prevNet
 	interval := m.refreshInterval
+	collector := m.collector
+	// Connection enumeration is comparatively costly, so it only runs while
+	// the Network tab is visible.
+	withConns := m.Tab == TabNetwork
 	return func() tea.Msg {
-		metrics, err := CollectMetrics(prevNet, interval)
+		metrics, err := collector.Collect(prevNet, interval)
+		if err == nil && withConns {
+			metrics.Connections, _ = collector.Connections(maxConnections)
+		}
 		return metricsMsg{metrics: metrics, err: err}
 	}
 }
 
+// snapshotWidth is the render width for Snapshot, which has no terminal
+// to measure.
+const snapshotWidth = 100
+
+// Snapshot collects one round of metrics and renders the current tab once,
+// without the key-hint footer. It backs `pw status --once`.
+func (m StatusModel) Snapshot() (string, error) {
+	msg := m.collectMetrics()().(metricsMsg)
+	if msg.err != nil {
+		return "", msg.err
+	}
+	updated, _ := m.Update(msg)
+	m = updated.(StatusModel)
+	m.Width = snapshotWidth
+	return RenderFrame(m), nil
+}
+
+// RenderFrame renders m's current tab once at m.Width and m.Height,
+// without the key-hint footer. Under lipgloss's Ascii color profile (as
+// with NO_COLOR) the result is plain text, so tests can compare frames
+// byte for byte.
+func RenderFrame(m StatusModel) string {
+	m.snapshot = true
+	m.showHelp = false
+	return m.renderView()
+}
+
 // ─── tea.Model interface ─────────────────────────────────────────────────────
 
 func (m StatusModel) Init() tea.Cmd {
@@ -95,11 +270,55 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Height = msg.Height
 		return m, nil
 
+	case tea.MouseMsg:
+		// Clicking a tab label switches to it. Keys can only switch tabs
+		// once Esc has closed and cleared the filter input, so a click
+		// does the same.
+		if !m.showHelp && msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress {
+			if t, ok := m.tabAt(msg.X, msg.Y); ok {
+				if m.filtering {
+					m.filtering = false
+					m.procFilter = ""
+				}
+				m.Tab = t
+			}
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		if m.filtering {
+			return m.updateProcFilter(msg)
+		}
+		// The help overlay swallows keys until it is dismissed.
+		if m.showHelp {
+			switch msg.String() {
+			case "?", "esc":
+				m.showHelp = false
+			case "q", "ctrl+c":
+				return m.quit()
+			}
+			return m, nil
+		}
+		// A pending flush goes ahead on Enter; any other key cancels it.
+		if m.confirmFlush {
+			m.confirmFlush = false
+			if msg.String() == "enter" {
+				m.flushNote = "Flushing standby list..."
+				return m, m.runFlush()
+			}
+			return m, nil
+		}
 		switch msg.String() {
-		case "q", "esc", "ctrl+c":
-			m.quitting = true
-			return m, tea.Quit
+		case "?":
+			m.showHelp = true
+		case "esc":
+			if m.Tab == TabProcesses && m.procFilter != "" {
+				m.procFilter = ""
+				return m, nil
+			}
+			return m.quit()
+		case "q", "ctrl+c":
+			return m.quit()
 		case "tab":
 			m.Tab = (m.Tab + 1) % Tab(len(TabNames))
 		case "shift+tab":
@@ -120,25 +339,107 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.Tab = TabNetwork
 		case "6":
 			m.Tab = TabProcesses
+		case "f":
+			if m.Tab == TabMemory {
+				m.confirmFlush = true
+			}
+		case "h":
+			if m.Tab == TabOverview {
+				m.ShowScoreBreakdown = !m.ShowScoreBreakdown
+			}
+		case "g":
+			switch m.Tab {
+			case TabProcesses:
+				m.GroupProcs = !m.GroupProcs
+			case TabNetwork:
+				m.NetGraph = !m.NetGraph
+			}
+		case "c":
+			if m.Tab == TabProcesses {
+				m.SortProcs = ProcSortCPU
+			}
+		case "m":
+			if m.Tab == TabProcesses {
+				m.SortProcs = ProcSortMemory
+			}
+		case "n":
+			if m.Tab == TabProcesses {
+				m.SortProcs = ProcSortName
+			}
+		case "/":
+			if m.Tab == TabProcesses {
+				m.filtering = true
+			}
+		case "i":
+			if m.Tab == TabNetwork && m.Metrics != nil {
+				m.NetIface = nextInterface(m.Metrics.Network.InterfaceNames(), m.NetIface)
+			}
+		case "up", "k":
+			if m.Tab == TabNetwork && m.connOffset > 0 {
+				m.connOffset--
+			}
+		case "down", "j":
+			if m.Tab == TabNetwork && m.Metrics != nil && m.connOffset < len(m.Metrics.Connections)-1 {
+				m.connOffset++
+			}
 		}
 		return m, nil
 
 	case tickMsg:
 		return m, m.collectMetrics()
 
+	case flushResultMsg:
+		if msg.err != nil {
+			// Keep only the headline of multi-line errors such as
+			// RequireAdmin's.
+			m.flushNote = "Flush failed: " + strings.SplitN(msg.err.Error(), "\n", 2)[0]
+		} else {
+			m.flushNote = "Standby list flushed"
+		}
+		return m, nil
+
 	case metricsMsg:
 		if msg.err != nil {
 			m.Err = msg.err
 			return m, m.doTick()
 		}
+		// Disk throughput comes from diffing against the previous sample;
+		// the first sample has nothing to diff against and shows 0 B/s.
+		if m.prevDisk != nil {
+			elapsed := msg.metrics.CollectedAt.Sub(m.prevDiskAt)
+			msg.metrics.Disk.ReadSpeed = byteRate(msg.metrics.Disk.ReadBytes, m.prevDisk.ReadBytes, elapsed)
+			msg.metrics.Disk.WriteSpeed = byteRate(msg.metrics.Disk.WriteBytes, m.prevDisk.WriteBytes, elapsed)
+		}
+
 		m.Metrics = msg.metrics
 		m.prevNet = &msg.metrics.Network
+		m.prevDisk = &msg.metrics.Disk
+		m.prevDiskAt = msg.metrics.CollectedAt
+		if m.connOffset >= len(msg.metrics.Connections) {
+			m.connOffset = 0
+		}
+
+		// Append to sparkline histories (cap at HistoryLen).
+		m.CPUHistory = appendF64(m.CPUHistory, msg.metrics.CPU.TotalPercent, HistoryLen)
+		m.MemHistory = appendF64(m.MemHistory, msg.metrics.Memory.UsedPercent, HistoryLen)
+		m.NetSendHistory = appendU64(m.NetSendHistory, msg.metrics.Network.SendSpeed, HistoryLen)
+		m.NetRecvHistory = appendU64(m.NetRecvHistory, msg.metrics.Network.RecvSpeed, HistoryLen)
+		m.appendInterfaceHistory(msg.metrics.Network.Interfaces)
+		m.DiskReadHist = appendU64(m.DiskReadHist, msg.metrics.Disk.ReadSpeed, HistoryLen)
+		m.DiskWriteHist = appendU64(m.DiskWriteHist, msg.metrics.Disk.WriteSpeed, HistoryLen)
+
+		if m.metricsLog != nil {
+			if err := m.metricsLog.Write(msg.metrics); err != nil {
+				m.Err = err
+			}
+		}
 
-		// Append to sparkline histories (cap at 60).
-		m.CPUHistory = appendF64(m.CPUHistory, msg.metrics.CPU.TotalPercent, 60)
-		m.MemHistory = appendF64(m.MemHistory, msg.metrics.Memory.UsedPercent, 60)
-		m.NetSendHistory = appendU64(m.NetSendHistory, msg.metrics.Network.SendSpeed, 60)
-		m.NetRecvHistory = appendU64(m.NetRecvHistory, msg.metrics.Network.RecvSpeed, 60)
+		breaches := m.thresholds.Breaches(msg.metrics)
+		ring := m.alertBell && hasNewBreach(m.breached, breaches)
+		m.breached = breaches
+		if ring {
+			return m, tea.Batch(m.doTick(), ringBell)
+		}
 
 		return m, m.doTick()
 	}
@@ -146,6 +447,45 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// runFlush flushes the standby list off the UI goroutine.
+func (m StatusModel) runFlush() tea.Cmd {
+	flush := m.flushStandby
+	return func() tea.Msg {
+		return flushResultMsg{err: flush()}
+	}
+}
+
+// quit closes the metrics log and ends the program.
+func (m StatusModel) quit() (tea.Model, tea.Cmd) {
+	m.quitting = true
+	if m.metricsLog != nil {
+		_ = m.metricsLog.Close()
+	}
+	return m, tea.Quit
+}
+
+// updateProcFilter handles keys while the Processes tab filter has focus.
+// The list narrows on every keystroke; Esc clears the filter.
+func (m StatusModel) updateProcFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.filtering = false
+		m.procFilter = ""
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyCtrlC:
+		return m.quit()
+	case tea.KeyBackspace:
+		if len(m.procFilter) > 0 {
+			_, size := utf8.DecodeLastRuneInString(m.procFilter)
+			m.procFilter = m.procFilter[:len(m.procFilter)-size]
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.procFilter += string(msg.Runes)
+	}
+	return m, nil
+}
+
 func (m StatusModel) View() string {
 	if m.quitting {
 		return ""
@@ -155,6 +495,41 @@ func (m StatusModel) View() string {
 
 // ─── History helpers ─────────────────────────────────────────────────────────
 
+// appendInterfaceHistory records each adapter's speeds and forgets adapters
+// that have disappeared since the last sample.
+func (m *StatusModel) appendInterfaceHistory(ifaces map[string]InterfaceMetrics) {
+	if m.ifaceSendHistory == nil {
+		m.ifaceSendHistory = make(map[string][]uint64)
+		m.ifaceRecvHistory = make(map[string][]uint64)
+	}
+	for name := range m.ifaceSendHistory {
+		if _, ok := ifaces[name]; !ok {
+			delete(m.ifaceSendHistory, name)
+			delete(m.ifaceRecvHistory, name)
+		}
+	}
+	for name, im := range ifaces {
+		m.ifaceSendHistory[name] = appendU64(m.ifaceSendHistory[name], im.SendSpeed, HistoryLen)
+		m.ifaceRecvHistory[name] = appendU64(m.ifaceRecvHistory[name], im.RecvSpeed, HistoryLen)
+	}
+}
+
+// nextInterface cycles "" (all adapters) → names[0] → … → "".
+func nextInterface(names []string, current string) string {
+	if current == "" {
+		if len(names) == 0 {
+			return ""
+		}
+		return names[0]
+	}
+	for i, name := range names {
+		if name == current && i+1 < len(names) {
+			return names[i+1]
+		}
+	}
+	return ""
+}
+
 func appendF64(h []float64, v float64, maxLen int) []float64 {
 	h = append(h, v)
 	if len(h) > maxLen {
@@ -170,3 +545,10 @@ func appendU64(h []uint64, v uint64, maxLen int) []uint64 {
 	}
 	return h
 }
+
+// ringBell sounds the terminal bell. It writes to stderr so the byte never
+// lands in the middle of a frame being drawn on stdout.
+func ringBell() tea.Msg {
+	fmt.Fprint(os.Stderr, "\a")
+	return nil
+}
@@ -0,0 +1,250 @@
+package status
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ---------------------------------------------------------------------------
+// Scripted collector
+// ---------------------------------------------------------------------------
+
+// fakeCollector returns one scripted reading per Collect call and records
+// the previous network reading it was handed.
+type fakeCollector struct {
+	readings []*SystemMetrics
+	prevs    []*NetworkMetrics
+}
+
+func (f *fakeCollector) Collect(prev *NetworkMetrics, _ time.Duration) (*SystemMetrics, error) {
+	f.prevs = append(f.prevs, prev)
+	n := len(f.prevs) - 1
+	if n >= len(f.readings) {
+		return nil, errors.New("no more readings")
+	}
+	return f.readings[n], nil
+}
+
+func (f *fakeCollector) Connections(int) ([]ConnectionInfo, error) {
+	return nil, nil
+}
+
+// reading builds a scripted sample taken at second i.
+func reading(i int, cpu float64, recv uint64) *SystemMetrics {
+	return &SystemMetrics{
+		CollectedAt: time.Unix(int64(i), 0),
+		CPU:         CPUMetrics{TotalPercent: cpu},
+		Network:     NetworkMetrics{RecvSpeed: recv},
+	}
+}
+
+// runCycles feeds n collection rounds through Update.
+func runCycles(t *testing.T, m StatusModel, n int) StatusModel {
+	t.Helper()
+	for range n {
+		updated, _ := m.Update(m.collectMetrics()())
+		m = updated.(StatusModel)
+	}
+	return m
+}
+
+// instantTicker fires every tick immediately on a fake clock.
+type instantTicker struct {
+	now   time.Time
+	ticks int
+}
+
+func (c *instantTicker) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		c.now = c.now.Add(d)
+		c.ticks++
+		return fn(c.now)
+	}
+}
+
+// runLoop drives the model from Init through the tick/collect loop until
+// rounds collections have been handled.
+func runLoop(t *testing.T, m StatusModel, rounds int) StatusModel {
+	t.Helper()
+	queue := []tea.Cmd{m.Init()}
+	for handled := 0; handled < rounds; {
+		if len(queue) == 0 {
+			t.Fatalf("loop stalled after %d rounds", handled)
+		}
+		cmd := queue[0]
+		queue = queue[1:]
+		if cmd == nil {
+			continue
+		}
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			queue = append(queue, batch...)
+			continue
+		}
+		if _, ok := msg.(metricsMsg); ok {
+			handled++
+		}
+		updated, next := m.Update(msg)
+		m = updated.(StatusModel)
+		queue = append(queue, next)
+	}
+	return m
+}
+
+// ---------------------------------------------------------------------------
+// StatusModel tests
+// ---------------------------------------------------------------------------
+
+func TestStatusModel_BuffersHistory(t *testing.T) {
+	fake := &fakeCollector{readings: []*SystemMetrics{
+		reading(0, 10, 100),
+		reading(1, 20, 200),
+		reading(2, 30, 300),
+	}}
+	m := runCycles(t, NewStatusModel(time.Second).WithCollector(fake), 3)
+
+	if got := m.CPUHistory; len(got) != 3 || got[0] != 10 || got[2] != 30 {
+		t.Errorf("CPUHistory = %v, want [10 20 30]", got)
+	}
+	if got := m.NetRecvHistory; len(got) != 3 || got[2] != 300 {
+		t.Errorf("NetRecvHistory = %v, want [100 200 300]", got)
+	}
+	if fake.prevs[0] != nil {
+		t.Error("first round was handed a previous reading")
+	}
+	if fake.prevs[2] != &fake.readings[1].Network {
+		t.Error("third round was not handed the second round's network reading")
+	}
+}
+
+func TestStatusModel_UpdateLoop(t *testing.T) {
+	for _, tc := range []struct {
+		rounds  int
+		wantLen int
+	}{
+		{1, 1},
+		{3, 3},
+		{HistoryLen, HistoryLen},
+		{HistoryLen + 15, HistoryLen},
+	} {
+		fake := &fakeCollector{}
+		for i := range tc.rounds {
+			fake.readings = append(fake.readings, reading(i, float64(i), uint64(i)))
+		}
+		ticker := &instantTicker{}
+		m := NewStatusModel(time.Second).WithCollector(fake).WithTicker(ticker)
+		m = runLoop(t, m, tc.rounds)
+
+		// Init collects once; every later round waits for a tick.
+		if ticker.ticks != tc.rounds-1 {
+			t.Errorf("%d rounds: %d ticks, want %d", tc.rounds, ticker.ticks, tc.rounds-1)
+		}
+		if len(m.CPUHistory) != tc.wantLen || len(m.NetRecvHistory) != tc.wantLen {
+			t.Errorf("%d rounds: history lengths %d/%d, want %d",
+				tc.rounds, len(m.CPUHistory), len(m.NetRecvHistory), tc.wantLen)
+		}
+		if last := m.CPUHistory[len(m.CPUHistory)-1]; last != float64(tc.rounds-1) {
+			t.Errorf("%d rounds: newest CPU reading %v, want %d", tc.rounds, last, tc.rounds-1)
+		}
+	}
+}
+
+func TestStatusModel_Alerts(t *testing.T) {
+	fake := &fakeCollector{readings: []*SystemMetrics{
+		reading(0, 50, 0),
+		reading(1, 95, 0),
+		reading(2, 40, 0),
+	}}
+	m := NewStatusModel(time.Second).
+		WithCollector(fake).
+		WithAlerts(Thresholds{CPU: 90}, false)
+
+	for i, want := range []bool{false, true, false} {
+		m = runCycles(t, m, 1)
+		if got := m.breached[alertCPU]; got != want {
+			t.Errorf("round %d: CPU breached = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestStatusModel_CollectError(t *testing.T) {
+	m := runCycles(t, NewStatusModel(time.Second).WithCollector(&fakeCollector{}), 1)
+	if m.Err == nil {
+		t.Fatal("collector error was not surfaced")
+	}
+	if m.Metrics != nil {
+		t.Error("failed round replaced the metrics")
+	}
+}
+
+func TestStatusModel_FlushNeedsConfirmation(t *testing.T) {
+	flushes := 0
+	m := NewStatusModel(time.Second).WithTab(TabMemory)
+	m.flushStandby = func() error {
+		flushes++
+		return nil
+	}
+	press := func(key string) tea.Cmd {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		if key == "enter" {
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		updated, cmd := m.Update(msg)
+		m = updated.(StatusModel)
+		return cmd
+	}
+
+	// Any key other than Enter cancels.
+	press("f")
+	if !m.confirmFlush {
+		t.Fatal("f on the Memory tab did not ask for confirmation")
+	}
+	if cmd := press("x"); cmd != nil || m.confirmFlush {
+		t.Error("a key other than Enter did not cancel the flush")
+	}
+
+	press("f")
+	cmd := press("enter")
+	if cmd == nil {
+		t.Fatal("Enter did not start the flush")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(StatusModel)
+	if flushes != 1 {
+		t.Errorf("flushed %d times, want 1", flushes)
+	}
+	if m.flushNote != "Standby list flushed" {
+		t.Errorf("flushNote = %q after a successful flush", m.flushNote)
+	}
+
+	// Elsewhere f does nothing.
+	m.Tab = TabCPU
+	press("f")
+	if m.confirmFlush {
+		t.Error("f armed a flush outside the Memory tab")
+	}
+}
+
+func TestStatusModel_RendersEveryTab(t *testing.T) {
+	for tab, name := range TabNames {
+		met := reading(0, 42, 2048)
+		met.TopProcs = []ProcessInfo{{PID: 7, Name: "fake.exe", CPUPct: 42, MemPct: 3}}
+		m := NewStatusModel(time.Second).
+			WithCollector(&fakeCollector{readings: []*SystemMetrics{met}}).
+			WithTab(Tab(tab))
+		m = runCycles(t, m, 1)
+		m.Width, m.Height = 100, 40
+
+		view := m.View()
+		if !strings.Contains(view, name) {
+			t.Errorf("%s tab view lacks its tab label", name)
+		}
+		if Tab(tab) == TabProcesses && !strings.Contains(view, "fake.exe") {
+			t.Errorf("Processes tab view lacks the scripted process")
+		}
+	}
+}
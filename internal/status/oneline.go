@@ -0,0 +1,65 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+)
+
+// ─── Single-line summary ─────────────────────────────────────────────────────
+
+// FormatOneLine renders a compact, single-line summary suitable for
+// embedding in a tmux or Windows Terminal status bar:
+//
+//	CPU 12% | MEM 43% 6.80 GB | ↓1.2 MB/s ↑0.1 MB/s | DSK C: 78%
+//
+// When plain is true, no ANSI styling is emitted.
+func FormatOneLine(m *SystemMetrics, plain bool) string {
+	if m == nil {
+		return ""
+	}
+
+	style := func(s lipgloss.Style, text string) string {
+		if plain {
+			return text
+		}
+		return s.Render(text)
+	}
+	pctStyle := func(pct float64) lipgloss.Style {
+		switch {
+		case pct >= 90:
+			return lipgloss.NewStyle().Foreground(ui.ColorError)
+		case pct >= 70:
+			return lipgloss.NewStyle().Foreground(ui.ColorWarning)
+		default:
+			return textStyle
+		}
+	}
+
+	parts := []string{
+		style(dimStyle, "CPU ") + style(pctStyle(m.CPU.TotalPercent), fmt.Sprintf("%.0f%%", m.CPU.TotalPercent)),
+		style(dimStyle, "MEM ") + style(pctStyle(m.Memory.UsedPercent), fmt.Sprintf("%.0f%%", m.Memory.UsedPercent)) +
+			" " + style(subtleStyle, core.FormatSize(int64(m.Memory.Used))),
+		style(altStyle, "↓") + style(textStyle, formatSpeed(m.Network.RecvSpeed)) + " " +
+			style(accentStyle, "↑") + style(textStyle, formatSpeed(m.Network.SendSpeed)),
+	}
+
+	if len(m.Disk.Partitions) > 0 {
+		var disks []string
+		for _, p := range m.Disk.Partitions {
+			disks = append(disks, fmt.Sprintf("%s %s",
+				strings.TrimRight(p.Path, `\`),
+				style(pctStyle(p.UsedPercent), fmt.Sprintf("%.0f%%", p.UsedPercent))))
+		}
+		parts = append(parts, style(dimStyle, "DSK ")+strings.Join(disks, " "))
+	}
+
+	sep := " | "
+	if !plain {
+		sep = dimStyle.Render(sep)
+	}
+	return strings.Join(parts, sep)
+}
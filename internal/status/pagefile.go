@@ -0,0 +1,72 @@
+package status
+
+import (
+	"strings"
+
+	"github.com/yusufpapurcu/wmi"
+)
+
+// ─── Page files ──────────────────────────────────────────────────────────────
+
+// PageFile describes one page file and how much of it is in use.
+type PageFile struct {
+	Path      string `json:"path"`
+	Allocated uint64 `json:"allocated"` // bytes reserved on disk
+	Used      uint64 `json:"used"`
+	Peak      uint64 `json:"peak"` // most used since boot
+	// SystemManaged means Windows grows and shrinks the file itself rather
+	// than keeping it within a fixed initial and maximum size.
+	SystemManaged bool `json:"system_managed"`
+}
+
+// win32PageFileUsage sizes are in megabytes.
+type win32PageFileUsage struct {
+	Name              string
+	AllocatedBaseSize uint32
+	CurrentUsage      uint32
+	PeakUsage         uint32
+}
+
+// win32PageFileSetting sizes are in megabytes; 0/0 means system-managed.
+// There are no settings at all when every page file is managed
+// automatically.
+type win32PageFileSetting struct {
+	Name        string
+	InitialSize uint32
+	MaximumSize uint32
+}
+
+// CollectPageFiles lists the page files with their usage. Callers should
+// treat an error as "unavailable" rather than fatal.
+func CollectPageFiles() ([]PageFile, error) {
+	var usage []win32PageFileUsage
+	if err := wmi.Query("SELECT Name, AllocatedBaseSize, CurrentUsage, PeakUsage FROM Win32_PageFileUsage", &usage); err != nil {
+		return nil, err
+	}
+	var settings []win32PageFileSetting
+	_ = wmi.Query("SELECT Name, InitialSize, MaximumSize FROM Win32_PageFileSetting", &settings)
+	return mergePageFiles(usage, settings), nil
+}
+
+// mergePageFiles pairs each page file's usage with its size setting.
+func mergePageFiles(usage []win32PageFileUsage, settings []win32PageFileSetting) []PageFile {
+	const mb = 1 << 20
+	files := make([]PageFile, 0, len(usage))
+	for _, u := range usage {
+		managed := true
+		for _, s := range settings {
+			if strings.EqualFold(s.Name, u.Name) {
+				managed = s.InitialSize == 0 && s.MaximumSize == 0
+				break
+			}
+		}
+		files = append(files, PageFile{
+			Path:          u.Name,
+			Allocated:     uint64(u.AllocatedBaseSize) * mb,
+			Used:          uint64(u.CurrentUsage) * mb,
+			Peak:          uint64(u.PeakUsage) * mb,
+			SystemManaged: managed,
+		})
+	}
+	return files
+}
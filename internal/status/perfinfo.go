@@ -0,0 +1,88 @@
+package status
+
+import (
+	"unsafe"
+
+	"github.com/shirou/gopsutil/v4/process"
+	"golang.org/x/sys/windows"
+)
+
+// ─── Process / thread / handle counts ────────────────────────────────────────
+
+var (
+	modPsapi               = windows.NewLazySystemDLL("psapi.dll")
+	procGetPerformanceInfo = modPsapi.NewProc("GetPerformanceInfo")
+)
+
+// performanceInformation mirrors the Windows PERFORMANCE_INFORMATION struct.
+type performanceInformation struct {
+	cb                uint32
+	commitTotal       uintptr
+	commitLimit       uintptr
+	commitPeak        uintptr
+	physicalTotal     uintptr
+	physicalAvailable uintptr
+	systemCache       uintptr
+	kernelTotal       uintptr
+	kernelPaged       uintptr
+	kernelNonpaged    uintptr
+	pageSize          uintptr
+	handleCount       uint32
+	processCount      uint32
+	threadCount       uint32
+}
+
+// SystemCounts holds system-wide object counts. A zero field means the
+// count was unavailable.
+type SystemCounts struct {
+	Processes uint32 `json:"processes"`
+	Threads   uint32 `json:"threads,omitempty"`
+	Handles   uint32 `json:"handles,omitempty"`
+}
+
+// readPerformanceInfo calls GetPerformanceInfo, reporting whether it
+// succeeded.
+func readPerformanceInfo() (performanceInformation, bool) {
+	var pi performanceInformation
+	pi.cb = uint32(unsafe.Sizeof(pi))
+	ret, _, _ := procGetPerformanceInfo.Call(uintptr(unsafe.Pointer(&pi)), uintptr(pi.cb))
+	return pi, ret != 0
+}
+
+// collectSystemCounts reads process, thread, and handle totals from
+// GetPerformanceInfo. If that fails it falls back to counting PIDs, leaving
+// threads and handles unset.
+func collectSystemCounts() SystemCounts {
+	if pi, ok := readPerformanceInfo(); ok {
+		return SystemCounts{
+			Processes: pi.processCount,
+			Threads:   pi.threadCount,
+			Handles:   pi.handleCount,
+		}
+	}
+
+	var c SystemCounts
+	if pids, err := process.Pids(); err == nil {
+		c.Processes = uint32(len(pids))
+	}
+	return c
+}
+
+// ─── Cache and commit charge ─────────────────────────────────────────────────
+
+// collectMemoryPressure fills in the cached (standby) and commit figures,
+// which GetPerformanceInfo reports in pages. They stay zero if the call
+// fails.
+func collectMemoryPressure(m *MemoryMetrics) {
+	pi, ok := readPerformanceInfo()
+	if !ok {
+		return
+	}
+	page := uint64(pi.pageSize)
+	m.Cached = uint64(pi.systemCache) * page
+	m.Committed = uint64(pi.commitTotal) * page
+	m.CommitLimit = uint64(pi.commitLimit) * page
+	if m.CommitLimit > 0 {
+		m.CommitPercent = float64(m.Committed) / float64(m.CommitLimit) * 100
+	}
+}
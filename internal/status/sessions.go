@@ -0,0 +1,79 @@
+package status
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── Logged-in sessions ──────────────────────────────────────────────────────
+
+var (
+	modWtsapi32                     = windows.NewLazySystemDLL("wtsapi32.dll")
+	procWTSQuerySessionInformationW = modWtsapi32.NewProc("WTSQuerySessionInformationW")
+)
+
+// wtsUserName is the WTS_INFO_CLASS value for a session's user name.
+const wtsUserName = 5
+
+// sessionStateNames labels the WTS_CONNECTSTATE_CLASS values worth showing.
+var sessionStateNames = map[uint32]string{
+	windows.WTSActive:       "active",
+	windows.WTSConnected:    "connected",
+	windows.WTSDisconnected: "disconnected",
+	windows.WTSIdle:         "idle",
+}
+
+// SessionInfo describes one logged-in user session.
+type SessionInfo struct {
+	ID      uint32 `json:"id"`
+	User    string `json:"user"`
+	Station string `json:"station"` // e.g. "Console" or "RDP-Tcp#0"
+	State   string `json:"state"`
+}
+
+// CollectSessions lists the sessions that have a user logged in, similar
+// to `quser`. Service and listener sessions are skipped. Callers should
+// treat an error as "unavailable" rather than fatal.
+func CollectSessions() ([]SessionInfo, error) {
+	var infos *windows.WTS_SESSION_INFO
+	var count uint32
+	if err := windows.WTSEnumerateSessions(0, 0, 1, &infos, &count); err != nil {
+		return nil, err
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(infos)))
+
+	var sessions []SessionInfo
+	for _, si := range unsafe.Slice(infos, count) {
+		state, ok := sessionStateNames[si.State]
+		if !ok {
+			continue
+		}
+		user := sessionUser(si.SessionID)
+		if user == "" {
+			continue
+		}
+		sessions = append(sessions, SessionInfo{
+			ID:      si.SessionID,
+			User:    user,
+			Station: windows.UTF16PtrToString(si.WindowStationName),
+			State:   state,
+		})
+	}
+	return sessions, nil
+}
+
+// sessionUser returns the user name logged in to session id, or "" if
+// there is none or it cannot be read.
+func sessionUser(id uint32) string {
+	var buf *uint16
+	var n uint32
+	ret, _, _ := procWTSQuerySessionInformationW.Call(
+		0, uintptr(id), wtsUserName,
+		uintptr(unsafe.Pointer(&buf)), uintptr(unsafe.Pointer(&n)))
+	if ret == 0 || buf == nil {
+		return ""
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(buf)))
+	return windows.UTF16PtrToString(buf)
+}
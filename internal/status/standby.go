@@ -0,0 +1,59 @@
+package status
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"golang.org/x/sys/windows"
+)
+
+// ─── Standby list flush ──────────────────────────────────────────────────────
+
+const (
+	// systemMemoryListInformation is the NtSetSystemInformation class for
+	// the memory page lists; memoryPurgeStandbyList is its command that
+	// empties the standby list.
+	systemMemoryListInformation = 80
+	memoryPurgeStandbyList      = 4
+)
+
+// FlushStandbyList empties the standby list so a benchmark can start from
+// a cold file cache. Nothing is lost: the pages were already free to reuse.
+// It requires administrator privileges.
+func FlushStandbyList() error {
+	if err := core.RequireAdmin("flush standby list"); err != nil {
+		return err
+	}
+	if err := enablePrivilege("SeProfileSingleProcessPrivilege"); err != nil {
+		return fmt.Errorf("cannot enable SeProfileSingleProcessPrivilege: %w", err)
+	}
+	cmd := uint32(memoryPurgeStandbyList)
+	if err := windows.NtSetSystemInformation(systemMemoryListInformation,
+		unsafe.Pointer(&cmd), uint32(unsafe.Sizeof(cmd))); err != nil {
+		return fmt.Errorf("failed to flush standby list: %w", err)
+	}
+	return nil
+}
+
+// enablePrivilege turns on the named privilege in the process token.
+func enablePrivilege(name string) error {
+	var token windows.Token
+	if err := windows.OpenProcessToken(windows.CurrentProcess(),
+		windows.TOKEN_ADJUST_PRIVILEGES|windows.TOKEN_QUERY, &token); err != nil {
+		return err
+	}
+	defer token.Close()
+
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return err
+	}
+	var luid windows.LUID
+	if err := windows.LookupPrivilegeValue(nil, namePtr, &luid); err != nil {
+		return err
+	}
+	tp := windows.Tokenprivileges{PrivilegeCount: 1}
+	tp.Privileges[0] = windows.LUIDAndAttributes{Luid: luid, Attributes: windows.SE_PRIVILEGE_ENABLED}
+	return windows.AdjustTokenPrivileges(token, false, &tp, 0, nil, nil)
+}
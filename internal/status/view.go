@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lakshaymaurya-felt/purewin/internal/core"
@@ -14,13 +15,23 @@ import (
 // Use ui package colors for consistency across all views.
 
 // Module-level style vars (safe: lipgloss styles are immutable copies).
-var (
-	textStyle   = lipgloss.NewStyle().Foreground(ui.ColorText)
-	dimStyle    = lipgloss.NewStyle().Foreground(ui.ColorMuted)
+// They are rebuilt whenever a theme is applied.
+var textStyle, dimStyle, subtleStyle, accentStyle, altStyle, alertStyle lipgloss.Style
+
+func init() {
+	setStyles()
+	ui.OnThemeChange(setStyles)
+}
+
+// setStyles builds the module-level styles from the active palette.
+func setStyles() {
+	textStyle = lipgloss.NewStyle().Foreground(ui.ColorText)
+	dimStyle = lipgloss.NewStyle().Foreground(ui.ColorMuted)
 	subtleStyle = lipgloss.NewStyle().Foreground(ui.ColorTextDim)
 	accentStyle = lipgloss.NewStyle().Foreground(ui.ColorPrimary)
-	altStyle    = lipgloss.NewStyle().Foreground(ui.ColorTeal)
-)
+	altStyle = lipgloss.NewStyle().Foreground(ui.ColorTeal)
+	alertStyle = lipgloss.NewStyle().Foreground(ui.ColorError).Bold(true)
+}
 
 // ─── Top-level renderer ─────────────────────────────────────────────────────
 
@@ -29,6 +40,9 @@ func (m StatusModel) renderView() string {
 	if w < 50 {
 		w = 50
 	}
+	if m.showHelp {
+		return ui.RenderHelp("Status keys", helpGroups, w, m.Height)
+	}
 
 	var s strings.Builder
 	s.WriteString(m.renderTabs(w))
@@ -56,6 +70,9 @@ func (m StatusModel) renderView() string {
 		s.WriteString(m.renderProcesses(w))
 	}
 
+	if m.snapshot {
+		return s.String()
+	}
 	s.WriteString("\n")
 	s.WriteString(m.renderStatusFooter())
 	return s.String()
@@ -64,6 +81,32 @@ func (m StatusModel) renderView() string {
 // ─── Tab bar ─────────────────────────────────────────────────────────────────
 
 func (m StatusModel) renderTabs(w int) string {
+	bar := lipgloss.JoinHorizontal(lipgloss.Bottom, m.renderTabLabels()...)
+	rule := ui.Divider(w)
+
+	return bar + "\n" + rule
+}
+
+// tabAt returns the tab whose label is at screen cell (x, y), for mouse
+// clicks. The labels sit at the top of the view.
+func (m StatusModel) tabAt(x, y int) (Tab, bool) {
+	labels := m.renderTabLabels()
+	if y < 0 || y >= lipgloss.Height(lipgloss.JoinHorizontal(lipgloss.Bottom, labels...)) {
+		return 0, false
+	}
+	left := 0
+	for i, label := range labels {
+		right := left + lipgloss.Width(label)
+		if x >= left && x < right {
+			return Tab(i), true
+		}
+		left = right
+	}
+	return 0, false
+}
+
+// renderTabLabels renders one label per tab, highlighting the active one.
+func (m StatusModel) renderTabLabels() []string {
 	activeTab := lipgloss.NewStyle().
 		Foreground(ui.ColorText).
 		Bold(true).
@@ -88,18 +131,14 @@ func (m StatusModel) renderTabs(w int) string {
 			tabs = append(tabs, inactiveTab.Render(label))
 		}
 	}
-
-	bar := lipgloss.JoinHorizontal(lipgloss.Bottom, tabs...)
-	rule := ui.Divider(w)
-
-	return bar + "\n" + rule
+	return tabs
 }
 
 // ─── Overview tab ────────────────────────────────────────────────────────────
 
 func (m StatusModel) renderOverview(w int) string {
 	met := m.Metrics
-	score := HealthScore(met)
+	score, components := HealthScore(met)
 
 	var s strings.Builder
 	s.WriteString("\n")
@@ -120,6 +159,9 @@ func (m StatusModel) renderOverview(w int) string {
 	s.WriteString(fmt.Sprintf("  %s  %s\n",
 		scoreTag.Render(fmt.Sprintf(" %d ", score)),
 		dimStyle.Render(scoreLabel)))
+	if m.ShowScoreBreakdown {
+		s.WriteString(renderScoreBreakdown(components))
+	}
 	s.WriteString("\n")
 
 	// ── System ──
@@ -129,6 +171,12 @@ func (m StatusModel) renderOverview(w int) string {
 		textStyle.Render(hw.Hostname),
 		dimStyle.Render("·"),
 		subtleStyle.Render(fmt.Sprintf("%s %s", hw.OS, hw.OSVersion)))
+	if hw.Uptime > 0 {
+		hwLine1 += fmt.Sprintf("  %s  %s %s",
+			dimStyle.Render("·"),
+			subtleStyle.Render("Up "+formatUptime(hw.Uptime)),
+			dimStyle.Render("(since "+hw.BootTime.Format("Jan 2 15:04")+")"))
+	}
 	hwLine2Parts := []string{
 		subtleStyle.Render(hw.CPUModel),
 		subtleStyle.Render(fmt.Sprintf("%d cores", hw.CPUCores)),
@@ -141,6 +189,12 @@ func (m StatusModel) renderOverview(w int) string {
 
 	s.WriteString(hwLine1 + "\n")
 	s.WriteString(hwLine2 + "\n")
+	if line := renderCounts(met.Counts); line != "" {
+		s.WriteString(line + "\n")
+	}
+	if line := renderSessions(met.Sessions); line != "" {
+		s.WriteString(line + "\n")
+	}
 
 	if met.Battery.HasBattery {
 		batt := fmt.Sprintf("%d%%", met.Battery.Charge)
@@ -167,7 +221,7 @@ func (m StatusModel) renderOverview(w int) string {
 	}
 
 	// CPU with line graph
-	s.WriteString(renderMetricRow("CPU", met.CPU.TotalPercent, barW, ""))
+	s.WriteString(renderMetricRow("CPU", met.CPU.TotalPercent, barW, "", m.breached[alertCPU]))
 	if len(m.CPUHistory) > 1 {
 		s.WriteString(renderLineGraph(m.CPUHistory, graphW, 6, ui.ColorPrimary, ""))
 	}
@@ -177,7 +231,7 @@ func (m StatusModel) renderOverview(w int) string {
 	s.WriteString(renderMetricRow("MEM", met.Memory.UsedPercent, barW,
 		fmt.Sprintf("%s / %s",
 			core.FormatSize(int64(met.Memory.Used)),
-			core.FormatSize(int64(met.Memory.Total)))))
+			core.FormatSize(int64(met.Memory.Total))), m.breached[alertMemory]))
 	if len(m.MemHistory) > 1 {
 		s.WriteString(renderLineGraph(m.MemHistory, graphW, 6, ui.ColorSecondary, ""))
 	}
@@ -190,10 +244,21 @@ func (m StatusModel) renderOverview(w int) string {
 			fmt.Sprintf("%s / %s  %s",
 				core.FormatSize(int64(p.Used)),
 				core.FormatSize(int64(p.Total)),
-				dimStyle.Render(p.Path))))
+				dimStyle.Render(p.Path)), m.breached[alertDisk+p.Path]))
 		s.WriteString("\n")
 	}
 
+	// Temperature, when the firmware reports one.
+	if met.Temperature > 0 {
+		tempStyle := textStyle
+		if m.breached[alertTemp] {
+			tempStyle = alertStyle
+		}
+		s.WriteString(fmt.Sprintf("  %s  %s\n\n",
+			dimStyle.Render("TMP    "),
+			tempStyle.Render(fmt.Sprintf("%.0f°C", met.Temperature))))
+	}
+
 	// Network
 	dlStyle := lipgloss.NewStyle().Foreground(ui.ColorTeal)
 	ulStyle := lipgloss.NewStyle().Foreground(ui.ColorAccent)
@@ -209,17 +274,108 @@ func (m StatusModel) renderOverview(w int) string {
 	if len(m.NetRecvHistory) > 1 {
 		s.WriteString(fmt.Sprintf("  %s  %s  %s\n",
 			dimStyle.Render("       "),
-			renderSparklineU64(m.NetRecvHistory, graphW/2, ui.ColorTeal),
-			renderSparklineU64(m.NetSendHistory, graphW/2, ui.ColorAccent)))
+			renderSparklineU64(m.NetRecvHistory, graphW/2, ui.ColorTeal, sparkOpts{}),
+			renderSparklineU64(m.NetSendHistory, graphW/2, ui.ColorAccent, sparkOpts{})))
 	}
 
 	return s.String()
 }
 
-// renderMetricRow renders a single metric: label + bar + percent + optional detail.
-func renderMetricRow(label string, pct float64, barW int, detail string) string {
-	bar := ui.GradientBar(pct, barW)
+// renderCounts renders "Processes 312 · Threads 4,102 · Handles 98,441",
+// omitting any count that could not be read.
+func renderCounts(c SystemCounts) string {
+	var parts []string
+	for _, f := range []struct {
+		label string
+		n     uint32
+	}{
+		{"Processes", c.Processes},
+		{"Threads", c.Threads},
+		{"Handles", c.Handles},
+	} {
+		if f.n > 0 {
+			parts = append(parts, dimStyle.Render(f.label)+" "+subtleStyle.Render(formatThousands(uint64(f.n))))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(parts, dimStyle.Render("  ·  "))
+}
+
+// renderSessions renders "Users alice active · bob disconnected", or ""
+// when no session list is available.
+func renderSessions(sessions []SessionInfo) string {
+	if len(sessions) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(sessions))
+	for _, si := range sessions {
+		state := subtleStyle.Render(si.State)
+		if si.State == "active" {
+			state = textStyle.Render(si.State)
+		}
+		parts = append(parts, subtleStyle.Render(si.User)+" "+state)
+	}
+	return "  " + dimStyle.Render("Users") + " " + strings.Join(parts, dimStyle.Render("  ·  "))
+}
+
+// formatUptime renders d as its two largest units, e.g. "3d 14h", "2h 5m",
+// or "12m".
+func formatUptime(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	mins := int(d/time.Minute) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
+
+// formatThousands formats n with comma thousands separators.
+func formatThousands(n uint64) string {
+	digits := fmt.Sprintf("%d", n)
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// renderScoreBreakdown lists the deduction each resource contributed to the
+// health score, e.g. "Memory   -15 / 25   82.4% used".
+func renderScoreBreakdown(components []ScoreComponent) string {
+	var s strings.Builder
+	s.WriteString("\n")
+	for _, c := range components {
+		pts := subtleStyle.Render(fmt.Sprintf("%4d", -c.Points))
+		if c.Points > 0 {
+			pts = lipgloss.NewStyle().Foreground(ui.ColorWarning).Render(fmt.Sprintf("%4d", -c.Points))
+		}
+		s.WriteString(fmt.Sprintf("  %s %s %s  %s\n",
+			dimStyle.Render(fmt.Sprintf("%-8s", c.Name)),
+			pts,
+			dimStyle.Render(fmt.Sprintf("/ %-3d", c.Max)),
+			subtleStyle.Render(c.Detail)))
+	}
+	return s.String()
+}
+
+// renderMetricRow renders a single metric: label + bar + percent + optional
+// detail. A breached metric is drawn in the alert color whatever its level.
+func renderMetricRow(label string, pct float64, barW int, detail string, breached bool) string {
+	bar := metricBar(pct, barW, breached)
 	pctStr := textStyle.Render(fmt.Sprintf("%5.1f%%", pct))
+	if breached {
+		pctStr = alertStyle.Render(fmt.Sprintf("%5.1f%%", pct))
+	}
 
 	line := fmt.Sprintf("  %s  %s  %s",
 		dimStyle.Render(fmt.Sprintf("%-7s", label)),
@@ -233,6 +389,14 @@ func renderMetricRow(label string, pct float64, barW int, detail string) string
 	return line + "\n"
 }
 
+// metricBar is ui.GradientBar, forced to the alert color when breached.
+func metricBar(pct float64, barW int, breached bool) string {
+	if breached {
+		return ui.SolidBar(pct, barW, ui.ColorError)
+	}
+	return ui.GradientBar(pct, barW)
+}
+
 // ─── CPU tab ─────────────────────────────────────────────────────────────────
 
 func (m StatusModel) renderCPU(w int) string {
@@ -250,7 +414,7 @@ func (m StatusModel) renderCPU(w int) string {
 	totalLabel := accentStyle.Bold(true).Render("CPU")
 	totalPct := textStyle.Render(fmt.Sprintf("%5.1f%%", met.CPU.TotalPercent))
 	lines = append(lines,
-		fmt.Sprintf("  %s  %s  %s", totalLabel, ui.GradientBar(met.CPU.TotalPercent, barW), totalPct))
+		fmt.Sprintf("  %s  %s  %s", totalLabel, metricBar(met.CPU.TotalPercent, barW, m.breached[alertCPU]), totalPct))
 	lines = append(lines, "")
 
 	// Line graph history.
@@ -293,7 +457,7 @@ func (m StatusModel) renderMemory(w int) string {
 	lines = append(lines,
 		fmt.Sprintf("  %s  %s  %s",
 			ml.Bold(true).Render("Used      "),
-			ui.GradientBar(met.Memory.UsedPercent, barW),
+			metricBar(met.Memory.UsedPercent, barW, m.breached[alertMemory]),
 			mp.Render(fmt.Sprintf("%5.1f%%", met.Memory.UsedPercent))))
 	lines = append(lines, "")
 
@@ -309,6 +473,30 @@ func (m StatusModel) renderMemory(w int) string {
 		fmt.Sprintf("  %s  %s", ml.Render("Available "), mv.Render(core.FormatSize(int64(met.Memory.Available)))))
 	lines = append(lines,
 		fmt.Sprintf("  %s  %s", ml.Render("Free      "), mv.Render(core.FormatSize(int64(met.Memory.Free)))))
+	if met.Memory.Cached > 0 {
+		// Standby pages count as available, which is why Available can
+		// stay high while Used looks high too.
+		lines = append(lines,
+			fmt.Sprintf("  %s  %s  %s", ml.Render("Cached    "),
+				mv.Render(core.FormatSize(int64(met.Memory.Cached))),
+				subtleStyle.Render("standby, reusable on demand")))
+	}
+
+	if met.Memory.CommitLimit > 0 {
+		lines = append(lines, "")
+		// ── Commit ──
+		lines = append(lines, "  "+ui.SectionHeader("Commit", barW+20))
+		lines = append(lines,
+			fmt.Sprintf("  %s  %s  %s",
+				ml.Bold(true).Render("Charged   "),
+				ui.GradientBar(met.Memory.CommitPercent, barW),
+				mp.Render(fmt.Sprintf("%5.1f%%", met.Memory.CommitPercent))))
+		lines = append(lines,
+			fmt.Sprintf("  %s  %s / %s",
+				ml.Render("Committed "),
+				mv.Render(core.FormatSize(int64(met.Memory.Committed))),
+				mv.Render(core.FormatSize(int64(met.Memory.CommitLimit)))))
+	}
 
 	if met.Memory.SwapTotal > 0 {
 		lines = append(lines, "")
@@ -324,11 +512,37 @@ func (m StatusModel) renderMemory(w int) string {
 				ml.Render("Swap Used "),
 				mv.Render(core.FormatSize(int64(met.Memory.SwapUsed))),
 				mv.Render(core.FormatSize(int64(met.Memory.SwapTotal)))))
+		for _, pf := range met.PageFiles {
+			lines = append(lines, renderPageFile(pf, ml, mv))
+		}
+	}
+
+	switch {
+	case m.confirmFlush:
+		lines = append(lines, "", alertStyle.Render(
+			"  "+ui.IconWarning+" Flush the standby list? Cached file data will be re-read from disk. Press Enter to confirm"))
+	case m.flushNote != "":
+		lines = append(lines, "", subtleStyle.Render("  "+m.flushNote))
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// renderPageFile shows where a page file lives and how much of it is used,
+// e.g. "C:\pagefile.sys  1.20 GB / 4.00 GB  peak 2.10 GB  system-managed".
+func renderPageFile(pf PageFile, label, value lipgloss.Style) string {
+	sizing := "fixed size"
+	if pf.SystemManaged {
+		sizing = "system-managed"
+	}
+	return fmt.Sprintf("  %s  %s / %s  %s  %s",
+		label.Render(ui.PadRight(ui.Truncate(pf.Path, 30), 10)),
+		value.Render(core.FormatSize(int64(pf.Used))),
+		value.Render(core.FormatSize(int64(pf.Allocated))),
+		subtleStyle.Render("peak "+core.FormatSize(int64(pf.Peak))),
+		subtleStyle.Render(sizing))
+}
+
 // ─── Disk tab ────────────────────────────────────────────────────────────────
 
 func (m StatusModel) renderDisk(w int) string {
@@ -349,7 +563,7 @@ func (m StatusModel) renderDisk(w int) string {
 		lines = append(lines,
 			fmt.Sprintf("  %s %s  %s  %s / %s",
 				dl.Render(fmt.Sprintf("%-4s", p.Path)),
-				ui.GradientBar(p.UsedPercent, barW),
+				metricBar(p.UsedPercent, barW, m.breached[alertDisk+p.Path]),
 				dp.Render(fmt.Sprintf("%5.1f%%", p.UsedPercent)),
 				dv.Render(core.FormatSize(int64(p.Used))),
 				dv.Render(core.FormatSize(int64(p.Total)))))
@@ -360,8 +574,23 @@ func (m StatusModel) renderDisk(w int) string {
 	wrLabel := lipgloss.NewStyle().Foreground(ui.ColorWarning).Render(ui.IconArrow + " Write")
 	lines = append(lines,
 		fmt.Sprintf("  %s   %s   %s  %s",
-			rdLabel, dv.Render(core.FormatSize(int64(met.Disk.ReadBytes))),
-			wrLabel, dv.Render(core.FormatSize(int64(met.Disk.WriteBytes)))))
+			rdLabel, textStyle.Render(formatSpeed(met.Disk.ReadSpeed)),
+			wrLabel, textStyle.Render(formatSpeed(met.Disk.WriteSpeed))))
+	lines = append(lines,
+		fmt.Sprintf("  %s  %s   %s  %s",
+			dimStyle.Render("Total Read"), dv.Render(core.FormatSize(int64(met.Disk.ReadBytes))),
+			dimStyle.Render("Total Written"), dv.Render(core.FormatSize(int64(met.Disk.WriteBytes)))))
+
+	// Sparklines.
+	if len(m.DiskReadHist) > 1 {
+		lines = append(lines, "")
+		lines = append(lines,
+			lipgloss.NewStyle().Foreground(ui.ColorTeal).Render("  "+ui.IconArrow+" ")+
+				renderSparklineU64(m.DiskReadHist, 30, ui.ColorTeal, sparkSpeed))
+		lines = append(lines,
+			lipgloss.NewStyle().Foreground(ui.ColorWarning).Render("  "+ui.IconArrow+" ")+
+				renderSparklineU64(m.DiskWriteHist, 30, ui.ColorWarning, sparkSpeed))
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -373,36 +602,179 @@ func (m StatusModel) renderNetwork(w int) string {
 	dlStyle := lipgloss.NewStyle().Foreground(ui.ColorTeal)
 	ulStyle := lipgloss.NewStyle().Foreground(ui.ColorAccent)
 
+	// Aggregate by default; a selected adapter that has gone away (e.g. a
+	// disconnected VPN) shows as zero until the user cycles past it.
+	sel := InterfaceMetrics{
+		BytesSent: met.Network.BytesSent,
+		BytesRecv: met.Network.BytesRecv,
+		SendSpeed: met.Network.SendSpeed,
+		RecvSpeed: met.Network.RecvSpeed,
+	}
+	ifaceLabel := "All adapters"
+	sendHist, recvHist := m.NetSendHistory, m.NetRecvHistory
+	if m.NetIface != "" {
+		sel = met.Network.Interfaces[m.NetIface]
+		ifaceLabel = m.NetIface
+		if _, ok := met.Network.Interfaces[m.NetIface]; !ok {
+			ifaceLabel += " (disconnected)"
+		}
+		sendHist, recvHist = m.ifaceSendHistory[m.NetIface], m.ifaceRecvHistory[m.NetIface]
+	}
+
 	var lines []string
 	lines = append(lines, "")
+	lines = append(lines,
+		fmt.Sprintf("  %s  %s  %s", dimStyle.Render("Interface"),
+			textStyle.Render(ifaceLabel), dimStyle.Render("(i to cycle)")))
+	lines = append(lines, "")
 
 	lines = append(lines,
 		fmt.Sprintf("  %s %s  %s",
 			dlStyle.Render(ui.IconArrow), dlStyle.Render("Download"),
-			textStyle.Render(formatSpeed(met.Network.RecvSpeed))))
+			textStyle.Render(formatSpeed(sel.RecvSpeed))))
 	lines = append(lines,
 		fmt.Sprintf("  %s %s    %s",
 			ulStyle.Render(ui.IconArrow), ulStyle.Render("Upload"),
-			textStyle.Render(formatSpeed(met.Network.SendSpeed))))
+			textStyle.Render(formatSpeed(sel.SendSpeed))))
 
 	lines = append(lines, "")
 	lines = append(lines,
-		fmt.Sprintf("  %s  %s", dimStyle.Render("Total Recv"), subtleStyle.Render(core.FormatSize(int64(met.Network.BytesRecv)))))
+		fmt.Sprintf("  %s  %s", dimStyle.Render("Total Recv"), subtleStyle.Render(core.FormatSize(int64(sel.BytesRecv)))))
 	lines = append(lines,
-		fmt.Sprintf("  %s  %s", dimStyle.Render("Total Sent"), subtleStyle.Render(core.FormatSize(int64(met.Network.BytesSent)))))
+		fmt.Sprintf("  %s  %s", dimStyle.Render("Total Sent"), subtleStyle.Render(core.FormatSize(int64(sel.BytesSent)))))
 
-	// Sparklines.
-	if len(m.NetRecvHistory) > 1 {
+	// Sparklines, or full charts in graph mode.
+	switch {
+	case len(recvHist) <= 1:
+	case m.NetGraph:
+		graphW := max(min(w-16, HistoryLen), 10) // one column per reading
+		lines = append(lines, "")
+		lines = append(lines, m.renderNetGraph("Download", recvHist, graphW, dlStyle, ui.ColorTeal)...)
+		lines = append(lines, "")
+		lines = append(lines, m.renderNetGraph("Upload", sendHist, graphW, ulStyle, ui.ColorAccent)...)
+	default:
 		lines = append(lines, "")
 		lines = append(lines,
-			dlStyle.Render("  "+ui.IconArrow+" ")+renderSparklineU64(m.NetRecvHistory, 30, ui.ColorTeal))
+			dlStyle.Render("  "+ui.IconArrow+" ")+renderSparklineU64(recvHist, 30, ui.ColorTeal, sparkSpeed))
 		lines = append(lines,
-			ulStyle.Render("  "+ui.IconArrow+" ")+renderSparklineU64(m.NetSendHistory, 30, ui.ColorAccent))
+			ulStyle.Render("  "+ui.IconArrow+" ")+renderSparklineU64(sendHist, 30, ui.ColorAccent, sparkSpeed))
 	}
 
+	lines = append(lines, "")
+	lines = append(lines, "  "+ui.SectionHeader("TCP Connections", w-4))
+	lines = append(lines, "")
+	lines = append(lines, m.renderConnections(w, len(lines))...)
+
 	return strings.Join(lines, "\n")
 }
 
+// netGraphRows is the height of each Network tab chart in graph mode.
+const netGraphRows = 5
+
+// renderNetGraph draws one labeled bandwidth chart: a title with the peak
+// rate, the chart with a y-axis scaled to that peak, and a time axis
+// spanning the visible readings. The time axis is left off until there are
+// two readings to span, as with status --once.
+func (m StatusModel) renderNetGraph(title string, data []uint64, width int, style lipgloss.Style, color lipgloss.AdaptiveColor) []string {
+	if len(data) > width {
+		data = data[len(data)-width:]
+	}
+	var peak uint64
+	for _, v := range data {
+		peak = max(peak, v)
+	}
+
+	const axisW = 10
+	lines := []string{fmt.Sprintf("  %s %s  %s",
+		style.Render(ui.IconArrow), style.Render(title),
+		dimStyle.Render("peak "+formatSpeed(peak)))}
+
+	chart := renderBlockChart(data, width, netGraphRows)
+	chartStyle := lipgloss.NewStyle().Foreground(color)
+	for i, row := range chart {
+		label := ""
+		switch i {
+		case 0:
+			label = formatSpeed(peak)
+		case len(chart) - 1:
+			label = "0"
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s%s",
+			dimStyle.Render(fmt.Sprintf("%*s", axisW, label)),
+			subtleStyle.Render("│"), chartStyle.Render(row)))
+	}
+	lines = append(lines, fmt.Sprintf("  %*s %s", axisW, "",
+		subtleStyle.Render("└"+strings.Repeat("─", width))))
+
+	span := time.Duration(len(data)-1) * m.refreshInterval
+	if span <= 0 {
+		return lines
+	}
+	// Readings fill the chart from the right, so "ago" sits under the
+	// oldest one when there is room.
+	ago := formatWindow(span) + " ago"
+	start := max(0, min(width-len(data), width-len(ago)-6))
+	lines = append(lines, fmt.Sprintf("  %*s  %s", axisW, "",
+		dimStyle.Render(strings.Repeat(" ", start)+ago+fmt.Sprintf("%*s", max(width-start-len(ago), 6), "now"))))
+	return lines
+}
+
+// formatWindow renders a chart's time span, e.g. "60s" or "5m".
+func formatWindow(d time.Duration) string {
+	if d < 2*time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// renderConnections renders the scrollable connection table into whatever
+// height is left after the used lines above it.
+func (m StatusModel) renderConnections(w, used int) []string {
+	conns := m.Metrics.Connections
+	if len(conns) == 0 {
+		return []string{dimStyle.Italic(true).Render("  (collecting connections…)")}
+	}
+
+	procW := 18
+	addrW := 24
+	if w > 110 {
+		procW, addrW = 24, 30
+	}
+
+	lines := []string{
+		dimStyle.Render(fmt.Sprintf("  %-6s %-*s %-*s %-*s %s",
+			"PID", procW, "Process", addrW, "Local", addrW, "Remote", "State")),
+		"  " + ui.Divider(w-4),
+	}
+
+	// Header, tab bar, and footer take roughly 8 lines.
+	rows := m.Height - used - len(lines) - 8
+	if rows < 3 {
+		rows = 3
+	}
+	end := m.connOffset + rows
+	if end > len(conns) {
+		end = len(conns)
+	}
+
+	for _, c := range conns[m.connOffset:end] {
+		state := subtleStyle.Render(c.State)
+		if c.State == "ESTABLISHED" {
+			state = textStyle.Render(c.State)
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s %s %s %s",
+			subtleStyle.Render(fmt.Sprintf("%-6d", c.PID)),
+			textStyle.Render(ui.PadRight(ui.Truncate(c.Process, procW), procW)),
+			subtleStyle.Render(ui.PadRight(ui.Truncate(c.Local, addrW), addrW)),
+			textStyle.Render(ui.PadRight(ui.Truncate(c.Remote, addrW), addrW)),
+			state))
+	}
+
+	lines = append(lines, dimStyle.Render(fmt.Sprintf(
+		"  %d–%d of %d (top %d)", m.connOffset+1, end, len(conns), maxConnections)))
+	return lines
+}
+
 // ─── Processes tab ───────────────────────────────────────────────────────────
 
 func (m StatusModel) renderProcesses(w int) string {
@@ -414,49 +786,128 @@ func (m StatusModel) renderProcesses(w int) string {
 
 	var lines []string
 	lines = append(lines, "")
-	lines = append(lines, "  "+ui.SectionHeader("Top Processes", w-4))
+	lines = append(lines, "  "+ui.SectionHeader("Top Processes by "+ProcSortNames[m.SortProcs], w-4))
 	lines = append(lines, "")
+	if m.filtering || m.procFilter != "" {
+		lines = append(lines, m.renderProcFilter(), "")
+	}
 
 	nameW := 22
 	if w > 100 {
 		nameW = 30
 	}
 
-	header := fmt.Sprintf("  %-6s %-*s %s  %6s  %6s", "PID", nameW, "Name", strings.Repeat(" ", barW), "CPU%", "Mem%")
+	// Grouped rows show the instance count where the PID would be.
+	type procRow struct {
+		id     string
+		name   string
+		cpuPct float64
+		memPct float32
+	}
+	var rows []procRow
+	idHeader := "PID"
+	if m.GroupProcs {
+		idHeader = "Count"
+		// Copy before sorting; the filters return the metrics slice as-is
+		// when there is no query.
+		groups := append([]ProcessGroup(nil), FilterProcessGroups(met.ProcGroups, m.procFilter)...)
+		SortProcessGroups(groups, m.SortProcs)
+		for _, g := range groups {
+			rows = append(rows, procRow{fmt.Sprintf("×%d", g.Count), g.Name, g.CPUPct, g.MemPct})
+		}
+	} else {
+		procs := append([]ProcessInfo(nil), FilterProcesses(met.TopProcs, m.procFilter)...)
+		SortProcesses(procs, m.SortProcs)
+		for _, p := range procs {
+			rows = append(rows, procRow{fmt.Sprintf("%d", p.PID), p.Name, p.CPUPct, p.MemPct})
+		}
+	}
+
+	header := fmt.Sprintf("  %-6s %-*s %s  %6s  %6s", idHeader, nameW, "Name", strings.Repeat(" ", barW), "CPU%", "Mem%")
 	lines = append(lines, dimStyle.Render(header))
 	lines = append(lines, "  "+ui.Divider(w-4))
 
-	for _, p := range met.TopProcs {
-		name := p.Name
-		if len(name) > nameW {
-			name = name[:nameW-1] + "…"
-		}
-		cpuClamp := p.CPUPct
+	// Header, tab bar, and footer take roughly 8 lines.
+	maxRows := m.Height - len(lines) - 8
+	if maxRows < 5 {
+		maxRows = 5
+	}
+	shown := rows
+	if len(shown) > maxRows {
+		shown = shown[:maxRows]
+	}
+
+	for _, r := range shown {
+		name := ui.PadRight(ui.Truncate(r.name, nameW), nameW)
+		// CPU% is per core, so a process or group can exceed 100%.
+		cpuClamp := r.cpuPct
 		if cpuClamp > 100 {
 			cpuClamp = 100
 		}
 		bar := ui.GradientBar(cpuClamp, barW)
 		lines = append(lines,
 			fmt.Sprintf("  %s %s %s  %s  %s",
-				subtleStyle.Render(fmt.Sprintf("%-6d", p.PID)),
-				textStyle.Render(fmt.Sprintf("%-*s", nameW, name)),
+				subtleStyle.Render(fmt.Sprintf("%-6s", r.id)),
+				textStyle.Render(name),
 				bar,
-				textStyle.Render(fmt.Sprintf("%5.1f%%", p.CPUPct)),
-				subtleStyle.Render(fmt.Sprintf("%5.1f%%", p.MemPct))))
+				textStyle.Render(fmt.Sprintf("%5.1f%%", r.cpuPct)),
+				subtleStyle.Render(fmt.Sprintf("%5.1f%%", r.memPct))))
 	}
 
-	if len(met.TopProcs) == 0 {
+	switch {
+	case len(met.TopProcs) == 0:
 		lines = append(lines,
 			dimStyle.Italic(true).Render("  (no process data yet)"))
+	case len(rows) == 0:
+		lines = append(lines,
+			dimStyle.Italic(true).Render(fmt.Sprintf("  (no processes match %q)", m.procFilter)))
+	case len(shown) < len(rows):
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  %d of %d shown", len(shown), len(rows))))
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// renderProcFilter draws the Processes tab filter input.
+func (m StatusModel) renderProcFilter() string {
+	prompt := lipgloss.NewStyle().
+		Foreground(ui.ColorCoral).
+		Bold(true).
+		Render("  / ")
+	query := lipgloss.NewStyle().
+		Foreground(ui.ColorText).
+		Render(m.procFilter)
+	if !m.filtering {
+		return prompt + query
+	}
+	cursor := lipgloss.NewStyle().
+		Foreground(ui.ColorCoral).
+		Render("▎")
+	return prompt + query + cursor
+}
+
 // ─── Footer ──────────────────────────────────────────────────────────────────
 
 func (m StatusModel) renderStatusFooter() string {
-	hints := "  Tab/Shift-Tab switch  " + ui.IconPipe + "  1-6 jump  " + ui.IconPipe + "  q quit"
+	keys := []ui.KeyBinding{keySwitch, keyJump}
+	switch m.Tab {
+	case TabOverview:
+		keys = append(keys, keyScore)
+	case TabMemory:
+		keys = append(keys, keyFlush)
+	case TabNetwork:
+		keys = append(keys, keyAdapter, keyGraph, keyScroll)
+	case TabProcesses:
+		keys = append(keys, keySort, keyGroup, keyFilter)
+	}
+	keys = append(keys, ui.KeyHelp, keyQuit)
+	if m.filtering {
+		keys = []ui.KeyBinding{keyFilterType, keyFilterKeep, keyFilterEsc}
+	}
+	if m.confirmFlush {
+		keys = []ui.KeyBinding{keyFlushOK, keyFlushEsc}
+	}
+	hints := "  " + strings.Join(ui.Hints(keys...), "  "+ui.IconPipe+"  ")
 	footer := ui.HintBarStyle().Render(hints)
 
 	if m.Err != nil {
@@ -470,22 +921,41 @@ func (m StatusModel) renderStatusFooter() string {
 
 // ─── Drawing primitives ─────────────────────────────────────────────────────
 
+// sparkOpts are the opt-in extras for the sparkline renderers. The zero
+// value draws a bare sparkline for places where space is tight.
+type sparkOpts struct {
+	// peak formats the window's largest reading as a suffix label so the
+	// scale can be read; nil omits the label.
+	peak func(float64) string
+	// markLast draws the newest reading in bold text color.
+	markLast bool
+}
+
+// sparkSpeed labels a sparkline of bytes/sec readings. CPU and memory are
+// drawn with renderLineGraph, whose axis already reads in percent, so
+// speeds are the only sparklines that need a peak label.
+var sparkSpeed = sparkOpts{
+	peak:     func(v float64) string { return formatSpeed(uint64(v)) },
+	markLast: true,
+}
+
 // renderSparkline renders a mini chart from float64 data using block chars.
-func renderSparkline(data []float64, width int, color lipgloss.AdaptiveColor) string {
+func renderSparkline(data []float64, width int, color lipgloss.AdaptiveColor, opts sparkOpts) string {
 	blocks := []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+	d := data
+	if len(d) > width {
+		d = d[len(d)-width:]
+	}
 	var maxVal float64
-	for _, v := range data {
+	for _, v := range d {
 		if v > maxVal {
 			maxVal = v
 		}
 	}
+	peak := maxVal
 	if maxVal == 0 {
 		maxVal = 1
 	}
-	d := data
-	if len(d) > width {
-		d = d[len(d)-width:]
-	}
 	var b strings.Builder
 	for _, v := range d {
 		idx := int(v / maxVal * 7)
@@ -497,43 +967,68 @@ func renderSparkline(data []float64, width int, color lipgloss.AdaptiveColor) st
 		}
 		b.WriteRune(blocks[idx])
 	}
-	for i := len(d); i < width; i++ {
-		b.WriteRune(blocks[0])
+	bar := []rune(b.String())
+
+	style := lipgloss.NewStyle().Foreground(color)
+	var out string
+	if opts.markLast && len(bar) > 0 {
+		last := lipgloss.NewStyle().Foreground(ui.ColorText).Bold(true)
+		out = style.Render(string(bar[:len(bar)-1])) + last.Render(string(bar[len(bar)-1]))
+	} else {
+		out = style.Render(string(bar))
+	}
+	if pad := width - len(d); pad > 0 {
+		out += style.Render(strings.Repeat(string(blocks[0]), pad))
 	}
-	return lipgloss.NewStyle().Foreground(color).Render(b.String())
+	if opts.peak != nil {
+		out += dimStyle.Render(" peak " + opts.peak(peak))
+	}
+	return out
 }
 
-// renderSparklineU64 renders a mini chart from uint64 data.
-func renderSparklineU64(data []uint64, width int, color lipgloss.AdaptiveColor) string {
-	blocks := []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+// renderBlockChart renders data as a bar chart height rows tall and width
+// columns wide, scaled so the largest value fills the chart. Each row is a
+// plain string, top row first; the newest reading sits in the rightmost
+// column and columns without data are blank.
+func renderBlockChart(data []uint64, width, height int) []string {
+	blocks := []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+	if len(data) > width {
+		data = data[len(data)-width:]
+	}
 	var maxVal uint64
 	for _, v := range data {
-		if v > maxVal {
-			maxVal = v
-		}
+		maxVal = max(maxVal, v)
 	}
 	if maxVal == 0 {
 		maxVal = 1
 	}
-	d := data
-	if len(d) > width {
-		d = d[len(d)-width:]
+
+	// Each column's height in eighths of a row.
+	levels := make([]int, width)
+	pad := width - len(data)
+	for i, v := range data {
+		levels[pad+i] = int(float64(v) / float64(maxVal) * float64(height*8))
 	}
-	var b strings.Builder
-	for _, v := range d {
-		idx := int(float64(v) / float64(maxVal) * 7)
-		if idx > 7 {
-			idx = 7
-		}
-		if idx < 0 {
-			idx = 0
+
+	rows := make([]string, height)
+	for r := range rows {
+		base := (height - 1 - r) * 8
+		var b strings.Builder
+		for _, lvl := range levels {
+			b.WriteRune(blocks[min(max(lvl-base, 0), 8)])
 		}
-		b.WriteRune(blocks[idx])
+		rows[r] = b.String()
 	}
-	for i := len(d); i < width; i++ {
-		b.WriteRune(blocks[0])
+	return rows
+}
+
+// renderSparklineU64 renders a mini chart from uint64 data.
+func renderSparklineU64(data []uint64, width int, color lipgloss.AdaptiveColor, opts sparkOpts) string {
+	f := make([]float64, len(data))
+	for i, v := range data {
+		f[i] = float64(v)
 	}
-	return lipgloss.NewStyle().Foreground(color).Render(b.String())
+	return renderSparkline(f, width, color, opts)
 }
 
 // formatSpeed returns a human-readable bytes/sec string.
@@ -0,0 +1,254 @@
+package status
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/")
+
+// ---------------------------------------------------------------------------
+// Fixture
+// ---------------------------------------------------------------------------
+
+const (
+	gib = 1 << 30
+	mib = 1 << 20
+)
+
+// goldenMetrics returns the i-th reading of a fixed machine whose CPU,
+// network, and disk activity climb a little each second.
+func goldenMetrics(i int) *SystemMetrics {
+	step := uint64(i)
+	return &SystemMetrics{
+		CollectedAt: time.Date(2025, 3, 14, 9, 30, i, 0, time.UTC),
+		CPU: CPUMetrics{
+			TotalPercent: 20 + 10*float64(i),
+			PerCore:      []float64{12.5, 48, 91.2, 30},
+			CoreCount:    4,
+			ModelName:    "Fake Core i5-1145G7",
+		},
+		Memory: MemoryMetrics{
+			Total:       16 * gib,
+			Used:        10 * gib,
+			Available:   6 * gib,
+			Free:        5 * gib,
+			UsedPercent: 62.5,
+			SwapTotal:   4 * gib,
+			SwapUsed:    1 * gib,
+			SwapPercent: 25,
+
+			Cached:        4 * gib,
+			Committed:     14 * gib,
+			CommitLimit:   20 * gib,
+			CommitPercent: 70,
+		},
+		Disk: DiskMetrics{
+			Partitions: []DiskPartition{
+				{Path: `C:\`, Total: 512 * gib, Used: 480 * gib, Free: 32 * gib, UsedPercent: 93.75},
+				{Path: `D:\`, Total: 1024 * gib, Used: 256 * gib, Free: 768 * gib, UsedPercent: 25},
+			},
+			ReadBytes:  step * 40 * mib,
+			WriteBytes: step * 8 * mib,
+		},
+		Network: NetworkMetrics{
+			BytesSent: 300*mib + step*mib,
+			BytesRecv: 2*gib + step*5*mib,
+			SendSpeed: step * 256 * 1024,
+			RecvSpeed: step * 5 * mib,
+			Interfaces: map[string]InterfaceMetrics{
+				"Ethernet": {BytesSent: 300 * mib, BytesRecv: 2 * gib, SendSpeed: step * 256 * 1024, RecvSpeed: step * 5 * mib},
+				"Wi-Fi":    {},
+			},
+		},
+		TopProcs: []ProcessInfo{
+			{PID: 4120, Name: "chrome.exe", CPUPct: 18.4, MemPct: 9.1},
+			{PID: 4388, Name: "chrome.exe", CPUPct: 6.2, MemPct: 4.3},
+			{PID: 912, Name: "Code.exe", CPUPct: 11.7, MemPct: 7.8},
+			{PID: 1337, Name: "pw.exe", CPUPct: 0.4, MemPct: 0.2},
+		},
+		ProcGroups: []ProcessGroup{
+			{Name: "chrome.exe", Count: 2, CPUPct: 24.6, MemPct: 13.4},
+			{Name: "Code.exe", Count: 1, CPUPct: 11.7, MemPct: 7.8},
+			{Name: "pw.exe", Count: 1, CPUPct: 0.4, MemPct: 0.2},
+		},
+		Counts: SystemCounts{Processes: 312, Threads: 4102, Handles: 98441},
+		PageFiles: []PageFile{
+			{Path: `C:\pagefile.sys`, Allocated: 4 * gib, Used: 1 * gib, Peak: 2 * gib, SystemManaged: true},
+			{Path: `E:\pagefile.sys`, Allocated: 2 * gib, Used: 0, Peak: 512 * mib},
+		},
+		Sessions: []SessionInfo{
+			{ID: 1, User: "alice", Station: "Console", State: "active"},
+		},
+		GPU:     GPUInfo{Name: "Fake Iris Xe", AdapterRAM: 1 * gib},
+		Battery: BatteryInfo{HasBattery: true, Charge: 81, IsCharging: true},
+		Hardware: HardwareInfo{
+			Hostname:     "GOLDEN-PC",
+			OS:           "windows",
+			OSVersion:    "10.0.22631",
+			CPUModel:     "Fake Core i5-1145G7",
+			CPUCores:     4,
+			RAMTotal:     16 * gib,
+			Architecture: "amd64",
+			Uptime:       86*time.Hour + 40*time.Minute,
+			BootTime:     time.Date(2025, 3, 10, 18, 50, 0, 0, time.UTC),
+		},
+		Connections: []ConnectionInfo{
+			{PID: 4120, Process: "chrome.exe", Local: "192.168.1.20:51234", Remote: "142.250.74.46:443", State: "ESTABLISHED"},
+			{PID: 912, Process: "Code.exe", Local: "127.0.0.1:53100", Remote: "127.0.0.1:53101", State: "ESTABLISHED"},
+			{PID: 4, Process: "System", Local: "0.0.0.0:445", Remote: "0.0.0.0:0", State: "LISTEN"},
+		},
+		Temperature: 64,
+	}
+}
+
+// goldenModel feeds three fixture readings through Update so the
+// histories and derived disk speeds are populated.
+func goldenModel(tab Tab) StatusModel {
+	m := NewStatusModel(time.Second).WithTab(tab)
+	for i := range 3 {
+		updated, _ := m.Update(metricsMsg{metrics: goldenMetrics(i + 1)})
+		m = updated.(StatusModel)
+	}
+	m.Width, m.Height = 100, 40
+	return m
+}
+
+// plainColors switches lipgloss to the Ascii profile, as NO_COLOR does,
+// for the rest of the test.
+func plainColors(t *testing.T) {
+	t.Helper()
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+}
+
+// checkGolden compares got with testdata/<name>.golden, rewriting the file
+// instead when -update is set.
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s frame differs from %s:\n--- got ---\n%s\n--- want ---\n%s", name, path, got, want)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Golden frame tests
+// ---------------------------------------------------------------------------
+
+func TestRenderFrame_Golden(t *testing.T) {
+	plainColors(t)
+	for _, tc := range []struct {
+		name  string
+		tab   Tab
+		tweak func(*StatusModel)
+	}{
+		{name: "overview", tab: TabOverview},
+		{name: "overview_score", tab: TabOverview, tweak: func(m *StatusModel) { m.ShowScoreBreakdown = true }},
+		{name: "cpu", tab: TabCPU},
+		{name: "memory", tab: TabMemory},
+		{name: "disk", tab: TabDisk},
+		{name: "network", tab: TabNetwork},
+		{name: "network_graph", tab: TabNetwork, tweak: func(m *StatusModel) { m.NetGraph = true }},
+		{name: "network_ethernet", tab: TabNetwork, tweak: func(m *StatusModel) { m.NetIface = "Ethernet" }},
+		{name: "processes", tab: TabProcesses},
+		{name: "processes_grouped", tab: TabProcesses, tweak: func(m *StatusModel) { m.GroupProcs = true }},
+		{name: "processes_filtered", tab: TabProcesses, tweak: func(m *StatusModel) { m.procFilter = "chrome" }},
+		{name: "narrow", tab: TabOverview, tweak: func(m *StatusModel) { m.Width = 60 }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := goldenModel(tc.tab)
+			if tc.tweak != nil {
+				tc.tweak(&m)
+			}
+			frame := RenderFrame(m)
+			if strings.Contains(frame, "\x1b[") {
+				t.Fatal("frame contains escape sequences under the Ascii profile")
+			}
+			checkGolden(t, tc.name, frame)
+		})
+	}
+}
+
+func TestRenderNetGraph_TimeAxis(t *testing.T) {
+	plainColors(t)
+	m := NewStatusModel(time.Second).WithTab(TabNetwork)
+	m.NetGraph = true
+	m.Width, m.Height = 100, 40
+	updated, _ := m.Update(metricsMsg{metrics: goldenMetrics(1)})
+	m = updated.(StatusModel)
+	if frame := RenderFrame(m); strings.Contains(frame, " ago") {
+		t.Errorf("a single reading should have no time axis:\n%s", frame)
+	}
+
+	for i := range 4 {
+		updated, _ = m.Update(metricsMsg{metrics: goldenMetrics(i + 2)})
+		m = updated.(StatusModel)
+	}
+	if frame := RenderFrame(m); !strings.Contains(frame, "4s ago") {
+		t.Errorf("five readings a second apart should span 4s:\n%s", frame)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Name truncation tests
+// ---------------------------------------------------------------------------
+
+func TestRenderProcesses_WideNames(t *testing.T) {
+	cjk := strings.Repeat("网易云音乐", 6) + ".exe" // two cells per character
+	emoji := strings.Repeat("🎮", 25) + ".exe"
+	short := "音乐.exe"
+	m := goldenModel(TabProcesses)
+	m.Metrics.TopProcs = []ProcessInfo{
+		{PID: 1, Name: cjk, CPUPct: 9},
+		{PID: 2, Name: emoji, CPUPct: 8},
+		{PID: 3, Name: short, CPUPct: 7},
+		{PID: 4, Name: "notepad.exe", CPUPct: 6},
+	}
+	frame := RenderFrame(m)
+
+	if !utf8.ValidString(frame) {
+		t.Fatal("frame contains invalid UTF-8")
+	}
+	// A 100-column frame leaves 22 cells for a name. Every row must end at
+	// the same column however wide its characters are.
+	widths := map[int]bool{}
+	for _, name := range []string{ui.Truncate(cjk, 22), ui.Truncate(emoji, 22), short, "notepad.exe"} {
+		found := false
+		for _, line := range strings.Split(frame, "\n") {
+			if strings.Contains(line, name) {
+				found = true
+				widths[lipgloss.Width(line)] = true
+			}
+		}
+		if !found {
+			t.Errorf("frame lacks name %q:\n%s", name, frame)
+		}
+	}
+	if len(widths) != 1 {
+		t.Errorf("process rows have differing widths %v:\n%s", widths, frame)
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
 	"github.com/mattn/go-isatty"
 	"golang.org/x/sys/windows"
 )
@@ -90,6 +92,15 @@ func IsVTEnabled() bool {
 	return vtEnabled
 }
 
+// RunProgram runs a bubbletea program for m. Stderr log messages are held
+// back while it runs so they don't scribble over the TUI; every TUI entry
+// point starts through here.
+func RunProgram(m tea.Model, opts ...tea.ProgramOption) (tea.Model, error) {
+	core.PauseStderrLog(true)
+	defer core.PauseStderrLog(false)
+	return tea.NewProgram(m, opts...).Run()
+}
+
 // ─── Intro Animation ─────────────────────────────────────────────────────────
 
 // ShowMoleIntro displays the animated mascot appearing line-by-line.
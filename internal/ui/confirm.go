@@ -77,6 +77,33 @@ func DangerConfirm(message string) (bool, error) {
 	return input == "yes", nil
 }
 
+// ─── Typed Confirm ───────────────────────────────────────────────────────────
+
+// TypedConfirm requires the user to type expected exactly (case-insensitive,
+// surrounding whitespace ignored) to confirm. Used for high-risk targets where
+// a stray Enter or "y" must not be enough — the caller should print what is
+// about to happen before prompting.
+//
+//	Type "C:\Windows.old" to confirm:
+func TypedConfirm(expected string) (bool, error) {
+	instructStyle := lipgloss.NewStyle().Foreground(ColorText)
+	expectedTag := TagErrorStyle().Render(` "` + expected + `" `)
+
+	fmt.Printf("%s %s %s ",
+		instructStyle.Render("  Type"),
+		expectedTag,
+		instructStyle.Render("to confirm:"),
+	)
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	return strings.EqualFold(strings.TrimSpace(input), expected), nil
+}
+
 // ─── Press Enter ─────────────────────────────────────────────────────────────
 
 // PressEnterToContinue pauses execution until the user presses Enter.
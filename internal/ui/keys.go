@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Key Bindings ────────────────────────────────────────────────────────────
+// TUIs declare each key once as a KeyBinding and build both their footer
+// hints and their help overlay from it, so the two never drift apart.
+
+// KeyBinding documents one key (or key family) and what it does.
+type KeyBinding struct {
+	Keys string // as shown to the user, e.g. "↑↓" or "Tab/Shift-Tab"
+	Help string // short action, e.g. "nav"
+}
+
+// Hint renders the binding for a footer hint bar, e.g. "↑↓ nav".
+func (k KeyBinding) Hint() string {
+	return k.Keys + " " + k.Help
+}
+
+// Hints renders each binding with Hint, ready to join into a hint bar.
+func Hints(bindings ...KeyBinding) []string {
+	out := make([]string, len(bindings))
+	for i, k := range bindings {
+		out[i] = k.Hint()
+	}
+	return out
+}
+
+// KeyGroup is a titled set of bindings shown together in a help overlay.
+type KeyGroup struct {
+	Title    string
+	Bindings []KeyBinding
+}
+
+// KeyHelp is the binding that opens and closes a help overlay.
+var KeyHelp = KeyBinding{"?", "help"}
+
+// ─── Help Overlay ────────────────────────────────────────────────────────────
+
+// RenderHelp draws a full-screen help overlay listing every group of
+// bindings, centered in a width x height screen. Groups are laid out in
+// two columns when they fit side by side.
+func RenderHelp(title string, groups []KeyGroup, width, height int) string {
+	keyW := 0
+	for _, g := range groups {
+		for _, k := range g.Bindings {
+			keyW = max(keyW, lipgloss.Width(k.Keys))
+		}
+	}
+
+	keyStyle := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
+	descStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	titleStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	blocks := make([]string, len(groups))
+	for i, g := range groups {
+		lines := []string{titleStyle.Render(g.Title)}
+		for _, k := range g.Bindings {
+			lines = append(lines, fmt.Sprintf("  %s  %s",
+				keyStyle.Render(PadRight(k.Keys, keyW)),
+				descStyle.Render(k.Help)))
+		}
+		blocks[i] = strings.Join(lines, "\n")
+	}
+
+	body := strings.Join(blocks, "\n\n")
+	if colW := lipgloss.Width(body); width >= 2*colW+12 {
+		// Fill the left column to about half the total height, keeping
+		// the groups in order.
+		half := lipgloss.Height(body) / 2
+		var left, right []string
+		h := 0
+		for _, b := range blocks {
+			bh := lipgloss.Height(b)
+			if right == nil && (left == nil || h+bh/2 <= half) {
+				left = append(left, b)
+				h += bh + 1
+			} else {
+				right = append(right, b)
+			}
+		}
+		leftCol := lipgloss.NewStyle().Width(colW + 4).Render(strings.Join(left, "\n\n"))
+		body = lipgloss.JoinHorizontal(lipgloss.Top, leftCol, strings.Join(right, "\n\n"))
+	}
+
+	heading := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true).Render(IconHelp + " " + title)
+	footer := MutedStyle().Italic(true).Render("? or Esc to close")
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBorderFocus).
+		Padding(1, 2).
+		Render(lipgloss.JoinVertical(lipgloss.Left, heading, "", body, "", footer))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -201,9 +201,7 @@ func (m MenuModel) View() string {
 // selected MenuItem key. Returns ("", nil) if the user quit without selecting.
 func RunMenu(items []MenuItem, title string) (string, error) {
 	m := NewMenuModel(items).SetTitle(title)
-	p := tea.NewProgram(m, tea.WithAltScreen())
-
-	final, err := p.Run()
+	final, err := RunProgram(m, tea.WithAltScreen())
 	if err != nil {
 		return "", fmt.Errorf("menu error: %w", err)
 	}
@@ -36,8 +36,9 @@ type SelectorItem struct {
 	// value appear under a shared header.
 	Category string
 
-	// sizeBytes is used internally for total-size calculation.
-	sizeBytes int64
+	// SizeBytes is the size in bytes, summed into the running total of
+	// selected items.
+	SizeBytes int64
 }
 
 // ─── Selector Model ──────────────────────────────────────────────────────────
@@ -146,11 +147,42 @@ func (m SelectorModel) selectedCount() int {
 	return count
 }
 
+func (m SelectorModel) hasCategories() bool {
+	for _, item := range m.items {
+		if item.Category != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleCategory selects every enabled item in the cursor's category, or
+// deselects them all if they are already selected.
+func (m *SelectorModel) toggleCategory() {
+	if len(m.items) == 0 || m.items[m.cursor].Category == "" {
+		return
+	}
+	category := m.items[m.cursor].Category
+
+	allSelected := true
+	for _, item := range m.items {
+		if item.Category == category && !item.Disabled && !item.Selected {
+			allSelected = false
+			break
+		}
+	}
+	for i := range m.items {
+		if m.items[i].Category == category && !m.items[i].Disabled {
+			m.items[i].Selected = !allSelected
+		}
+	}
+}
+
 func (m SelectorModel) totalSelectedBytes() int64 {
 	var total int64
 	for _, item := range m.items {
 		if item.Selected {
-			total += item.sizeBytes
+			total += item.SizeBytes
 		}
 	}
 	return total
@@ -233,6 +265,10 @@ func (m SelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.items[m.cursor].Selected = !m.items[m.cursor].Selected
 			}
 
+		// ── Toggle Category ──
+		case "c":
+			m.toggleCategory()
+
 		// ── Select All ──
 		case "a":
 			for i := range m.items {
@@ -383,6 +419,9 @@ func (m SelectorModel) View() string {
 	var hints []string
 	hints = append(hints, "↑↓ nav")
 	hints = append(hints, "space toggle")
+	if m.hasCategories() {
+		hints = append(hints, "c category")
+	}
 	hints = append(hints, "a all")
 	hints = append(hints, "n none")
 	if totalPages > 1 {
@@ -409,9 +448,7 @@ func RunSelector(items []SelectorItem, title string) ([]SelectorItem, error) {
 	}
 
 	m := NewSelectorModel(items).SetTitle(title)
-	p := tea.NewProgram(m, tea.WithAltScreen())
-
-	final, err := p.Run()
+	final, err := RunProgram(m, tea.WithAltScreen())
 	if err != nil {
 		return nil, fmt.Errorf("selector error: %w", err)
 	}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func selectorKey(m SelectorModel, key string) SelectorModel {
+	var msg tea.KeyMsg
+	if key == " " {
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	} else {
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	updated, _ := m.Update(msg)
+	return updated.(SelectorModel)
+}
+
+func TestSelector_TotalCountsSelectedBytes(t *testing.T) {
+	m := NewSelectorModel([]SelectorItem{
+		{Label: "a", SizeBytes: 100, Selected: true},
+		{Label: "b", SizeBytes: 50},
+		{Label: "c", SizeBytes: 25, Selected: true},
+	})
+	if got := m.totalSelectedBytes(); got != 125 {
+		t.Fatalf("totalSelectedBytes() = %d, want 125", got)
+	}
+
+	m = selectorKey(m, " ") // deselect "a"
+	if got := m.totalSelectedBytes(); got != 25 {
+		t.Errorf("after toggle, totalSelectedBytes() = %d, want 25", got)
+	}
+}
+
+func TestSelector_ToggleCategorySkipsDisabled(t *testing.T) {
+	m := NewSelectorModel([]SelectorItem{
+		{Label: "a", Category: "User"},
+		{Label: "b", Category: "User", Selected: true},
+		{Label: "c", Category: "User", Disabled: true},
+		{Label: "d", Category: "System"},
+	})
+
+	m = selectorKey(m, "c")
+	want := []bool{true, true, false, false}
+	for i, item := range m.items {
+		if item.Selected != want[i] {
+			t.Errorf("after select, item %s Selected = %v, want %v", item.Label, item.Selected, want[i])
+		}
+	}
+
+	m = selectorKey(m, "c")
+	for _, item := range m.items {
+		if item.Selected {
+			t.Errorf("after second toggle, item %s still selected", item.Label)
+		}
+	}
+}
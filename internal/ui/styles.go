@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 )
 
 // ─── Color Palette ───────────────────────────────────────────────────────────
@@ -286,8 +288,26 @@ func SectionHeader(label string, width int) string {
 	return MutedStyle().Render(pre) + styled + MutedStyle().Render(suf)
 }
 
+// NoColor reports whether styles render as plain text, as under NO_COLOR
+// or --no-color. Views use it to add text markers for anything they would
+// otherwise signal by color alone.
+func NoColor() bool {
+	return lipgloss.ColorProfile() == termenv.Ascii
+}
+
 // GradientBar renders a filled/empty bar with color that shifts based on percentage.
 func GradientBar(pct float64, width int) string {
+	barColor := ColorPrimary
+	if pct >= 90 {
+		barColor = ColorError
+	} else if pct >= 70 {
+		barColor = ColorWarning
+	}
+	return SolidBar(pct, width, barColor)
+}
+
+// SolidBar renders a filled/empty bar in a fixed color.
+func SolidBar(pct float64, width int, barColor lipgloss.AdaptiveColor) string {
 	if pct < 0 {
 		pct = 0
 	}
@@ -299,13 +319,6 @@ func GradientBar(pct float64, width int) string {
 		filled = width
 	}
 
-	barColor := ColorPrimary
-	if pct >= 90 {
-		barColor = ColorError
-	} else if pct >= 70 {
-		barColor = ColorWarning
-	}
-
 	fStr := lipgloss.NewStyle().Foreground(barColor).Render(strings.Repeat("█", filled))
 	eStr := MutedStyle().Render(strings.Repeat("░", width-filled))
 	return fStr + eStr
@@ -456,6 +469,38 @@ func FormatPathWidth(path string, maxWidth int) string {
 	return MutedStyle().Render(result)
 }
 
+// Truncate shortens s to at most width terminal cells, ending with an
+// ellipsis if cut. Wide characters such as CJK and emoji count as two
+// cells, so the result fits a fixed-width column.
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return ansi.Truncate(s, width, "…")
+}
+
+// TruncateLeft is Truncate from the other end: it keeps the last cells of
+// s, which for a path keeps the file name.
+func TruncateLeft(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	over := lipgloss.Width(s) - width
+	if over <= 0 {
+		return s
+	}
+	return ansi.TruncateLeft(s, over+1, "…")
+}
+
+// PadRight pads s with spaces to width terminal cells. Unlike fmt's %-*s
+// it measures display width, not runes, so wide characters stay aligned.
+func PadRight(s string, width int) string {
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
 // FormatCount renders a number with the given label, styled by magnitude.
 func FormatCount(n int, label string) string {
 	s := fmt.Sprintf("%d %s", n, label)
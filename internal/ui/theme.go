@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Themes ──────────────────────────────────────────────────────────────────
+// A Theme is a full set of palette colors. ApplyTheme copies one into the
+// Color* tokens at startup, so every style built afterwards uses it.
+
+// Theme holds one value per palette token.
+type Theme struct {
+	Name string
+
+	Primary, Secondary, Success, Warning, Error, Info lipgloss.AdaptiveColor
+	Muted, Surface, Text, TextDim, Accent             lipgloss.AdaptiveColor
+	SurfaceDark, Overlay, Border, BorderFocus         lipgloss.AdaptiveColor
+	Teal, Violet, Coral, Blue, Hazy                   lipgloss.AdaptiveColor
+}
+
+// DefaultTheme is the theme used when none is chosen.
+const DefaultTheme = "charmtone"
+
+// Themes lists the built-in themes; the first is the default.
+var Themes = []Theme{
+	{
+		Name:        DefaultTheme,
+		Primary:     ColorPrimary,
+		Secondary:   ColorSecondary,
+		Success:     ColorSuccess,
+		Warning:     ColorWarning,
+		Error:       ColorError,
+		Info:        ColorInfo,
+		Muted:       ColorMuted,
+		Surface:     ColorSurface,
+		Text:        ColorText,
+		TextDim:     ColorTextDim,
+		Accent:      ColorAccent,
+		SurfaceDark: ColorSurfaceDark,
+		Overlay:     ColorOverlay,
+		Border:      ColorBorder,
+		BorderFocus: ColorBorderFocus,
+		Teal:        ColorTeal,
+		Violet:      ColorViolet,
+		Coral:       ColorCoral,
+		Blue:        ColorBlue,
+		Hazy:        ColorHazy,
+	},
+	{
+		// Saturated colors and pure black/white text for low-vision users
+		// and washed-out displays.
+		Name:        "high-contrast",
+		Primary:     lipgloss.AdaptiveColor{Light: "#0000CC", Dark: "#5FAFFF"},
+		Secondary:   lipgloss.AdaptiveColor{Light: "#8B008B", Dark: "#FF5FFF"},
+		Success:     lipgloss.AdaptiveColor{Light: "#006400", Dark: "#00FF00"},
+		Warning:     lipgloss.AdaptiveColor{Light: "#8B4500", Dark: "#FFD700"},
+		Error:       lipgloss.AdaptiveColor{Light: "#B00000", Dark: "#FF3030"},
+		Info:        lipgloss.AdaptiveColor{Light: "#00008B", Dark: "#00FFFF"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#404040", Dark: "#B0B0B0"},
+		Surface:     lipgloss.AdaptiveColor{Light: "#E0E0E0", Dark: "#303030"},
+		Text:        lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		TextDim:     lipgloss.AdaptiveColor{Light: "#202020", Dark: "#E0E0E0"},
+		Accent:      lipgloss.AdaptiveColor{Light: "#005F5F", Dark: "#00FFD7"},
+		SurfaceDark: lipgloss.AdaptiveColor{Light: "#D0D0D0", Dark: "#000000"},
+		Overlay:     lipgloss.AdaptiveColor{Light: "#C0C0C0", Dark: "#404040"},
+		Border:      lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		BorderFocus: lipgloss.AdaptiveColor{Light: "#0000CC", Dark: "#5FAFFF"},
+		Teal:        lipgloss.AdaptiveColor{Light: "#005F5F", Dark: "#00FFFF"},
+		Violet:      lipgloss.AdaptiveColor{Light: "#5F00AF", Dark: "#D787FF"},
+		Coral:       lipgloss.AdaptiveColor{Light: "#AF0000", Dark: "#FF8787"},
+		Blue:        lipgloss.AdaptiveColor{Light: "#0000CC", Dark: "#87D7FF"},
+		Hazy:        lipgloss.AdaptiveColor{Light: "#3A3A8A", Dark: "#AFAFFF"},
+	},
+	{
+		// Grays only: meaning comes from weight and position, never hue.
+		// Suits colorblind users and clean screenshots.
+		Name:        "monochrome",
+		Primary:     lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Secondary:   lipgloss.AdaptiveColor{Light: "#262626", Dark: "#E4E4E4"},
+		Success:     lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+		Warning:     lipgloss.AdaptiveColor{Light: "#262626", Dark: "#E4E4E4"},
+		Error:       lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Info:        lipgloss.AdaptiveColor{Light: "#3A3A3A", Dark: "#C6C6C6"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#8A8A8A", Dark: "#6C6C6C"},
+		Surface:     lipgloss.AdaptiveColor{Light: "#EEEEEE", Dark: "#303030"},
+		Text:        lipgloss.AdaptiveColor{Light: "#1C1C1C", Dark: "#EEEEEE"},
+		TextDim:     lipgloss.AdaptiveColor{Light: "#585858", Dark: "#B2B2B2"},
+		Accent:      lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+		SurfaceDark: lipgloss.AdaptiveColor{Light: "#E4E4E4", Dark: "#1C1C1C"},
+		Overlay:     lipgloss.AdaptiveColor{Light: "#DADADA", Dark: "#4E4E4E"},
+		Border:      lipgloss.AdaptiveColor{Light: "#B2B2B2", Dark: "#444444"},
+		BorderFocus: lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Teal:        lipgloss.AdaptiveColor{Light: "#3A3A3A", Dark: "#C6C6C6"},
+		Violet:      lipgloss.AdaptiveColor{Light: "#262626", Dark: "#E4E4E4"},
+		Coral:       lipgloss.AdaptiveColor{Light: "#1C1C1C", Dark: "#EEEEEE"},
+		Blue:        lipgloss.AdaptiveColor{Light: "#3A3A3A", Dark: "#C6C6C6"},
+		Hazy:        lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+	},
+	{
+		// Cool arctic blues after the Nord palette.
+		Name:        "nord",
+		Primary:     lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88C0D0"},
+		Secondary:   lipgloss.AdaptiveColor{Light: "#B48EAD", Dark: "#B48EAD"},
+		Success:     lipgloss.AdaptiveColor{Light: "#6A8F4E", Dark: "#A3BE8C"},
+		Warning:     lipgloss.AdaptiveColor{Light: "#C08A3E", Dark: "#EBCB8B"},
+		Error:       lipgloss.AdaptiveColor{Light: "#BF616A", Dark: "#BF616A"},
+		Info:        lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#81A1C1"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#7B88A1", Dark: "#4C566A"},
+		Surface:     lipgloss.AdaptiveColor{Light: "#E5E9F0", Dark: "#3B4252"},
+		Text:        lipgloss.AdaptiveColor{Light: "#2E3440", Dark: "#ECEFF4"},
+		TextDim:     lipgloss.AdaptiveColor{Light: "#4C566A", Dark: "#D8DEE9"},
+		Accent:      lipgloss.AdaptiveColor{Light: "#5E9A9A", Dark: "#8FBCBB"},
+		SurfaceDark: lipgloss.AdaptiveColor{Light: "#D8DEE9", Dark: "#2E3440"},
+		Overlay:     lipgloss.AdaptiveColor{Light: "#D8DEE9", Dark: "#434C5E"},
+		Border:      lipgloss.AdaptiveColor{Light: "#D8DEE9", Dark: "#434C5E"},
+		BorderFocus: lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88C0D0"},
+		Teal:        lipgloss.AdaptiveColor{Light: "#5E9A9A", Dark: "#8FBCBB"},
+		Violet:      lipgloss.AdaptiveColor{Light: "#8C6A88", Dark: "#B48EAD"},
+		Coral:       lipgloss.AdaptiveColor{Light: "#B8664F", Dark: "#D08770"},
+		Blue:        lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#81A1C1"},
+		Hazy:        lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#81A1C1"},
+	},
+}
+
+// ThemeNames returns the names of the built-in themes.
+func ThemeNames() []string {
+	names := make([]string, len(Themes))
+	for i, t := range Themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// themeHooks run after ApplyTheme so packages can rebuild cached styles.
+var themeHooks []func()
+
+// OnThemeChange registers fn to run whenever a theme is applied. Packages
+// that keep styles in variables use it to rebuild them.
+func OnThemeChange(fn func()) {
+	themeHooks = append(themeHooks, fn)
+}
+
+// ApplyTheme makes the named built-in theme (case-insensitive) active.
+func ApplyTheme(name string) error {
+	for _, t := range Themes {
+		if strings.EqualFold(t.Name, name) {
+			setPalette(t)
+			for _, fn := range themeHooks {
+				fn()
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown theme %q (want one of %s)", name, strings.Join(ThemeNames(), ", "))
+}
+
+// setPalette copies t into the Color* tokens.
+func setPalette(t Theme) {
+	ColorPrimary, ColorSecondary, ColorSuccess = t.Primary, t.Secondary, t.Success
+	ColorWarning, ColorError, ColorInfo = t.Warning, t.Error, t.Info
+	ColorMuted, ColorSurface, ColorText, ColorTextDim = t.Muted, t.Surface, t.Text, t.TextDim
+	ColorAccent, ColorSurfaceDark, ColorOverlay = t.Accent, t.SurfaceDark, t.Overlay
+	ColorBorder, ColorBorderFocus = t.Border, t.BorderFocus
+	ColorTeal, ColorViolet, ColorCoral, ColorBlue, ColorHazy = t.Teal, t.Violet, t.Coral, t.Blue, t.Hazy
+}
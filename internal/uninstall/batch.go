@@ -1,19 +1,29 @@
 package uninstall
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/lakshaymaurya-felt/purewin/internal/core"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
 )
 
+// BatchResult counts the outcome of a batch uninstall.
+type BatchResult struct {
+	Selected       int // apps chosen in the selector
+	Uninstalled    int // including those that need a restart
+	RebootRequired int // uninstalled, but a restart finishes the job
+	Failed         int
+}
+
 // RunBatchUninstall presents a multi-select UI for the given applications,
 // confirms the selection, and executes uninstalls with progress feedback.
 // In dryRun mode, operations are listed but not executed.
-func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
+func RunBatchUninstall(apps []InstalledApp, dryRun, assumeYes bool) (BatchResult, error) {
+	var result BatchResult
 	if len(apps) == 0 {
 		fmt.Println(ui.MutedStyle().Render("  No applications found."))
-		return nil
+		return result, nil
 	}
 
 	// 1. Convert to selector items.
@@ -37,15 +47,16 @@ func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
 	// 2. Run the selector.
 	selected, err := ui.RunSelector(items, "Select applications to uninstall")
 	if err != nil {
-		return fmt.Errorf("selector error: %w", err)
+		return result, fmt.Errorf("selector error: %w", err)
 	}
 	if len(selected) == 0 {
 		fmt.Println(ui.MutedStyle().Render("  No applications selected."))
-		return nil
+		return result, nil
 	}
 
 	// 3. Map selected items back to apps.
 	selectedApps := mapSelectedApps(apps, selected)
+	result.Selected = len(selectedApps)
 
 	// 4. Show what was selected.
 	fmt.Println()
@@ -64,50 +75,63 @@ func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
 	if dryRun {
 		fmt.Println(ui.WarningStyle().Render(
 			"  DRY RUN — no applications will be uninstalled."))
-		return nil
+		return result, nil
 	}
 
-	// 6. Confirm before executing.
-	confirmed, err := ui.DangerConfirm("This will uninstall the selected applications")
-	if err != nil {
-		return fmt.Errorf("confirmation error: %w", err)
-	}
-	if !confirmed {
-		fmt.Println(ui.MutedStyle().Render("  Cancelled."))
-		return nil
+	// 6. Confirm before executing, unless --yes was given.
+	if !assumeYes {
+		confirmed, err := ui.DangerConfirm("This will uninstall the selected applications")
+		if err != nil {
+			return result, fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+			return result, nil
+		}
 	}
 
 	// 7. Execute uninstalls with progress.
 	fmt.Println()
-	var successes, failures int
-
 	for _, app := range selectedApps {
 		spin := ui.NewInlineSpinner()
 		spin.Start(fmt.Sprintf("Uninstalling %s...", app.Name))
 
 		uninstErr := UninstallApp(app, false)
-		if uninstErr != nil {
+		switch {
+		case errors.Is(uninstErr, ErrRebootRequired):
+			spin.Stop(fmt.Sprintf("Uninstalled %s (restart required)", app.Name))
+			result.Uninstalled++
+			result.RebootRequired++
+		case uninstErr != nil:
 			spin.StopWithError(fmt.Sprintf("Failed to uninstall %s: %s", app.Name, uninstErr))
-			failures++
-		} else {
+			var prepErr *EdgePrepError
+			if errors.As(uninstErr, &prepErr) {
+				PrintEdgePrep(prepErr.Prep)
+			}
+			result.Failed++
+		default:
 			spin.Stop(fmt.Sprintf("Uninstalled %s", app.Name))
-			successes++
+			result.Uninstalled++
 		}
 	}
 
 	// 8. Summary.
 	fmt.Println()
 	fmt.Println(ui.Divider(40))
-	if successes > 0 {
+	if result.Uninstalled > 0 {
 		fmt.Println(ui.SuccessStyle().Render(
-			fmt.Sprintf("  %s %d application(s) uninstalled successfully", ui.IconSuccess, successes)))
+			fmt.Sprintf("  %s %d application(s) uninstalled successfully", ui.IconSuccess, result.Uninstalled)))
+	}
+	if result.RebootRequired > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s Restart Windows to finish removing %d application(s)", ui.IconWarning, result.RebootRequired)))
 	}
-	if failures > 0 {
+	if result.Failed > 0 {
 		fmt.Println(ui.ErrorStyle().Render(
-			fmt.Sprintf("  %s %d application(s) failed to uninstall", ui.IconError, failures)))
+			fmt.Sprintf("  %s %d application(s) failed to uninstall", ui.IconError, result.Failed)))
 	}
 
-	return nil
+	return result, nil
 }
 
 // mapSelectedApps maps selected SelectorItems back to InstalledApp entries
@@ -0,0 +1,377 @@
+package uninstall
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+)
+
+// ─── Edge Preparation Report ─────────────────────────────────────────────────
+
+// Edge preparation steps, in the order prepareEdgeUninstall runs them.
+// Each is named for the state it leaves behind so the same list reads as a
+// status report.
+const (
+	edgeStepServices       = "Edge Update and elevation services stopped"
+	edgeStepProcesses      = "Edge processes closed"
+	edgeStepAllowUninstall = `EdgeUpdateDev\AllowUninstall set`
+	edgeStepNoReinstall    = "Reinstall via Windows Update blocked"
+	edgeStepNoRemove       = "NoRemove cleared on the Edge uninstall entry"
+	edgeStepStub           = "Edge UWP stub present"
+)
+
+var edgeSteps = []string{
+	edgeStepServices,
+	edgeStepProcesses,
+	edgeStepAllowUninstall,
+	edgeStepNoReinstall,
+	edgeStepNoRemove,
+	edgeStepStub,
+}
+
+// Registry keys and services touched while preparing Edge for removal.
+const (
+	edgeUpdateDevKey = `SOFTWARE\WOW6432Node\Microsoft\EdgeUpdateDev`
+	edgeUpdateKey    = `SOFTWARE\Microsoft\EdgeUpdate`
+	edgeUninstallKey = `SOFTWARE\WOW6432Node\Microsoft\Windows\CurrentVersion\Uninstall\Microsoft Edge`
+)
+
+// edgeServices are stopped before Edge's processes are killed, since the
+// SCM would otherwise restart them. Newer builds add the elevation service.
+var edgeServices = []string{"edgeupdate", "edgeupdatem", "MicrosoftEdgeElevationService"}
+
+// edgeProcesses hold file locks and registry handles that block removal.
+var edgeProcesses = []string{
+	"msedge.exe",
+	"msedge_proxy.exe",
+	"pwahelper.exe",
+	"identity_helper.exe",
+	"MicrosoftEdgeUpdate.exe",
+}
+
+// webView2Processes are the WebView2 runtime, which apps keep running and
+// which shares Edge's files. It has no updater of its own: the shared
+// MicrosoftEdgeUpdate.exe in edgeProcesses updates it along with Edge.
+var webView2Processes = []string{"msedgewebview2.exe"}
+
+// ─── Edge Process Control ────────────────────────────────────────────────────
+
+// edgeController stops and starts the services and processes around an
+// Edge uninstall. scController is the real one; tests swap in a fake.
+type edgeController interface {
+	ServiceRunning(name string) (bool, error) // a missing service is not running
+	StopService(name string) error
+	StartService(name string) error
+	RunningProcesses() (map[string]bool, error) // lower-cased image names
+	KillProcess(image string) error
+}
+
+var edgeControl edgeController = scController{}
+
+// scController drives services with sc and processes with tasklist and
+// taskkill.
+type scController struct{}
+
+// ServiceRunning implements edgeController.
+func (scController) ServiceRunning(name string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), edgeHelperTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, "sc", "query", name).CombinedOutput()
+	if exitCodeIs(err, scServiceMissing) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot query %s: %w", name, err)
+	}
+	return !strings.Contains(string(output), "STOPPED"), nil
+}
+
+// StopService implements edgeController.
+func (scController) StopService(name string) error {
+	err := runBestEffort("sc", "stop", name)
+	if exitCodeIs(err, scServiceMissing, scServiceNotRunning) {
+		return nil
+	}
+	return err
+}
+
+// StartService implements edgeController.
+func (scController) StartService(name string) error {
+	return runBestEffort("sc", "start", name)
+}
+
+// RunningProcesses implements edgeController.
+func (scController) RunningProcesses() (map[string]bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), edgeHelperTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, "tasklist", "/FO", "CSV", "/NH").Output()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list processes: %w", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(output)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse tasklist output: %w", err)
+	}
+	running := make(map[string]bool, len(records))
+	for _, rec := range records {
+		if len(rec) > 0 {
+			running[strings.ToLower(rec[0])] = true
+		}
+	}
+	return running, nil
+}
+
+// KillProcess implements edgeController.
+func (scController) KillProcess(image string) error {
+	err := runBestEffort("taskkill", "/F", "/IM", image, "/T")
+	if exitCodeIs(err, taskkillNotFound) {
+		return nil
+	}
+	return err
+}
+
+// stopEdgeServices stops each Edge service that is running and returns the
+// ones it stopped, so a rollback restarts only those.
+func stopEdgeServices(ctl edgeController) ([]string, error) {
+	var stopped []string
+	var errs []error
+	for _, svc := range edgeServices {
+		running, err := ctl.ServiceRunning(svc)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !running {
+			continue
+		}
+		if err := ctl.StopService(svc); err != nil {
+			errs = append(errs, fmt.Errorf("sc stop %s: %w", svc, err))
+			continue
+		}
+		stopped = append(stopped, svc)
+	}
+	return stopped, errors.Join(errs...)
+}
+
+// closeEdgeProcesses kills the Edge and WebView2 processes that are
+// running. If the process list can't be read, every one is killed.
+func closeEdgeProcesses(ctl edgeController) error {
+	running, listErr := ctl.RunningProcesses()
+	if listErr != nil {
+		logBestEffort("list running processes", listErr)
+	}
+
+	var errs []error
+	for _, image := range slices.Concat(edgeProcesses, webView2Processes) {
+		if listErr == nil && !running[strings.ToLower(image)] {
+			continue
+		}
+		if err := ctl.KillProcess(image); err != nil {
+			errs = append(errs, fmt.Errorf("taskkill %s: %w", image, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// EdgeStep is the outcome of one Edge preparation step.
+type EdgeStep struct {
+	Name     string
+	Done     bool  // the step succeeded, or its state was already in place
+	Err      error // why the step failed; nil when it succeeded or never ran
+	Critical bool  // Edge cannot be removed while this step fails
+}
+
+// Skipped reports a step that never ran because an earlier critical step
+// failed.
+func (s EdgeStep) Skipped() bool {
+	return !s.Done && s.Err == nil
+}
+
+// EdgePrep lists what preparing Edge for removal did, one step per entry in
+// the order the steps run.
+type EdgePrep struct {
+	Steps      []EdgeStep
+	RolledBack bool // stopped Edge services were restarted after a failure
+
+	stoppedServices []string // services this run stopped, for a rollback
+}
+
+// Stuck returns the critical step that stopped preparation, if any.
+func (p EdgePrep) Stuck() (EdgeStep, bool) {
+	for _, s := range p.Steps {
+		if s.Critical && s.Err != nil {
+			return s, true
+		}
+	}
+	return EdgeStep{}, false
+}
+
+// record appends the outcome of the next step and logs it. A critical
+// failure stops the uninstall, so it is logged as an error; anything else
+// is best effort.
+func (p *EdgePrep) record(name string, critical bool, err error) {
+	p.Steps = append(p.Steps, EdgeStep{Name: name, Done: err == nil, Err: err, Critical: critical})
+	if critical && err != nil {
+		core.LogError("edge: %s failed: %v", name, err)
+		return
+	}
+	logBestEffort(name, err)
+}
+
+// skipRemaining appends the steps that were not reached.
+func (p *EdgePrep) skipRemaining() {
+	for _, name := range edgeSteps[len(p.Steps):] {
+		p.Steps = append(p.Steps, EdgeStep{Name: name})
+	}
+}
+
+// EdgePrepError is returned by UninstallApp when Edge could not be prepared
+// for removal. Prep tells which step it got stuck on.
+type EdgePrepError struct {
+	Prep EdgePrep
+	Err  error
+}
+
+func (e *EdgePrepError) Error() string {
+	return "failed to prepare Edge uninstall: " + e.Err.Error()
+}
+
+func (e *EdgePrepError) Unwrap() error { return e.Err }
+
+// EdgeStatus reports which Edge preparation steps are in effect right now,
+// without changing anything. Running Edge processes are not checked: they
+// are only closed for the length of an uninstall.
+func EdgeStatus() EdgePrep {
+	var p EdgePrep
+	add := func(name string, critical bool, err error) {
+		p.Steps = append(p.Steps, EdgeStep{Name: name, Done: err == nil, Err: err, Critical: critical})
+	}
+
+	add(edgeStepServices, false, edgeServicesStopped(edgeControl))
+
+	var err error
+	if key, openErr := registry.OpenKey(registry.LOCAL_MACHINE, edgeUpdateDevKey, registry.QUERY_VALUE); openErr != nil {
+		err = errors.New("EdgeUpdateDev key not present")
+	} else {
+		if _, _, valErr := key.GetStringValue("AllowUninstall"); valErr != nil {
+			err = errors.New("AllowUninstall not set")
+		}
+		key.Close()
+	}
+	add(edgeStepAllowUninstall, true, err)
+
+	err = errors.New("DoNotUpdateToEdgeWithChromium not set")
+	if key, openErr := registry.OpenKey(registry.LOCAL_MACHINE, edgeUpdateKey, registry.QUERY_VALUE); openErr == nil {
+		if v, _, valErr := key.GetIntegerValue("DoNotUpdateToEdgeWithChromium"); valErr == nil && v == 1 {
+			err = nil
+		}
+		key.Close()
+	}
+	add(edgeStepNoReinstall, false, err)
+
+	err = nil
+	if key, openErr := registry.OpenKey(registry.LOCAL_MACHINE, edgeUninstallKey, registry.QUERY_VALUE); openErr == nil {
+		if _, _, valErr := key.GetIntegerValue("NoRemove"); valErr == nil {
+			err = errors.New("NoRemove is still set")
+		}
+		key.Close()
+	}
+	add(edgeStepNoRemove, false, err)
+
+	stub, err := edgeStubPath()
+	if err == nil {
+		if _, statErr := os.Stat(stub); statErr != nil {
+			err = fmt.Errorf("%s not found", stub)
+		}
+	}
+	add(edgeStepStub, false, err)
+
+	return p
+}
+
+// edgeServicesStopped returns an error naming any Edge service that is
+// running. A service that is not installed counts as stopped.
+func edgeServicesStopped(ctl edgeController) error {
+	var running []string
+	for _, svc := range edgeServices {
+		isRunning, err := ctl.ServiceRunning(svc)
+		if err != nil {
+			return err
+		}
+		if isRunning {
+			running = append(running, svc)
+		}
+	}
+	if len(running) > 0 {
+		return fmt.Errorf("%s running", strings.Join(running, ", "))
+	}
+	return nil
+}
+
+// edgeStubPath returns where the legacy Edge UWP stub is created.
+func edgeStubPath() (string, error) {
+	sysRoot := os.Getenv("SystemRoot")
+	if sysRoot == "" {
+		return "", errors.New("SystemRoot environment variable is not set")
+	}
+	return filepath.Join(sysRoot, "SystemApps", "Microsoft.MicrosoftEdge_8wekyb3d8bbwe", "MicrosoftEdge.exe"), nil
+}
+
+// Exit codes of sc and taskkill that mean there was nothing to stop.
+const (
+	scServiceMissing    = 1060 // ERROR_SERVICE_DOES_NOT_EXIST
+	scServiceNotRunning = 1062 // ERROR_SERVICE_NOT_ACTIVE
+	taskkillNotFound    = 128
+)
+
+// exitCodeIs reports whether err is a command exiting with one of codes.
+func exitCodeIs(err error, codes ...int) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	for _, code := range codes {
+		if exitErr.ExitCode() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintEdgePrep prints each Edge preparation step with its outcome, and
+// what to try next when a critical step failed.
+func PrintEdgePrep(p EdgePrep) {
+	for _, s := range p.Steps {
+		switch {
+		case s.Done:
+			fmt.Printf("  %s %s\n", ui.SuccessStyle().Render(ui.IconSuccess), s.Name)
+		case s.Skipped():
+			fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  %s %s (not reached)", ui.IconPending, s.Name)))
+		case s.Critical:
+			fmt.Printf("  %s %s %s\n", ui.ErrorStyle().Render(ui.IconError), s.Name,
+				ui.ErrorStyle().Render("— "+s.Err.Error()))
+		default:
+			fmt.Printf("  %s %s %s\n", ui.WarningStyle().Render(ui.IconWarning), s.Name,
+				ui.MutedStyle().Render("— "+s.Err.Error()))
+		}
+	}
+
+	if p.RolledBack {
+		fmt.Println(ui.MutedStyle().Render("  Edge services were restarted."))
+	}
+	if stuck, ok := p.Stuck(); ok && errors.Is(stuck.Err, os.ErrPermission) {
+		fmt.Println(ui.WarningStyle().Render("  Run as administrator: pw --admin uninstall"))
+	}
+}
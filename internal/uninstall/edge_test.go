@@ -0,0 +1,268 @@
+package uninstall
+
+import (
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Fake controller
+// ---------------------------------------------------------------------------
+
+// fakeController records every stop, start, and kill in the order they
+// were asked for. A service it stops successfully is no longer running.
+type fakeController struct {
+	services  map[string]bool // name → running; absent means not installed
+	processes map[string]bool // lower-cased image names
+	listErr   error
+	stopErr   map[string]error
+	calls     []string
+}
+
+func (f *fakeController) ServiceRunning(name string) (bool, error) {
+	return f.services[name], nil
+}
+
+func (f *fakeController) StopService(name string) error {
+	f.calls = append(f.calls, "stop "+name)
+	if err := f.stopErr[name]; err != nil {
+		return err
+	}
+	if f.services[name] {
+		f.services[name] = false
+	}
+	return nil
+}
+
+func (f *fakeController) StartService(name string) error {
+	f.calls = append(f.calls, "start "+name)
+	return nil
+}
+
+func (f *fakeController) RunningProcesses() (map[string]bool, error) {
+	return f.processes, f.listErr
+}
+
+func (f *fakeController) KillProcess(image string) error {
+	f.calls = append(f.calls, "kill "+image)
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Stopping Edge
+// ---------------------------------------------------------------------------
+
+func TestStopEdge_OnlyRunning(t *testing.T) {
+	ctl := &fakeController{
+		services: map[string]bool{"edgeupdate": true, "edgeupdatem": false},
+		processes: map[string]bool{
+			"msedge.exe":         true,
+			"msedgewebview2.exe": true,
+			"explorer.exe":       true,
+		},
+	}
+
+	stopped, err := stopEdgeServices(ctl)
+	if err != nil {
+		t.Fatalf("stopEdgeServices: %v", err)
+	}
+	if want := []string{"edgeupdate"}; !slices.Equal(stopped, want) {
+		t.Errorf("stopped = %q, want %q", stopped, want)
+	}
+	if err := closeEdgeProcesses(ctl); err != nil {
+		t.Fatalf("closeEdgeProcesses: %v", err)
+	}
+
+	want := []string{"stop edgeupdate", "kill msedge.exe", "kill msedgewebview2.exe"}
+	if !slices.Equal(ctl.calls, want) {
+		t.Errorf("calls = %q, want %q", ctl.calls, want)
+	}
+}
+
+func TestStopEdge_UnlistableKillsAll(t *testing.T) {
+	ctl := &fakeController{listErr: errors.New("tasklist failed")}
+
+	if err := closeEdgeProcesses(ctl); err != nil {
+		t.Fatalf("closeEdgeProcesses: %v", err)
+	}
+
+	want := len(edgeProcesses) + len(webView2Processes)
+	if len(ctl.calls) != want {
+		t.Errorf("got %d kills, want %d: %q", len(ctl.calls), want, ctl.calls)
+	}
+}
+
+func TestStopEdge_ReportsStopFailure(t *testing.T) {
+	ctl := &fakeController{
+		services: map[string]bool{"edgeupdate": true, "edgeupdatem": true},
+		stopErr:  map[string]error{"edgeupdate": errors.New("access denied")},
+	}
+
+	stopped, err := stopEdgeServices(ctl)
+	if err == nil || !strings.Contains(err.Error(), "edgeupdate") {
+		t.Fatalf("err = %v, want one naming edgeupdate", err)
+	}
+	if want := []string{"edgeupdatem"}; !slices.Equal(stopped, want) {
+		t.Errorf("stopped = %q, want %q", stopped, want)
+	}
+	want := []string{"stop edgeupdate", "stop edgeupdatem"}
+	if !slices.Equal(ctl.calls, want) {
+		t.Errorf("calls = %q, want %q", ctl.calls, want)
+	}
+	if got := edgeServicesStopped(ctl); got == nil || got.Error() != "edgeupdate running" {
+		t.Errorf("edgeServicesStopped = %v, want only the failed edgeupdate running", got)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Rollback
+// ---------------------------------------------------------------------------
+
+func TestPrepareEdge_RollbackRestartsOnlyStopped(t *testing.T) {
+	ctl := &fakeController{
+		services: map[string]bool{
+			"edgeupdate":                    true,
+			"edgeupdatem":                   false,
+			"MicrosoftEdgeElevationService": false,
+		},
+	}
+	origCtl, origAllow := edgeControl, allowEdgeUninstall
+	t.Cleanup(func() { edgeControl, allowEdgeUninstall = origCtl, origAllow })
+	edgeControl = ctl
+	allowEdgeUninstall = func() error { return errors.New("access denied") }
+
+	prep, err := prepareEdgeUninstall()
+	if err == nil {
+		t.Fatal("prepareEdgeUninstall succeeded with the critical step failing")
+	}
+	if !prep.RolledBack {
+		t.Error("RolledBack = false after a failed critical step")
+	}
+	if stuck, ok := prep.Stuck(); !ok || stuck.Name != edgeStepAllowUninstall {
+		t.Errorf("Stuck() = %q, %v; want %q", stuck.Name, ok, edgeStepAllowUninstall)
+	}
+
+	var starts []string
+	for _, c := range ctl.calls {
+		if name, ok := strings.CutPrefix(c, "start "); ok {
+			starts = append(starts, name)
+		}
+	}
+	if want := []string{"edgeupdate"}; !slices.Equal(starts, want) {
+		t.Errorf("restarted %q, want only %q (calls %q)", starts, want, ctl.calls)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Preparation report
+// ---------------------------------------------------------------------------
+
+func TestEdgePrep_Stuck(t *testing.T) {
+	denied := errors.New("access denied")
+	tests := []struct {
+		name  string
+		steps []EdgeStep
+		want  string // stuck step name; "" for none
+	}{
+		{"no steps", nil, ""},
+		{"all done", []EdgeStep{
+			{Name: edgeStepServices, Done: true},
+			{Name: edgeStepAllowUninstall, Done: true, Critical: true},
+		}, ""},
+		{"non-critical failure", []EdgeStep{
+			{Name: edgeStepServices, Err: denied},
+			{Name: edgeStepAllowUninstall, Done: true, Critical: true},
+		}, ""},
+		{"critical failure", []EdgeStep{
+			{Name: edgeStepServices, Err: denied},
+			{Name: edgeStepAllowUninstall, Err: denied, Critical: true},
+			{Name: edgeStepStub, Critical: true},
+		}, edgeStepAllowUninstall},
+		{"critical step skipped, not failed", []EdgeStep{
+			{Name: edgeStepStub, Critical: true},
+		}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stuck, ok := EdgePrep{Steps: tt.steps}.Stuck()
+			if ok != (tt.want != "") || stuck.Name != tt.want {
+				t.Errorf("Stuck() = %q, %v; want %q", stuck.Name, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestEdgePrep_SkipRemaining(t *testing.T) {
+	for ran := 0; ran <= len(edgeSteps); ran++ {
+		var p EdgePrep
+		for _, name := range edgeSteps[:ran] {
+			p.record(name, false, nil)
+		}
+		p.skipRemaining()
+
+		var names []string
+		for _, s := range p.Steps {
+			names = append(names, s.Name)
+		}
+		if !slices.Equal(names, edgeSteps) {
+			t.Errorf("%d ran: steps = %q, want %q", ran, names, edgeSteps)
+		}
+
+		out := captureStdout(t, func() { PrintEdgePrep(p) })
+		if got, want := strings.Count(out, "(not reached)"), len(edgeSteps)-ran; got != want {
+			t.Errorf("%d ran: %d steps printed as not reached, want %d:\n%s", ran, got, want, out)
+		}
+	}
+}
+
+func TestEdgeStatus_StepOrder(t *testing.T) {
+	orig := edgeControl
+	t.Cleanup(func() { edgeControl = orig })
+	edgeControl = &fakeController{services: map[string]bool{"edgeupdate": true}}
+	t.Setenv("SystemRoot", t.TempDir())
+
+	// Running processes are not part of the status report.
+	want := slices.DeleteFunc(slices.Clone(edgeSteps), func(s string) bool { return s == edgeStepProcesses })
+
+	p := EdgeStatus()
+	var names []string
+	for _, s := range p.Steps {
+		names = append(names, s.Name)
+		if s.Skipped() {
+			t.Errorf("%s reported as not reached", s.Name)
+		}
+		if s.Critical != (s.Name == edgeStepAllowUninstall) {
+			t.Errorf("%s Critical = %v", s.Name, s.Critical)
+		}
+	}
+	if !slices.Equal(names, want) {
+		t.Errorf("steps = %q, want %q", names, want)
+	}
+	if p.Steps[0].Done {
+		t.Error("services step done with edgeupdate running")
+	}
+}
+
+// captureStdout returns what fn printed to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
@@ -13,6 +13,8 @@ import (
 	"unicode/utf8"
 
 	"golang.org/x/sys/windows/registry"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
 )
 
 const (
@@ -20,6 +22,10 @@ const (
 	uninstallTimeout = 120 * time.Second
 )
 
+// ErrRebootRequired reports an uninstall that succeeded but needs a restart
+// to finish (installer exit code 1641 or 3010).
+var ErrRebootRequired = errors.New("restart required to finish uninstalling")
+
 // msiGUIDPattern matches MSI product GUIDs like {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}.
 var msiGUIDPattern = regexp.MustCompile(`\{[0-9A-Fa-f-]+\}`)
 
@@ -45,7 +51,9 @@ const (
 
 // UninstallApp executes the uninstall command for the given application.
 // If quiet is true and a QuietUninstallString is available, it is preferred.
-// The process is given a 120-second timeout.
+// The process is given a 120-second timeout. An uninstall that worked but
+// needs a restart returns ErrRebootRequired, and an Edge uninstall whose
+// preparation failed returns an *EdgePrepError.
 func UninstallApp(app InstalledApp, quiet bool) error {
 	cmdStr := chooseUninstallCommand(app, quiet)
 	if cmdStr == "" {
@@ -60,16 +68,17 @@ func UninstallApp(app InstalledApp, quiet bool) error {
 
 	// Edge requires registry preparation before uninstall can proceed.
 	if installerType == InstallerEdge {
-		if err := prepareEdgeUninstall(); err != nil {
-			return fmt.Errorf("failed to prepare Edge uninstall: %w", err)
+		prep, err := prepareEdgeUninstall()
+		if err != nil {
+			return &EdgePrepError{Prep: prep, Err: err}
 		}
 		// Run the uninstall. On failure: clean up stub AND restart Edge services
 		// so Edge isn't left in a broken state. On success: stub MUST remain to
 		// prevent Windows from re-provisioning Edge on future updates.
 		uninstallErr := runUninstallCommand(cmdStr, installerType, quiet)
-		if uninstallErr != nil {
+		if uninstallErr != nil && !errors.Is(uninstallErr, ErrRebootRequired) {
 			cleanupEdgeStub()
-			restartEdgeServices()
+			restartEdgeServices(edgeControl, prep.stoppedServices)
 		}
 		return uninstallErr
 	}
@@ -281,119 +290,155 @@ func runMSIUninstall(cmdStr string, quiet bool) error {
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "msiexec.exe", args...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return handleExitError(err, output)
-	}
-	return nil
+	return runLogged(cmd)
 }
 
 // prepareEdgeUninstall sets required registry keys and stub files to allow Edge removal.
 // Without this, Edge's setup.exe returns exit code 93 (uninstall blocked).
 // Based on the proven approach used by Win11Debloat (10k+ stars), ChrisTitusTech/winutil
 // (47k+ stars), RyTuneX, and other production tools.
-func prepareEdgeUninstall() error {
-	// 1. Stop Edge Update services first — the SCM can restart killed processes.
-	edgeServices := []string{"edgeupdate", "edgeupdatem"}
-	for _, svc := range edgeServices {
-		svcCtx, svcCancel := context.WithTimeout(context.Background(), 10*time.Second)
-		_ = exec.CommandContext(svcCtx, "sc", "stop", svc).Run()
-		svcCancel()
-	}
-
-	// 2. Kill all Edge processes — they hold file locks and registry handles.
-	edgeProcesses := []string{"msedge.exe", "msedgewebview2.exe", "MicrosoftEdgeUpdate.exe"}
-	for _, proc := range edgeProcesses {
-		killCtx, killCancel := context.WithTimeout(context.Background(), 10*time.Second)
-		_ = exec.CommandContext(killCtx, "taskkill", "/F", "/IM", proc, "/T").Run()
-		killCancel()
-	}
-
-	// 3. Create EdgeUpdateDev key with AllowUninstall (empty string value).
-	// Must use WOW6432Node path since Edge installer is 32-bit.
-	// This is the CRITICAL step — if it fails, restart Edge services so we don't leave
-	// Edge in a broken state (processes killed but registry unchanged).
-	devKey, _, err := registry.CreateKey(
-		registry.LOCAL_MACHINE,
-		`SOFTWARE\WOW6432Node\Microsoft\EdgeUpdateDev`,
-		registry.SET_VALUE,
-	)
-	if err != nil {
-		restartEdgeServices()
-		return fmt.Errorf("failed to create EdgeUpdateDev key (need admin): %w", err)
-	}
-	if err := devKey.SetStringValue("AllowUninstall", ""); err != nil {
-		devKey.Close()
-		restartEdgeServices()
-		return fmt.Errorf("failed to set AllowUninstall: %w", err)
-	}
-	devKey.Close()
+// The returned EdgePrep records every step, including those a critical
+// failure kept from running.
+func prepareEdgeUninstall() (EdgePrep, error) {
+	var prep EdgePrep
+	fail := func(err error) (EdgePrep, error) {
+		prep.skipRemaining()
+		restartEdgeServices(edgeControl, prep.stoppedServices)
+		prep.RolledBack = true
+		return prep, err
+	}
+
+	// 1. Stop Edge services first — the SCM can restart killed processes.
+	stopped, err := stopEdgeServices(edgeControl)
+	prep.stoppedServices = stopped
+	prep.record(edgeStepServices, false, err)
+
+	// 2. Kill Edge and WebView2 processes — they hold file locks and registry handles.
+	prep.record(edgeStepProcesses, false, closeEdgeProcesses(edgeControl))
+
+	// 3. Allow the uninstall. This is the CRITICAL step — if it fails,
+	// restart Edge services so we don't leave Edge in a broken state
+	// (processes killed but registry unchanged).
+	if err := allowEdgeUninstall(); err != nil {
+		prep.record(edgeStepAllowUninstall, true, err)
+		return fail(err)
+	}
+	prep.record(edgeStepAllowUninstall, true, nil)
 
 	// 4. Prevent Edge from reinstalling via Windows Update.
-	euKey, _, err := registry.CreateKey(
-		registry.LOCAL_MACHINE,
-		`SOFTWARE\Microsoft\EdgeUpdate`,
-		registry.SET_VALUE,
-	)
+	euKey, _, err := registry.CreateKey(registry.LOCAL_MACHINE, edgeUpdateKey, registry.SET_VALUE)
 	if err == nil {
-		_ = euKey.SetDWordValue("DoNotUpdateToEdgeWithChromium", 1) // Best effort.
+		err = euKey.SetDWordValue("DoNotUpdateToEdgeWithChromium", 1)
 		euKey.Close()
 	}
+	prep.record(edgeStepNoReinstall, false, err)
 
 	// 5. Remove NoRemove flag from Edge uninstall key (allows uninstall button in Settings too).
-	edgeUninstallKey, err := registry.OpenKey(
-		registry.LOCAL_MACHINE,
-		`SOFTWARE\WOW6432Node\Microsoft\Windows\CurrentVersion\Uninstall\Microsoft Edge`,
-		registry.SET_VALUE,
-	)
+	// A missing key or value already allows removal.
+	uninstKey, err := registry.OpenKey(registry.LOCAL_MACHINE, edgeUninstallKey, registry.SET_VALUE)
 	if err == nil {
-		_ = edgeUninstallKey.DeleteValue("NoRemove") // Best effort.
-		edgeUninstallKey.Close()
+		err = uninstKey.DeleteValue("NoRemove")
+		uninstKey.Close()
 	}
+	if errors.Is(err, registry.ErrNotExist) {
+		err = nil
+	}
+	prep.record(edgeStepNoRemove, false, err)
 
 	// 6. Create Edge UWP stub directory + file.
 	// This tricks the Chromium Edge uninstaller into thinking the legacy Edge UWP app exists,
 	// which is a prerequisite for the uninstaller to proceed.
-	sysRoot := os.Getenv("SystemRoot")
-	if sysRoot == "" {
-		restartEdgeServices()
-		return fmt.Errorf("SystemRoot environment variable is not set")
+	stubFile, err := edgeStubPath()
+	if err != nil {
+		prep.record(edgeStepStub, true, err)
+		return fail(err)
 	}
-	stubDir := filepath.Join(sysRoot, "SystemApps", "Microsoft.MicrosoftEdge_8wekyb3d8bbwe")
-	_ = os.MkdirAll(stubDir, 0o755) // Best effort — may already exist.
-	stubFile := filepath.Join(stubDir, "MicrosoftEdge.exe")
-	if _, statErr := os.Stat(stubFile); os.IsNotExist(statErr) {
-		_ = os.WriteFile(stubFile, []byte{}, 0o644) // Empty stub file.
+	err = os.MkdirAll(filepath.Dir(stubFile), 0o755) // May already exist.
+	if _, statErr := os.Stat(stubFile); err == nil && os.IsNotExist(statErr) {
+		err = os.WriteFile(stubFile, []byte{}, 0o644) // Empty stub file.
 	}
+	prep.record(edgeStepStub, false, err)
+
+	return prep, nil
+}
+
+// allowEdgeUninstall runs the critical preparation step. Tests swap it to
+// exercise the rollback without touching the registry.
+var allowEdgeUninstall = setEdgeAllowUninstall
 
+// setEdgeAllowUninstall creates the EdgeUpdateDev key with AllowUninstall
+// (empty string value). Must use the WOW6432Node path since the Edge
+// installer is 32-bit.
+func setEdgeAllowUninstall() error {
+	devKey, _, err := registry.CreateKey(registry.LOCAL_MACHINE, edgeUpdateDevKey, registry.SET_VALUE)
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return fmt.Errorf("couldn't create EdgeUpdateDev key — not admin: %w", err)
+		}
+		return fmt.Errorf("couldn't create EdgeUpdateDev key: %w", err)
+	}
+	err = devKey.SetStringValue("AllowUninstall", "")
+	devKey.Close()
+	if err != nil {
+		return fmt.Errorf("couldn't set AllowUninstall: %w", err)
+	}
 	return nil
 }
 
-// restartEdgeServices attempts to restart Edge Update services after a failed preparation.
-// Best effort — prevents leaving Edge in a broken state (services stopped but uninstall not proceeding).
-func restartEdgeServices() {
-	edgeServices := []string{"edgeupdate", "edgeupdatem"}
-	for _, svc := range edgeServices {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		_ = exec.CommandContext(ctx, "sc", "start", svc).Run()
-		cancel()
+// restartEdgeServices attempts to restart the Edge services that
+// preparation stopped, after it or the uninstall failed. Services that were
+// not running beforehand stay stopped. Best effort — prevents leaving Edge
+// in a broken state (services stopped but uninstall not proceeding).
+func restartEdgeServices(ctl edgeController, services []string) {
+	for _, svc := range services {
+		_ = ctl.StartService(svc)
 	}
 }
 
 // cleanupEdgeStub removes ONLY the zero-byte stub file created by prepareEdgeUninstall.
 // Does NOT use RemoveAll to avoid destroying pre-existing UWP Edge files on rollback.
 func cleanupEdgeStub() {
-	sysRoot := os.Getenv("SystemRoot")
-	if sysRoot == "" {
+	stub, err := edgeStubPath()
+	if err != nil {
 		return // Cannot determine path safely — leave as-is.
 	}
-	stub := filepath.Join(sysRoot, "SystemApps", "Microsoft.MicrosoftEdge_8wekyb3d8bbwe", "MicrosoftEdge.exe")
 	info, err := os.Stat(stub)
 	if err == nil && info.Size() == 0 {
-		_ = os.Remove(stub) // Only remove if it's our zero-byte stub, not a real executable.
+		// Only remove if it's our zero-byte stub, not a real executable.
+		logBestEffort("remove stub "+stub, os.Remove(stub))
 	}
 }
 
+// edgeHelperTimeout bounds each sc/taskkill call made around an Edge
+// uninstall.
+const edgeHelperTimeout = 10 * time.Second
+
+// runBestEffort runs a helper command whose failure does not stop the
+// uninstall, logging what it printed at debug level. taskkill, for one,
+// fails whenever the process was not running.
+func runBestEffort(name string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), edgeHelperTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, name, args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		core.LogDebug("edge: %s: %v\n%s", cmd.String(), err, strings.TrimSpace(string(output)))
+		return err
+	}
+	core.LogDebug("edge: %s", cmd.String())
+	return nil
+}
+
+// logBestEffort logs the outcome of an Edge preparation step that is
+// allowed to fail.
+func logBestEffort(step string, err error) {
+	if err != nil {
+		core.LogDebug("edge: %s failed: %v", step, err)
+		return
+	}
+	core.LogDebug("edge: %s", step)
+}
+
 // runUninstallCommand runs an arbitrary uninstall command.
 // This is the CRITICAL FIX for the Logseq bug: we parse the command string properly
 // instead of passing it raw to cmd.exe, which allows quoted paths with spaces to work.
@@ -412,11 +457,26 @@ func runUninstallCommand(cmdStr string, installerType InstallerType, quiet bool)
 
 	// Execute the command directly (NOT via cmd.exe /C).
 	cmd := exec.CommandContext(ctx, exe, args...)
+	return runLogged(cmd)
+}
+
+// runLogged runs an uninstall command, logging its command line and how
+// it ended, and translates its exit status with handleExitError.
+func runLogged(cmd *exec.Cmd) error {
+	core.LogInfo("uninstall: running %s", cmd.String())
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return handleExitError(err, output)
+		err = handleExitError(err, output)
 	}
-	return nil
+	switch {
+	case errors.Is(err, ErrRebootRequired):
+		core.LogWarn("uninstall: %s: %v", filepath.Base(cmd.Path), err)
+	case err != nil:
+		core.LogError("uninstall: %s: %v", cmd.String(), err)
+	default:
+		core.LogDebug("uninstall: %s finished", filepath.Base(cmd.Path))
+	}
+	return err
 }
 
 // handleExitError wraps an exec error with contextual information.
@@ -435,8 +495,8 @@ func handleExitError(err error, output []byte) error {
 		case 1605:
 			return fmt.Errorf("product is not currently installed (exit code 1605)")
 		case 1641, 3010:
-			// Restart required but uninstall itself succeeded — not an error.
-			return nil
+			// Restart required but uninstall itself succeeded.
+			return ErrRebootRequired
 		default:
 			outputStr := strings.TrimSpace(string(output))
 			if len(outputStr) > 200 {
@@ -1,6 +1,8 @@
 package update
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,6 +24,10 @@ const (
 
 	// UpdateCheckInterval is how often to check for updates (24 hours)
 	UpdateCheckInterval = 24 * time.Hour
+
+	// backgroundCheckTimeout bounds the opportunistic check so it never
+	// holds up a command.
+	backgroundCheckTimeout = 5 * time.Second
 )
 
 // ReleaseInfo holds information about a GitHub release.
@@ -48,53 +54,150 @@ type UpdateCheckCache struct {
 	DownloadURL   string    `json:"download_url"`
 }
 
-// CheckForUpdate checks GitHub for the latest release.
-// Returns the latest version, download URL, and any error.
-func CheckForUpdate(currentVersion string) (latestVersion string, downloadURL string, err error) {
-	// Normalize version strings (remove 'v' prefix if present)
-	currentVersion = strings.TrimPrefix(currentVersion, "v")
+// checksumAssetNames are the release assets that may carry SHA-256 sums for
+// every binary. A per-asset "<name>.sha256" file is also accepted.
+var checksumAssetNames = []string{"checksums.txt", "SHA256SUMS", "sha256sums.txt"}
+
+// LatestRelease describes the newest GitHub release and the asset that
+// matches this platform.
+type LatestRelease struct {
+	Version     string
+	AssetName   string
+	DownloadURL string
+	ChecksumURL string // empty when the release publishes no checksums
+}
+
+// CheckLatest queries GitHub for the latest release and locates the
+// platform binary and its checksum file.
+func CheckLatest() (*LatestRelease, error) {
+	return checkLatest(30 * time.Second)
+}
 
+func checkLatest(timeout time.Duration) (*LatestRelease, error) {
 	// Make HTTP request to GitHub API
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: timeout}
 	resp, err := client.Get(GitHubAPIURL)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to fetch release info: %w", err)
+		return nil, fmt.Errorf("failed to fetch release info: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", "", fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
 	}
 
 	// Parse response
 	var release ReleaseInfo
 	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return "", "", fmt.Errorf("failed to parse release info: %w", err)
+		return nil, fmt.Errorf("failed to parse release info: %w", err)
 	}
 
-	latestVersion = strings.TrimPrefix(release.TagName, "v")
+	latest := &LatestRelease{Version: strings.TrimPrefix(release.TagName, "v")}
 
 	// Find the appropriate asset for this platform.
 	// Try multiple naming conventions since releases may use either.
 	assetNames := getAssetNamesForPlatform()
-	for _, asset := range release.Assets {
-		for _, name := range assetNames {
-			if strings.EqualFold(asset.Name, name) {
-				downloadURL = asset.BrowserDownloadURL
-				break
-			}
-		}
-		if downloadURL != "" {
+	for _, name := range assetNames {
+		if asset := findAsset(release.Assets, name); asset != nil {
+			latest.AssetName = asset.Name
+			latest.DownloadURL = asset.BrowserDownloadURL
 			break
 		}
 	}
 
-	if downloadURL == "" {
-		return "", "", fmt.Errorf("no asset found for platform %s/%s (tried: %s)",
+	if latest.DownloadURL == "" {
+		return nil, fmt.Errorf("no asset found for platform %s/%s (tried: %s)",
 			runtime.GOOS, runtime.GOARCH, strings.Join(assetNames, ", "))
 	}
 
-	return latestVersion, downloadURL, nil
+	// Prefer a dedicated checksum for the asset, then a combined sums file.
+	for _, name := range append([]string{latest.AssetName + ".sha256"}, checksumAssetNames...) {
+		if asset := findAsset(release.Assets, name); asset != nil {
+			latest.ChecksumURL = asset.BrowserDownloadURL
+			break
+		}
+	}
+
+	return latest, nil
+}
+
+// findAsset returns the asset with the given name (case-insensitive), or nil.
+func findAsset(assets []Asset, name string) *Asset {
+	for i := range assets {
+		if strings.EqualFold(assets[i].Name, name) {
+			return &assets[i]
+		}
+	}
+	return nil
+}
+
+// VerifyChecksum downloads the checksum file at checksumURL and checks that
+// the SHA-256 of the file at path matches the entry for assetName.
+func VerifyChecksum(path, checksumURL, assetName string) error {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(checksumURL)
+	if err != nil {
+		return fmt.Errorf("failed to download checksums: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("checksum download failed with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read checksums: %w", err)
+	}
+
+	want, err := parseChecksum(string(data), assetName)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open download: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to hash download: %w", err)
+	}
+
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, want) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", assetName, want, got)
+	}
+	return nil
+}
+
+// parseChecksum extracts the SHA-256 for assetName from a checksum file.
+// It accepts sha256sum output ("<hash>  <name>" or "<hash> *<name>") and
+// single-hash files that contain only the digest.
+func parseChecksum(data, assetName string) (string, error) {
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 1 && isSHA256(fields[0]):
+			return fields[0], nil
+		case len(fields) >= 2 && isSHA256(fields[0]):
+			name := strings.TrimPrefix(fields[len(fields)-1], "*")
+			if strings.EqualFold(name, assetName) {
+				return fields[0], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no checksum found for %s", assetName)
+}
+
+// isSHA256 reports whether s looks like a hex-encoded SHA-256 digest.
+func isSHA256(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
 }
 
 // CheckForUpdateBackground performs a non-blocking update check and caches the result.
@@ -110,7 +213,7 @@ func CheckForUpdateBackground(currentVersion string, cacheDir string) {
 		}
 
 		// Perform the check
-		latestVersion, downloadURL, err := CheckForUpdate(currentVersion)
+		latest, err := checkLatest(backgroundCheckTimeout)
 		if err != nil {
 			return
 		}
@@ -118,13 +221,27 @@ func CheckForUpdateBackground(currentVersion string, cacheDir string) {
 		// Save to cache
 		newCache := UpdateCheckCache{
 			LastCheck:     time.Now(),
-			LatestVersion: latestVersion,
-			DownloadURL:   downloadURL,
+			LatestVersion: latest.Version,
+			DownloadURL:   latest.DownloadURL,
 		}
 		_ = saveUpdateCache(cachePath, newCache)
 	}()
 }
 
+// AvailableUpdate returns the cached latest version if it is newer than
+// currentVersion, or "" when there is nothing to report. It never touches
+// the network.
+func AvailableUpdate(currentVersion string, cacheDir string) string {
+	cache, err := loadUpdateCache(filepath.Join(cacheDir, UpdateCheckCacheFile))
+	if err != nil || cache.LatestVersion == "" {
+		return ""
+	}
+	if !IsNewerVersion(currentVersion, cache.LatestVersion) {
+		return ""
+	}
+	return cache.LatestVersion
+}
+
 // loadUpdateCache reads the cached update check result.
 func loadUpdateCache(path string) (*UpdateCheckCache, error) {
 	data, err := os.ReadFile(path)
@@ -0,0 +1,33 @@
+package update
+
+import "testing"
+
+func TestParseChecksum(t *testing.T) {
+	const sum = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	other := "0000000000000000000000000000000000000000000000000000000000000000"
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"sha256sum format", other + "  pw_linux_amd64\n" + sum + "  pw.exe\n", false},
+		{"binary marker", sum + " *PW.EXE\n", false},
+		{"single digest", sum + "\n", false},
+		{"missing asset", other + "  other.exe\n", true},
+		{"garbage", "not a checksum file", true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseChecksum(tt.data, "pw.exe")
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %q", tt.name, got)
+			}
+			continue
+		}
+		if err != nil || got != sum {
+			t.Errorf("%s: got (%q, %v), want %q", tt.name, got, err, sum)
+		}
+	}
+}
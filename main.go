@@ -15,7 +15,5 @@ var (
 
 func main() {
 	cmd.SetVersionInfo(version, commit, date)
-	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
-	}
+	os.Exit(cmd.Execute())
 }
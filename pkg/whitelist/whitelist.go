@@ -2,8 +2,12 @@ package whitelist
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	pathpkg "path"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -19,6 +23,23 @@ var defaultPatterns = []string{
 	`%APPDATA%\Code\User\*`,
 }
 
+// DefaultProtections are sensitive locations that cleanup should never
+// touch: SSH and GPG keys, git credential stores, Windows credential
+// vaults, and password-manager data. Merge them in with MergeDefaults.
+var DefaultProtections = []string{
+	`%USERPROFILE%\.ssh\*`,
+	`%USERPROFILE%\.gnupg\*`,
+	`%USERPROFILE%\.gnupg\private-keys-v1.d`,
+	`%USERPROFILE%\.config\git\credentials`,
+	`%APPDATA%\Microsoft\Credentials`,
+	`%LOCALAPPDATA%\Microsoft\Credentials`,
+	`%APPDATA%\Microsoft\Protect`,
+	`%APPDATA%\Bitwarden\*`,
+	`%LOCALAPPDATA%\1Password\data`,
+	`%APPDATA%\KeePass\*`,
+	`%APPDATA%\KeePassXC\*`,
+}
+
 // Whitelist manages a set of glob patterns representing paths that
 // should be excluded from cleanup operations.
 type Whitelist struct {
@@ -170,39 +191,93 @@ func (w *Whitelist) Remove(pattern string) error {
 }
 
 // IsWhitelisted returns true if the given path matches any whitelist
-// pattern. Environment variables in patterns are expanded before matching.
+// pattern. Environment variables in patterns are expanded before matching,
+// and matching is case-insensitive.
+//
+// Literal patterns protect the path itself and everything beneath it. Glob
+// patterns are matched against the path and each of its ancestors, so
+// `C:\Users\*\.ssh` also protects files inside every .ssh folder. A glob
+// that is not rooted (no drive, UNC prefix, or leading separator), such as
+// `*\node_modules\.cache`, matches those trailing segments at any depth.
 func (w *Whitelist) IsWhitelisted(path string) bool {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
 
-	cleaned := filepath.Clean(path)
+	candidate := normalizePath(path)
 
 	for _, pattern := range w.patterns {
-		expanded := envutil.ExpandWindowsEnv(pattern)
-		expanded = filepath.Clean(expanded)
-
-		// Exact match (case-insensitive).
-		if strings.EqualFold(cleaned, expanded) {
+		if compileEntry(pattern).matches(candidate) {
 			return true
 		}
+	}
+
+	return false
+}
+
+// entry is a whitelist pattern prepared for matching.
+type entry struct {
+	expr     string // expanded, forward-slashed, lowercased pattern
+	glob     bool   // expr contains glob metacharacters
+	anchored bool   // expr starts at a drive, UNC share, or root
+	segments int    // number of path segments in expr
+}
+
+// compileEntry expands and normalizes a raw pattern.
+func compileEntry(pattern string) entry {
+	expr := normalizePath(envutil.ExpandWindowsEnv(pattern))
+	return entry{
+		expr:     expr,
+		glob:     strings.ContainsAny(expr, "*?["),
+		anchored: strings.HasPrefix(expr, "/") || (len(expr) >= 2 && expr[1] == ':'),
+		segments: strings.Count(expr, "/") + 1,
+	}
+}
+
+// normalizePath converts p to a lowercase, forward-slashed, cleaned form so
+// matching behaves the same for either separator and any letter case.
+// Backslashes are never glob escapes in a Windows path.
+func normalizePath(p string) string {
+	p = strings.ReplaceAll(strings.TrimSpace(p), `\`, "/")
+	return strings.ToLower(pathpkg.Clean(p))
+}
+
+// matches reports whether the normalized candidate is protected by e.
+func (e entry) matches(candidate string) bool {
+	if e.expr == "." {
+		return false
+	}
+
+	if !e.glob {
+		return candidate == e.expr || strings.HasPrefix(candidate, strings.TrimSuffix(e.expr, "/")+"/")
+	}
 
-		// Glob match.
-		matched, err := filepath.Match(strings.ToLower(expanded), strings.ToLower(cleaned))
-		if err == nil && matched {
+	// Walk from the candidate up through its ancestors.
+	for p := candidate; ; {
+		target := p
+		if !e.anchored {
+			target = lastSegments(p, e.segments)
+		}
+		if matched, err := pathpkg.Match(e.expr, target); err == nil && matched {
 			return true
 		}
-
-		// Prefix match: if the pattern is a directory (no glob chars),
-		// check if path is under it.
-		if !strings.ContainsAny(expanded, "*?[") {
-			prefix := strings.ToLower(expanded) + string(os.PathSeparator)
-			if strings.HasPrefix(strings.ToLower(cleaned)+string(os.PathSeparator), prefix) {
-				return true
-			}
+		parent := pathpkg.Dir(p)
+		if parent == p || parent == "." {
+			return false
 		}
+		p = parent
 	}
+}
 
-	return false
+// lastSegments returns the final n slash-separated segments of p.
+func lastSegments(p string, n int) string {
+	idx := len(p)
+	for ; n > 0; n-- {
+		idx = strings.LastIndex(p[:idx], "/")
+		if idx < 0 {
+			return p
+		}
+	}
+	return p[idx+1:]
 }
 
 // List returns a copy of all current whitelist patterns.
@@ -214,3 +289,107 @@ func (w *Whitelist) List() []string {
 	copy(result, w.patterns)
 	return result
 }
+
+// MergeDefaults adds every DefaultProtections pattern not already present
+// and returns how many were added.
+func (w *Whitelist) MergeDefaults() int {
+	added := 0
+	for _, p := range DefaultProtections {
+		if w.Add(p) == nil {
+			added++
+		}
+	}
+	return added
+}
+
+// Export writes the patterns to dst in the same one-per-line format as the
+// whitelist file, so the output can be shared and re-imported.
+func (w *Whitelist) Export(dst io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("# PureWin whitelist export — one glob pattern per line\n")
+	for _, p := range w.List() {
+		sb.WriteString(p + "\n")
+	}
+	if _, err := io.WriteString(dst, sb.String()); err != nil {
+		return fmt.Errorf("cannot write whitelist export: %w", err)
+	}
+	return nil
+}
+
+// Import reads patterns from r and adds those not already present. The
+// input is either one pattern per line ("#" comments allowed), a JSON array
+// of strings, or a JSON object with a "patterns" array. Every pattern is
+// validated like Add; invalid ones are reported together while the valid
+// ones are still added. Returns the number of patterns added.
+func (w *Whitelist) Import(r io.Reader) (int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read whitelist import: %w", err)
+	}
+
+	patterns, err := parseImport(data)
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	var errs []error
+	for _, p := range patterns {
+		if err := validatePattern(p); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if w.Add(p) == nil {
+			added++
+		}
+	}
+	return added, errors.Join(errs...)
+}
+
+// parseImport extracts patterns from line-based or JSON import data.
+func parseImport(data []byte) ([]string, error) {
+	trimmed := strings.TrimSpace(string(data))
+
+	switch {
+	case strings.HasPrefix(trimmed, "["):
+		var patterns []string
+		if err := json.Unmarshal([]byte(trimmed), &patterns); err != nil {
+			return nil, fmt.Errorf("invalid whitelist JSON: %w", err)
+		}
+		return nonEmpty(patterns), nil
+
+	case strings.HasPrefix(trimmed, "{"):
+		var doc struct {
+			Patterns []string `json:"patterns"`
+		}
+		if err := json.Unmarshal([]byte(trimmed), &doc); err != nil {
+			return nil, fmt.Errorf("invalid whitelist JSON: %w", err)
+		}
+		return nonEmpty(doc.Patterns), nil
+	}
+
+	var patterns []string
+	scanner := bufio.NewScanner(strings.NewReader(trimmed))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading whitelist import: %w", err)
+	}
+	return patterns, nil
+}
+
+// nonEmpty trims each pattern and drops blanks.
+func nonEmpty(patterns []string) []string {
+	result := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
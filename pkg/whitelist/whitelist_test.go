@@ -93,6 +93,39 @@ func TestWhitelist_IsWhitelistedGlob(t *testing.T) {
 	}
 }
 
+func TestWhitelist_IsWhitelistedPatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		// Literal path protects itself and its contents only.
+		{`C:\Users\test\keep`, `C:\Users\test\keep\a\b.txt`, true},
+		{`C:\Users\test\keep`, `C:\Users\test\keeper\b.txt`, false},
+		{`C:\Users\test\keep`, `C:\Users\test`, false},
+
+		// Rooted glob protects matches and everything under them.
+		{`C:\Users\*\.ssh`, `C:\Users\alice\.ssh`, true},
+		{`C:\Users\*\.ssh`, `c:\users\BOB\.SSH\id_ed25519`, true},
+		{`C:\Users\*\.ssh`, `C:\Users\alice\.sshx\id_rsa`, false},
+		{`C:\Users\*\.ssh`, `C:\Users\alice\nested\.ssh\id_rsa`, false},
+		{`C:\Users\*\.ssh`, `D:\Users\alice\.ssh\id_rsa`, false},
+
+		// Unrooted glob matches trailing segments at any depth.
+		{`*\node_modules\.cache`, `C:\src\app\node_modules\.cache\babel\x.json`, true},
+		{`*\node_modules\.cache`, `D:\node_modules\.cache`, true},
+		{`*\node_modules\.cache`, `C:\src\app\node_modules\lodash\index.js`, false},
+		{`*\node_modules\.cache`, `C:\src\app\node_modules\.cache-old`, false},
+	}
+
+	for _, tt := range tests {
+		w := &Whitelist{patterns: []string{tt.pattern}}
+		if got := w.IsWhitelisted(tt.path); got != tt.want {
+			t.Errorf("pattern %q, IsWhitelisted(%q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
 func TestWhitelist_SaveAndLoad(t *testing.T) {
 	dir := t.TempDir()
 	fpath := filepath.Join(dir, "whitelist.txt")
@@ -173,3 +206,66 @@ func TestValidatePattern_RejectsDangerous(t *testing.T) {
 		}
 	}
 }
+
+func TestWhitelist_ExportImportRoundTrip(t *testing.T) {
+	src := &Whitelist{patterns: []string{
+		`C:\Users\test\AppData\Local\keep`,
+		`%APPDATA%\Code\User\*`,
+	}}
+
+	var buf strings.Builder
+	if err := src.Export(&buf); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	dst := &Whitelist{patterns: []string{`%APPDATA%\Code\User\*`}}
+	added, err := dst.Import(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if added != 1 {
+		t.Errorf("Import added %d patterns, want 1 (duplicate skipped)", added)
+	}
+	if len(dst.List()) != 2 {
+		t.Errorf("expected 2 patterns after import, got %v", dst.List())
+	}
+}
+
+func TestWhitelist_ImportJSON(t *testing.T) {
+	inputs := []string{
+		`["C:\\Users\\test\\keep\\a", "C:\\Users\\test\\keep\\b"]`,
+		`{"patterns": ["C:\\Users\\test\\keep\\a", "C:\\Users\\test\\keep\\b"]}`,
+	}
+	for _, in := range inputs {
+		w := &Whitelist{patterns: make([]string, 0)}
+		added, err := w.Import(strings.NewReader(in))
+		if err != nil {
+			t.Fatalf("Import(%s) failed: %v", in, err)
+		}
+		if added != 2 {
+			t.Errorf("Import(%s) added %d, want 2", in, added)
+		}
+	}
+}
+
+func TestWhitelist_ImportReportsInvalid(t *testing.T) {
+	w := &Whitelist{patterns: make([]string, 0)}
+	added, err := w.Import(strings.NewReader("C:\\\n*\nC:\\Users\\test\\keep\n"))
+	if err == nil {
+		t.Error("Import with dangerous patterns should return an error")
+	}
+	if added != 1 {
+		t.Errorf("Import added %d, want 1 valid pattern", added)
+	}
+}
+
+func TestWhitelist_MergeDefaults(t *testing.T) {
+	w := &Whitelist{patterns: make([]string, 0)}
+	if added := w.MergeDefaults(); added != len(DefaultProtections) {
+		t.Errorf("MergeDefaults added %d, want %d (every default must pass validation)",
+			added, len(DefaultProtections))
+	}
+	if added := w.MergeDefaults(); added != 0 {
+		t.Errorf("second MergeDefaults added %d, want 0", added)
+	}
+}